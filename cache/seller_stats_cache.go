@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"db/model"
+	"sync"
+	"time"
+)
+
+// SellerStatsCache : 出品者の実績のインメモリキャッシュ
+// 購入・メッセージ送信で該当の出品者を無効化する。それ以外の変化(出品など)はttlで反映する
+// 無効化のたびに出品者ごとの世代を進め、計算中に無効化された古い実績を保存しないようにする
+type SellerStatsCache struct {
+	mu          sync.RWMutex
+	data        map[string]sellerStatsEntry
+	generations map[string]uint64
+	ttl         time.Duration
+}
+
+type sellerStatsEntry struct {
+	stats     model.SellerStats
+	expiresAt time.Time
+}
+
+// NewSellerStatsCache : キャッシュの初期化 (ttlは1件あたりの有効期間)
+func NewSellerStatsCache(ttl time.Duration) *SellerStatsCache {
+	return &SellerStatsCache{
+		data:        make(map[string]sellerStatsEntry),
+		generations: make(map[string]uint64),
+		ttl:         ttl,
+	}
+}
+
+// Get : 有効期限内の実績を取得 (コピーを返す)
+func (c *SellerStatsCache) Get(sellerID string) (*model.SellerStats, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.data[sellerID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	stats := entry.stats
+	return &stats, true
+}
+
+// Generation : 出品者の実績の現在の世代 (実績の計算を始める前に取得し、Setに渡す)
+func (c *SellerStatsCache) Generation(sellerID string) uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.generations[sellerID]
+}
+
+// Set : 実績を保存 (generationを取得した後に無効化されていれば、計算前のデータによる実績なので保存しない)
+func (c *SellerStatsCache) Set(sellerID string, generation uint64, stats *model.SellerStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[sellerID] != generation {
+		return
+	}
+	c.data[sellerID] = sellerStatsEntry{stats: *stats, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// Invalidate : 出品者の実績を破棄し世代を進める (次の取得で計算し直す)
+func (c *SellerStatsCache) Invalidate(sellerID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.data, sellerID)
+	c.generations[sellerID]++
+}
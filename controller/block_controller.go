@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"db/middleware"
+	"db/model"
+	"db/usecase"
+	"errors"
+	"net/http"
+)
+
+// BlockController : ユーザーのブロックを扱うコントローラ
+type BlockController struct {
+	blockUsecase usecase.BlockUsecase
+}
+
+func NewBlockController(u usecase.BlockUsecase) *BlockController {
+	return &BlockController{blockUsecase: u}
+}
+
+// HandleBlock : ユーザーをブロック (PUT /users/{id}/block)
+func (c *BlockController) HandleBlock(w http.ResponseWriter, r *http.Request) {
+	c.handleBlockAction(w, r, c.blockUsecase.Block)
+}
+
+// HandleUnblock : ブロックを解除 (DELETE /users/{id}/block)
+func (c *BlockController) HandleUnblock(w http.ResponseWriter, r *http.Request) {
+	c.handleBlockAction(w, r, c.blockUsecase.Unblock)
+}
+
+// handleBlockAction : ブロック・解除の共通処理。操作後の状態を返す
+func (c *BlockController) handleBlockAction(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, blockerID, blockedID string) (*model.BlockState, error)) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	state, err := action(ctx, userID, r.PathValue("id"))
+	if err != nil {
+		respondBlockError(w, "Failed to update block", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, state)
+}
+
+// HandleGetBlockedUsers : ブロック中のユーザー一覧 (GET /users/me/blocks)
+func (c *BlockController) HandleGetBlockedUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	limit, offset := parseHistoryPage(r)
+	users, err := c.blockUsecase.GetBlockedUsers(ctx, userID, limit, offset)
+	if err != nil {
+		respondBlockError(w, "Failed to get blocked users", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"users": users})
+}
+
+// respondBlockError : ブロック系のエラーをステータスコードに変換して返す
+func respondBlockError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, model.ErrUserNotFound):
+		respondError(w, http.StatusNotFound, "User not found", err)
+	case errors.Is(err, model.ErrCannotBlockSelf):
+		respondError(w, http.StatusBadRequest, "Cannot block yourself", err)
+	default:
+		respondError(w, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"db/usecase"
+	"net/http"
+)
+
+// CategoryController : カテゴリ一覧を扱うコントローラ
+type CategoryController struct {
+	categoryUsecase usecase.CategoryUsecase
+}
+
+func NewCategoryController(u usecase.CategoryUsecase) *CategoryController {
+	return &CategoryController{categoryUsecase: u}
+}
+
+// HandleGetCategories : カテゴリの木を商品数つきで取得 (GET /categories)
+func (c *CategoryController) HandleGetCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := c.categoryUsecase.GetCategoryTree(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get categories", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"categories": categories})
+}
@@ -5,15 +5,24 @@ import (
 	"db/model"
 	"db/usecase"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strconv"
+	"time"
 )
 
+// streamHeartbeatInterval : SSE接続を維持するためのハートビート間隔(プロキシのアイドル切断対策)
+const streamHeartbeatInterval = 25 * time.Second
+
 type ChatController struct {
-	chatUsecase usecase.ChatUsecase
+	chatUsecase       usecase.ChatUsecase
+	heartbeatInterval time.Duration
 }
 
 func NewChatController(u usecase.ChatUsecase) *ChatController {
-	return &ChatController{chatUsecase: u}
+	return &ChatController{chatUsecase: u, heartbeatInterval: streamHeartbeatInterval}
 }
 
 // HandleGetOrCreateRoom : チャットルームを開始・取得 (POST /items/{item_id}/chat)
@@ -27,18 +36,10 @@ func (c *ChatController) HandleGetOrCreateRoom(w http.ResponseWriter, r *http.Re
 
 	itemID := r.PathValue("item_id")
 
-	// リクエストボディから出品者IDを取得
-	var req struct {
-		SellerID string `json:"seller_id"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body", err)
-		return
-	}
-
-	room, err := c.chatUsecase.GetOrCreateChatRoom(ctx, itemID, buyerID, req.SellerID)
+	// 出品者は商品情報からusecase側で決定する(リクエストボディのseller_idは使わない)
+	room, err := c.chatUsecase.GetOrCreateChatRoom(ctx, itemID, buyerID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to get or create room", err)
+		respondChatError(w, "Failed to get or create room", err)
 		return
 	}
 
@@ -48,10 +49,42 @@ func (c *ChatController) HandleGetOrCreateRoom(w http.ResponseWriter, r *http.Re
 // HandleGetChatRoomList : チャットルーム一覧を取得 (GET /items/{item_id}/chat_rooms)
 func (c *ChatController) HandleGetChatRoomList(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
 	itemID := r.PathValue("item_id")
-	rooms, err := c.chatUsecase.GetChatRoomList(ctx, itemID)
+	rooms, err := c.chatUsecase.GetChatRoomList(ctx, itemID, userID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to get chat list", err)
+		respondChatError(w, "Failed to get chat list", err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]interface{}{"rooms": rooms})
+}
+
+// HandleGetInbox : 参加中の全チャットルームを取得 (GET /chats)
+func (c *ChatController) HandleGetInbox(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	limit := 20
+	offset := 0
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	if o, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && o >= 0 {
+		offset = o
+	}
+
+	rooms, err := c.chatUsecase.GetInbox(ctx, userID, limit, offset)
+	if err != nil {
+		respondChatError(w, "Failed to get chat inbox", err)
 		return
 	}
 	respondJSON(w, http.StatusOK, map[string]interface{}{"rooms": rooms})
@@ -60,15 +93,35 @@ func (c *ChatController) HandleGetChatRoomList(w http.ResponseWriter, r *http.Re
 // HandleGetMessages : メッセージ一覧を取得 (GET /chats/{room_id}/messages)
 func (c *ChatController) HandleGetMessages(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
 	roomID := r.PathValue("room_id")
 
-	messages, err := c.chatUsecase.GetMessages(ctx, roomID)
+	// カーソル(before/after)とlimitを取得
+	query := model.MessagePageQuery{
+		Before: r.URL.Query().Get("before"),
+		After:  r.URL.Query().Get("after"),
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid limit", err)
+			return
+		}
+		query.Limit = l
+	}
+
+	page, err := c.chatUsecase.GetMessages(ctx, roomID, userID, query)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to get messages", err)
+		respondChatError(w, "Failed to get messages", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{"messages": messages})
+	respondJSON(w, http.StatusOK, page)
 }
 
 // HandleSendMessage : メッセージを送信 (POST /chats/{room_id}/messages)
@@ -89,9 +142,142 @@ func (c *ChatController) HandleSendMessage(w http.ResponseWriter, r *http.Reques
 	}
 
 	if err := c.chatUsecase.SendMessage(ctx, roomID, senderID, req.Content); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to send message", err)
+		respondChatError(w, "Failed to send message", err)
 		return
 	}
 
 	respondJSON(w, http.StatusOK, map[string]string{"message": "sent"})
 }
+
+// HandleMarkRead : ルームを既読にする (PUT /chats/{room_id}/read)
+func (c *ChatController) HandleMarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	roomID := r.PathValue("room_id")
+
+	// ボディは省略可能 (省略時は最新メッセージまで既読)
+	var req model.MarkReadRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body", err)
+			return
+		}
+	}
+
+	if err := c.chatUsecase.MarkRoomRead(ctx, roomID, userID, req.MessageID); err != nil {
+		respondChatError(w, "Failed to mark room as read", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// HandleGetUnreadCounts : ルームごとの未読数と合計を取得 (GET /chats/unread)
+func (c *ChatController) HandleGetUnreadCounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	counts, err := c.chatUsecase.GetUnreadCounts(ctx, userID)
+	if err != nil {
+		respondChatError(w, "Failed to get unread counts", err)
+		return
+	}
+
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	respondJSON(w, http.StatusOK, map[string]interface{}{"unread_count": total, "rooms": counts})
+}
+
+// HandleStreamMessages : 新着メッセージをServer-Sent Eventsで配信 (GET /chats/{room_id}/stream)
+func (c *ChatController) HandleStreamMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondError(w, http.StatusInternalServerError, "Streaming not supported", nil)
+		return
+	}
+
+	roomID := r.PathValue("room_id")
+
+	messages, unsubscribe, err := c.chatUsecase.SubscribeMessages(ctx, roomID, userID)
+	if err != nil {
+		respondChatError(w, "Failed to subscribe messages", err)
+		return
+	}
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(c.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(msg)
+			if err != nil {
+				log.Printf("fail: encode stream message, %v\n", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: message\nid: %s\ndata: %s\n\n", msg.Id, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// respondChatError : チャット系のエラーをステータスコードに変換して返す
+func respondChatError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, model.ErrNotChatMember), errors.Is(err, model.ErrNotAuthorized):
+		respondError(w, http.StatusForbidden, "Forbidden", err)
+	case errors.Is(err, model.ErrChatRoomNotFound):
+		respondError(w, http.StatusNotFound, "Chat room not found", err)
+	case errors.Is(err, model.ErrMessageNotFound):
+		respondError(w, http.StatusNotFound, "Message not found", err)
+	case errors.Is(err, model.ErrItemNotFound):
+		respondError(w, http.StatusNotFound, "Item not found", err)
+	case errors.Is(err, model.ErrInvalidRequest):
+		respondError(w, http.StatusBadRequest, "Invalid request", err)
+	case errors.Is(err, model.ErrCannotChatOwnItem):
+		respondError(w, http.StatusBadRequest, "Cannot chat on own item", err)
+	case errors.Is(err, model.ErrItemNotOnSale):
+		respondError(w, http.StatusConflict, "Item is no longer on sale", err)
+	case errors.Is(err, model.ErrUserBlocked):
+		respondError(w, http.StatusForbidden, "User is blocked", err)
+	default:
+		respondError(w, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"db/hub"
+	"db/middleware"
+	"db/model"
+	"db/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubChatUsecase : ストリーム配信テスト用のusecase (未使用メソッドは埋め込みで省略)
+type stubChatUsecase struct {
+	usecase.ChatUsecase
+	hub  hub.ChatHub
+	room *model.ChatRoom
+}
+
+func (s *stubChatUsecase) SubscribeMessages(ctx context.Context, roomID, userID string) (<-chan model.Message, func(), error) {
+	if !s.room.IsMember(userID) {
+		return nil, nil, model.ErrNotChatMember
+	}
+	return s.hub.Subscribe(ctx, roomID)
+}
+
+// newStreamTestServer : 認証済みユーザーをcontextに詰めてストリームハンドラを呼ぶテストサーバー
+func newStreamTestServer(c *ChatController, userID string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /chats/{room_id}/stream", func(w http.ResponseWriter, r *http.Request) {
+		c.HandleStreamMessages(w, r.WithContext(middleware.WithUserID(r.Context(), userID)))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestChatController_HandleStreamMessages(t *testing.T) {
+	room := &model.ChatRoom{Id: "room1", ItemId: "item1", BuyerId: "buyer1", SellerId: "seller1"}
+
+	t.Run("成功: 新着メッセージとハートビートを受信", func(t *testing.T) {
+		chatHub := hub.NewLocalChatHub()
+		c := NewChatController(&stubChatUsecase{hub: chatHub, room: room})
+		c.heartbeatInterval = 50 * time.Millisecond
+
+		srv := newStreamTestServer(c, "buyer1")
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL + "/chats/room1/stream")
+		if err != nil {
+			t.Fatalf("GET stream error = %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+		if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+			t.Fatalf("Content-Type = %v, want text/event-stream", ct)
+		}
+
+		// ヘッダー受信時点で購読済みなのでPublishは届く
+		msg := model.Message{Id: "msg1", ChatRoomId: "room1", SenderId: "seller1", Content: "hello"}
+		if err := chatHub.Publish(context.Background(), "room1", msg); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+
+		reader := bufio.NewReader(resp.Body)
+		gotMessage, gotHeartbeat := false, false
+		deadline := time.Now().Add(2 * time.Second)
+		for !(gotMessage && gotHeartbeat) && time.Now().Before(deadline) {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("read stream error = %v", err)
+			}
+			if strings.HasPrefix(line, "data: ") && strings.Contains(line, `"content":"hello"`) {
+				gotMessage = true
+			}
+			if strings.HasPrefix(line, ": heartbeat") {
+				gotHeartbeat = true
+			}
+		}
+		if !gotMessage {
+			t.Errorf("stream did not deliver published message")
+		}
+		if !gotHeartbeat {
+			t.Errorf("stream did not send heartbeat")
+		}
+	})
+
+	t.Run("失敗: 当事者以外は403", func(t *testing.T) {
+		c := NewChatController(&stubChatUsecase{hub: hub.NewLocalChatHub(), room: room})
+
+		srv := newStreamTestServer(c, "other")
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL + "/chats/room1/stream")
+		if err != nil {
+			t.Fatalf("GET stream error = %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("status = %v, want %v", resp.StatusCode, http.StatusForbidden)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"db/middleware"
+	"db/model"
+	"db/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// FollowController : フォローとフォロー中の出品者の新着フィードを扱うコントローラ
+type FollowController struct {
+	followUsecase usecase.FollowUsecase
+}
+
+func NewFollowController(u usecase.FollowUsecase) *FollowController {
+	return &FollowController{followUsecase: u}
+}
+
+// HandleFollow : ユーザーをフォロー (PUT /users/{id}/follow)
+func (c *FollowController) HandleFollow(w http.ResponseWriter, r *http.Request) {
+	c.handleFollowAction(w, r, c.followUsecase.Follow)
+}
+
+// HandleUnfollow : フォローを解除 (DELETE /users/{id}/follow)
+func (c *FollowController) HandleUnfollow(w http.ResponseWriter, r *http.Request) {
+	c.handleFollowAction(w, r, c.followUsecase.Unfollow)
+}
+
+// handleFollowAction : フォロー・解除の共通処理。操作後の状態とフォロワー数を返す
+func (c *FollowController) handleFollowAction(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, followerID, followeeID string) (*model.FollowState, error)) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	state, err := action(ctx, userID, r.PathValue("id"))
+	if err != nil {
+		respondFollowError(w, "Failed to update follow", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, state)
+}
+
+// HandleGetFollowers : フォロワー一覧 (GET /users/{id}/followers)
+func (c *FollowController) HandleGetFollowers(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parseHistoryPage(r)
+	users, err := c.followUsecase.GetFollowers(r.Context(), r.PathValue("id"), limit, offset)
+	if err != nil {
+		respondFollowError(w, "Failed to get followers", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"users": users})
+}
+
+// HandleGetFollowing : フォロー中のユーザー一覧 (GET /users/{id}/following)
+func (c *FollowController) HandleGetFollowing(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parseHistoryPage(r)
+	users, err := c.followUsecase.GetFollowing(r.Context(), r.PathValue("id"), limit, offset)
+	if err != nil {
+		respondFollowError(w, "Failed to get following", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"users": users})
+}
+
+// HandleGetFeed : フォロー中の出品者の新着商品 (GET /items/feed?before=&limit=)
+func (c *FollowController) HandleGetFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid limit", err)
+			return
+		}
+		limit = l
+	}
+
+	page, err := c.followUsecase.GetFeed(ctx, userID, r.URL.Query().Get("before"), limit)
+	if err != nil {
+		respondFollowError(w, "Failed to get feed", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, page)
+}
+
+// respondFollowError : フォロー系のエラーをステータスコードに変換して返す
+func respondFollowError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, model.ErrUserNotFound):
+		respondError(w, http.StatusNotFound, "User not found", err)
+	case errors.Is(err, model.ErrCannotFollowSelf):
+		respondError(w, http.StatusBadRequest, "Cannot follow yourself", err)
+	case errors.Is(err, model.ErrUserBlocked):
+		respondError(w, http.StatusForbidden, "User is blocked", err)
+	case errors.Is(err, model.ErrInvalidRequest):
+		respondError(w, http.StatusBadRequest, "Invalid request", err)
+	default:
+		respondError(w, http.StatusInternalServerError, message, err)
+	}
+}
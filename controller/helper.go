@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"db/middleware"
 	"encoding/json"
 	"log"
+	"net"
 	"net/http"
+	"strings"
 )
 
 // ---------------------------------------------------------
@@ -28,3 +31,20 @@ func respondError(w http.ResponseWriter, status int, message string, err error)
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(map[string]string{"error": message})
 }
+
+// clientKey : リクエストの送り主を識別するキー (ログイン中はユーザーID、ゲストは接続元のIP)
+// ロードバランサ経由ではX-Forwarded-Forの末尾(ロードバランサが付けた値)を使う
+func clientKey(r *http.Request) string {
+	if userID, err := middleware.GetUserIDFromContext(r.Context()); err == nil {
+		return "user:" + userID
+	}
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		parts := strings.Split(forwarded, ",")
+		return "ip:" + strings.TrimSpace(parts[len(parts)-1])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
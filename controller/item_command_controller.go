@@ -15,17 +15,20 @@ type ItemCommandController struct {
 	register usecase.ItemRegister
 	update   usecase.ItemUpdate
 	purchase usecase.ItemPurchase
+	withdraw usecase.ItemWithdraw
 }
 
 func NewItemCommandController(
 	register usecase.ItemRegister,
 	update usecase.ItemUpdate,
 	purchase usecase.ItemPurchase,
+	withdraw usecase.ItemWithdraw,
 ) *ItemCommandController {
 	return &ItemCommandController{
 		register: register,
 		update:   update,
 		purchase: purchase,
+		withdraw: withdraw,
 	}
 }
 
@@ -71,12 +74,19 @@ func (c *ItemCommandController) HandleItemPurchase(w http.ResponseWriter, r *htt
 
 	itemID := r.PathValue("id")
 
-	if err := c.purchase.PurchaseItem(ctx, itemID, uid); err != nil {
-		respondError(w, http.StatusBadRequest, "Failed to purchase item", err)
+	orderID, err := c.purchase.PurchaseItem(ctx, itemID, uid)
+	if err != nil {
+		if errors.Is(err, model.ErrItemReserved) {
+			respondError(w, http.StatusConflict, "Item is reserved for another buyer", err)
+		} else if errors.Is(err, model.ErrUserBlocked) {
+			respondError(w, http.StatusForbidden, "User is blocked", err)
+		} else {
+			respondError(w, http.StatusBadRequest, "Failed to purchase item", err)
+		}
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]string{"message": "purchase successful"})
+	respondJSON(w, http.StatusOK, map[string]string{"message": "purchase successful", "order_id": orderID})
 }
 
 // HandleItemUpdate : 商品更新処理 (PUT /items/{id})
@@ -120,6 +130,10 @@ func (c *ItemCommandController) HandleItemUpdate(w http.ResponseWriter, r *http.
 			respondError(w, http.StatusBadRequest, "Cannot update sold item", err)
 			return
 		}
+		if errors.Is(err, model.ErrItemNotFound) {
+			respondError(w, http.StatusNotFound, "Item not found", err)
+			return
+		}
 		if errors.Is(err, model.ErrInvalidUpdateRequest) {
 			respondError(w, http.StatusBadRequest, "Invalid request", err)
 			return
@@ -130,3 +144,36 @@ func (c *ItemCommandController) HandleItemUpdate(w http.ResponseWriter, r *http.
 
 	respondJSON(w, http.StatusOK, map[string]string{"message": "Item updated successfully"})
 }
+
+// HandleItemWithdraw : 出品の取り下げ (DELETE /items/{id})
+func (c *ItemCommandController) HandleItemWithdraw(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	itemID := r.PathValue("id")
+	if itemID == "" {
+		respondError(w, http.StatusBadRequest, "item ID is required", nil)
+		return
+	}
+
+	if err := c.withdraw.WithdrawItem(ctx, itemID, userID); err != nil {
+		switch {
+		case errors.Is(err, model.ErrItemNotFound):
+			respondError(w, http.StatusNotFound, "Item not found", err)
+		case errors.Is(err, model.ErrNotAuthorized):
+			respondError(w, http.StatusForbidden, "Not authorized to withdraw this item", err)
+		case errors.Is(err, model.ErrItemInTransaction):
+			respondError(w, http.StatusConflict, "Cannot withdraw item with an active transaction", err)
+		default:
+			respondError(w, http.StatusInternalServerError, "Failed to withdraw item", err)
+		}
+		return
+	}
+
+	log.Printf("successfully withdrew item: id=%s", itemID)
+	respondJSON(w, http.StatusOK, map[string]string{"message": "item withdrawn"})
+}
@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"context"
 	"db/middleware"
 	"db/model"
 	"db/usecase"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
@@ -11,23 +14,46 @@ import (
 
 // ItemQueryController : 商品情報を取得するコントローラ
 type ItemQueryController struct {
-	list          usecase.ItemList
-	myItemsList   usecase.MyItemsList
-	userItemsList usecase.UserItemsList
-	get           usecase.ItemGet
+	list           usecase.ItemList
+	semanticSearch usecase.ItemSemanticSearch
+	suggest        usecase.ItemSuggest
+	myItemsList    usecase.MyItemsList
+	userItemsList  usecase.UserItemsList
+	get            usecase.ItemGet
+	history        usecase.PurchaseHistory
+	like           usecase.LikeUsecase
 }
 
 func NewItemQueryController(
 	list usecase.ItemList,
+	semanticSearch usecase.ItemSemanticSearch,
+	suggest usecase.ItemSuggest,
 	myItemsList usecase.MyItemsList,
 	userItemsList usecase.UserItemsList,
 	get usecase.ItemGet,
+	history usecase.PurchaseHistory,
+	like usecase.LikeUsecase,
 ) *ItemQueryController {
 	return &ItemQueryController{
-		list:          list,
-		myItemsList:   myItemsList,
-		userItemsList: userItemsList,
-		get:           get,
+		list:           list,
+		semanticSearch: semanticSearch,
+		suggest:        suggest,
+		myItemsList:    myItemsList,
+		userItemsList:  userItemsList,
+		get:            get,
+		history:        history,
+		like:           like,
+	}
+}
+
+// markLikedByMe : ログイン中なら一覧の各商品にいいね済みかどうかを付ける (失敗しても一覧は返す)
+func (c *ItemQueryController) markLikedByMe(ctx context.Context, items []model.ItemSimple) {
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		return
+	}
+	if err := c.like.MarkLikedByMe(ctx, userID, items); err != nil {
+		log.Printf("Warning: failed to mark liked items: %v", err)
 	}
 }
 
@@ -56,23 +82,124 @@ func (c *ItemQueryController) HandleItemList(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	var items []model.ItemSimple
-	var err error
+	filter, err := parseItemFilter(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid filter", err)
+		return
+	}
+	// ログイン中ならブロック関係にある出品者の商品を除外する
+	if userID, err := middleware.GetUserIDFromContext(ctx); err == nil {
+		filter.ViewerId = userID
+	}
 
-	if keyword != "" {
+	// 検索方式 (keyword / semantic / hybrid)
+	mode := r.URL.Query().Get("mode")
+	switch mode {
+	case "", model.SearchModeKeyword:
+	case model.SearchModeSemantic, model.SearchModeHybrid:
+		if keyword == "" {
+			respondError(w, http.StatusBadRequest, "name is required for "+mode+" search", nil)
+			return
+		}
+	default:
+		respondError(w, http.StatusBadRequest, "Invalid search mode", nil)
+		return
+	}
+
+	var result *model.ItemListResult
+
+	if mode == model.SearchModeSemantic || mode == model.SearchModeHybrid {
+		// Search items by embedding similarity
+		result, err = c.semanticSearch.SearchItems(ctx, keyword, mode, filter, limit, offset)
+	} else if keyword != "" {
 		// Search items by keyword
-		items, err = c.list.SearchItems(ctx, keyword, limit, offset)
+		result, err = c.list.SearchItems(ctx, keyword, filter, limit, offset)
 	} else {
 		// Get all items
-		items, err = c.list.GetItems(ctx, limit, offset)
+		result, err = c.list.GetItems(ctx, filter, limit, offset)
 	}
 
 	if err != nil {
+		if errors.Is(err, model.ErrCategoryNotFound) {
+			respondError(w, http.StatusNotFound, "Category not found", err)
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to fetch items", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{"items": items})
+	c.markLikedByMe(ctx, result.Items)
+
+	// 人気の検索語の集計用に記録 (ページ送りは数えない。レスポンスは待たせない)
+	if keyword != "" && offset == 0 {
+		go c.suggest.LogQuery(context.WithoutCancel(ctx), clientKey(r), keyword)
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// HandleSuggest : 検索語の補完候補を取得 (GET /items/suggest?q=)
+func (c *ItemQueryController) HandleSuggest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit := 10
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l < limit {
+		limit = l
+	}
+
+	suggestions, err := c.suggest.Suggest(ctx, r.URL.Query().Get("q"), limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get suggestions", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"suggestions": suggestions})
+}
+
+// parseItemFilter : 商品一覧・検索の絞り込み条件をクエリパラメータから取得
+func parseItemFilter(r *http.Request) (model.ItemFilter, error) {
+	query := r.URL.Query()
+	filter := model.ItemFilter{
+		// 商品の状態・ブランド・配送条件 (完全一致)
+		ItemAttributes: model.ItemAttributes{
+			Condition:      query.Get("condition"),
+			Brand:          query.Get("brand"),
+			ShippingPayer:  query.Get("shipping_payer"),
+			ShippingMethod: query.Get("shipping_method"),
+		},
+		Status:   query.Get("status"),
+		SellerId: query.Get("seller"),
+		Sort:     query.Get("sort"),
+	}
+
+	// カテゴリ (子孫カテゴリの商品も含む)
+	if v := query.Get("category"); v != "" {
+		categoryID, err := strconv.Atoi(v)
+		if err != nil || categoryID <= 0 {
+			return filter, fmt.Errorf("invalid category: %q", v)
+		}
+		filter.CategoryId = categoryID
+	}
+	// 価格帯
+	if v := query.Get("min_price"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid min_price: %w", err)
+		}
+		filter.MinPrice = n
+	}
+	if v := query.Get("max_price"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid max_price: %w", err)
+		}
+		filter.MaxPrice = n
+	}
+
+	if !filter.IsValid() {
+		return filter, fmt.Errorf("invalid filter: %+v", filter)
+	}
+	return filter, nil
 }
 
 // HandleItemDetail : 商品の詳細を取得する
@@ -86,6 +213,12 @@ func (c *ItemQueryController) HandleItemDetail(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if userID, err := middleware.GetUserIDFromContext(ctx); err == nil {
+		if err := c.like.MarkItemLikedByMe(ctx, userID, item); err != nil {
+			log.Printf("Warning: failed to mark liked item: %v", err)
+		}
+	}
+
 	respondJSON(w, http.StatusOK, item)
 }
 
@@ -111,6 +244,59 @@ func (c *ItemQueryController) HandleMyItems(w http.ResponseWriter, r *http.Reque
 	respondJSON(w, http.StatusOK, items)
 }
 
+// HandlePurchasedItems : 自分の購入履歴を取得 (GET /items/purchased)
+func (c *ItemQueryController) HandlePurchasedItems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "User not authenticated", err)
+		return
+	}
+
+	limit, offset := parseHistoryPage(r)
+	items, err := c.history.GetPurchasedItems(ctx, userID, limit, offset)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get purchased items", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// HandleSoldItems : 自分の出品で売れた商品一覧を取得 (GET /items/sold)
+func (c *ItemQueryController) HandleSoldItems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "User not authenticated", err)
+		return
+	}
+
+	limit, offset := parseHistoryPage(r)
+	items, err := c.history.GetSoldItems(ctx, userID, limit, offset)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get sold items", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// parseHistoryPage : 履歴系のlimit/offsetを取得 (limitは1〜100、既定20)
+func parseHistoryPage(r *http.Request) (int, int) {
+	limit := 20
+	offset := 0
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	if o, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && o >= 0 {
+		offset = o
+	}
+	return limit, offset
+}
+
 // HandleUserItems : 特定のユーザーの出品商品一覧を取得 (GET /users/{userId}/items)
 func (c *ItemQueryController) HandleUserItems(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -129,5 +315,7 @@ func (c *ItemQueryController) HandleUserItems(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	c.markLikedByMe(ctx, items)
+
 	respondJSON(w, http.StatusOK, items)
 }
@@ -1,8 +1,11 @@
 package controller
 
 import (
+	"context"
 	"db/middleware"
+	"db/model"
 	"db/usecase"
+	"errors"
 	"log"
 	"net/http"
 )
@@ -17,8 +20,23 @@ func NewLikeController(likeUsecase usecase.LikeUsecase) *LikeController {
 	}
 }
 
+// HandleLike : 商品にいいねする (PUT /items/{id}/like)
+func (c *LikeController) HandleLike(w http.ResponseWriter, r *http.Request) {
+	c.handleLikeAction(w, r, c.likeUsecase.Like)
+}
+
+// HandleUnlike : 商品のいいねを解除する (DELETE /items/{id}/like)
+func (c *LikeController) HandleUnlike(w http.ResponseWriter, r *http.Request) {
+	c.handleLikeAction(w, r, c.likeUsecase.Unlike)
+}
+
 // HandleToggleLike : 商品のいいねをトグルする (POST /items/{id}/like)
 func (c *LikeController) HandleToggleLike(w http.ResponseWriter, r *http.Request) {
+	c.handleLikeAction(w, r, c.likeUsecase.ToggleLike)
+}
+
+// handleLikeAction : いいねの登録・解除・切り替えの共通処理。操作後の状態といいね数を返す
+func (c *LikeController) handleLikeAction(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, userID, itemID string) (*model.LikeState, error)) {
 	ctx := r.Context()
 
 	// Get user ID from context (set by Firebase auth middleware)
@@ -36,14 +54,18 @@ func (c *LikeController) HandleToggleLike(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = c.likeUsecase.ToggleLike(ctx, userID, itemID)
+	state, err := action(ctx, userID, itemID)
 	if err != nil {
-		log.Printf("failed to toggle like: %v\n", err)
-		respondError(w, http.StatusInternalServerError, "Failed to toggle like", err)
+		if errors.Is(err, model.ErrItemNotFound) {
+			respondError(w, http.StatusNotFound, "Item not found", err)
+			return
+		}
+		log.Printf("failed to update like: %v\n", err)
+		respondError(w, http.StatusInternalServerError, "Failed to update like", err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]string{"message": "Like toggled successfully"})
+	respondJSON(w, http.StatusOK, state)
 }
 
 // HandleGetLikedItems : ユーザーがいいねした商品を取得する (GET /items/liked)
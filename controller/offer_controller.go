@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"db/middleware"
+	"db/model"
+	"db/usecase"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// OfferController : 価格交渉を扱うコントローラ
+type OfferController struct {
+	offerUsecase usecase.OfferUsecase
+}
+
+func NewOfferController(u usecase.OfferUsecase) *OfferController {
+	return &OfferController{offerUsecase: u}
+}
+
+// HandleCreateOffer : 価格を提案 (POST /items/{id}/offers)
+func (c *OfferController) HandleCreateOffer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req model.OfferCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if !req.IsValid() {
+		respondError(w, http.StatusBadRequest, "Price must be positive", nil)
+		return
+	}
+
+	offer, err := c.offerUsecase.CreateOffer(ctx, r.PathValue("id"), userID, req.Price)
+	if err != nil {
+		respondOfferError(w, "Failed to create offer", err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, offer)
+}
+
+// HandleGetOffer : 価格交渉を取得 (GET /offers/{id})
+func (c *OfferController) HandleGetOffer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	offer, err := c.offerUsecase.GetOffer(ctx, r.PathValue("id"), userID)
+	if err != nil {
+		respondOfferError(w, "Failed to get offer", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, offer)
+}
+
+// HandleRespondOffer : 価格の提案に回答 (POST /offers/{id}/{action})
+// action: accept / reject / counter (counterはbodyのpriceで再提示)
+func (c *OfferController) HandleRespondOffer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	// accept/rejectはbodyなしでよい
+	var req model.OfferRespondRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	offer, err := c.offerUsecase.RespondOffer(ctx, r.PathValue("id"), userID, r.PathValue("action"), req.Price)
+	if err != nil {
+		respondOfferError(w, "Failed to respond to offer", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, offer)
+}
+
+// respondOfferError : 価格交渉系のエラーをステータスコードに変換して返す
+func respondOfferError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, model.ErrOfferNotFound):
+		respondError(w, http.StatusNotFound, "Offer not found", err)
+	case errors.Is(err, model.ErrItemNotFound):
+		respondError(w, http.StatusNotFound, "Item not found", err)
+	case errors.Is(err, model.ErrNotOfferParticipant), errors.Is(err, model.ErrCannotOfferOwnItem):
+		respondError(w, http.StatusForbidden, "Forbidden", err)
+	case errors.Is(err, model.ErrInvalidOfferTransition):
+		respondError(w, http.StatusConflict, "Invalid offer status transition", err)
+	case errors.Is(err, model.ErrOfferAlreadyPending):
+		respondError(w, http.StatusConflict, "An offer is already pending", err)
+	case errors.Is(err, model.ErrItemReserved):
+		respondError(w, http.StatusConflict, "Item is reserved for another buyer", err)
+	case errors.Is(err, model.ErrUserBlocked):
+		respondError(w, http.StatusForbidden, "User is blocked", err)
+	case errors.Is(err, model.ErrInvalidRequest):
+		respondError(w, http.StatusBadRequest, "Invalid request", err)
+	default:
+		respondError(w, http.StatusInternalServerError, message, err)
+	}
+}
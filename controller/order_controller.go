@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"db/middleware"
+	"db/model"
+	"db/usecase"
+	"errors"
+	"net/http"
+)
+
+// OrderController : 購入後の取引を扱うコントローラ
+type OrderController struct {
+	orderUsecase usecase.OrderUsecase
+}
+
+func NewOrderController(u usecase.OrderUsecase) *OrderController {
+	return &OrderController{orderUsecase: u}
+}
+
+// HandleGetOrder : 取引を取得 (GET /orders/{id})
+func (c *OrderController) HandleGetOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	order, err := c.orderUsecase.GetOrder(ctx, r.PathValue("id"), userID)
+	if err != nil {
+		respondOrderError(w, "Failed to get order", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, order)
+}
+
+// HandleOrderTransition : 取引の状態を進める (POST /orders/{id}/{action})
+// action: pay(購入者) / ship(出品者) / receive(購入者) / complete(出品者) / cancel(双方)
+func (c *OrderController) HandleOrderTransition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	order, err := c.orderUsecase.TransitionOrder(ctx, r.PathValue("id"), userID, r.PathValue("action"))
+	if err != nil {
+		respondOrderError(w, "Failed to update order", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, order)
+}
+
+// respondOrderError : 取引系のエラーをステータスコードに変換して返す
+func respondOrderError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, model.ErrOrderNotFound):
+		respondError(w, http.StatusNotFound, "Order not found", err)
+	case errors.Is(err, model.ErrNotOrderParticipant):
+		respondError(w, http.StatusForbidden, "Forbidden", err)
+	case errors.Is(err, model.ErrInvalidOrderTransition):
+		respondError(w, http.StatusConflict, "Invalid order status transition", err)
+	case errors.Is(err, model.ErrInvalidRequest):
+		respondError(w, http.StatusBadRequest, "Invalid request", err)
+	default:
+		respondError(w, http.StatusInternalServerError, message, err)
+	}
+}
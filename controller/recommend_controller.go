@@ -19,8 +19,11 @@ func (c *RecommendController) HandleGetRecommendations(w http.ResponseWriter, r
 	ctx := r.Context()
 	itemID := r.PathValue("id")
 
+	// ログイン中ならブロック関係にある出品者の商品を除外する (ゲストは空)
+	viewerID, _ := middleware.GetUserIDFromContext(ctx)
+
 	// 4件表示
-	items, err := c.recommendUsecase.GetSimilarItems(ctx, itemID, 4)
+	items, err := c.recommendUsecase.GetSimilarItems(ctx, itemID, viewerID, 4)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to get recommendations", err)
 		return
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"db/middleware"
+	"db/model"
+	"db/usecase"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// ReviewController : 取引後の評価を扱うコントローラ
+type ReviewController struct {
+	reviewUsecase usecase.ReviewUsecase
+}
+
+func NewReviewController(u usecase.ReviewUsecase) *ReviewController {
+	return &ReviewController{reviewUsecase: u}
+}
+
+// HandleCreateReview : 取引相手を評価 (POST /items/{id}/reviews)
+func (c *ReviewController) HandleCreateReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req model.ReviewCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	review, err := c.reviewUsecase.CreateReview(ctx, r.PathValue("id"), userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrInvalidRequest):
+			respondError(w, http.StatusBadRequest, "Rating must be 1-5 and comment must be within 500 characters", err)
+		case errors.Is(err, model.ErrItemNotFound):
+			respondError(w, http.StatusNotFound, "Item not found", err)
+		case errors.Is(err, model.ErrNotEligibleForReview):
+			respondError(w, http.StatusForbidden, "Only the buyer and seller of a completed order can review", err)
+		case errors.Is(err, model.ErrAlreadyReviewed):
+			respondError(w, http.StatusConflict, "Already reviewed", err)
+		default:
+			respondError(w, http.StatusInternalServerError, "Failed to create review", err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, review)
+}
+
+// HandleGetUserReviews : ユーザーが受けた評価一覧 (GET /users/{id}/reviews)
+func (c *ReviewController) HandleGetUserReviews(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	offset := 0
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	if o, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && o >= 0 {
+		offset = o
+	}
+
+	reviews, err := c.reviewUsecase.GetUserReviews(r.Context(), r.PathValue("id"), limit, offset)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get reviews", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, reviews)
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"db/middleware"
+	"db/model"
+	"db/usecase"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// SavedSearchController : 保存した検索条件を扱うコントローラ
+type SavedSearchController struct {
+	savedSearchUsecase usecase.SavedSearchUsecase
+}
+
+func NewSavedSearchController(u usecase.SavedSearchUsecase) *SavedSearchController {
+	return &SavedSearchController{savedSearchUsecase: u}
+}
+
+// HandleCreateSavedSearch : 検索条件を保存 (POST /saved-searches)
+func (c *SavedSearchController) HandleCreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req model.SavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	savedSearch, err := c.savedSearchUsecase.CreateSavedSearch(ctx, userID, &req)
+	if err != nil {
+		respondSavedSearchError(w, "Failed to create saved search", err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, savedSearch)
+}
+
+// HandleGetSavedSearches : 保存した検索条件の一覧 (GET /saved-searches)
+func (c *SavedSearchController) HandleGetSavedSearches(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	savedSearches, err := c.savedSearchUsecase.GetSavedSearches(ctx, userID)
+	if err != nil {
+		respondSavedSearchError(w, "Failed to get saved searches", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"saved_searches": savedSearches})
+}
+
+// HandleUpdateSavedSearch : 検索条件を更新 (PUT /saved-searches/{id})
+func (c *SavedSearchController) HandleUpdateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req model.SavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	savedSearch, err := c.savedSearchUsecase.UpdateSavedSearch(ctx, r.PathValue("id"), userID, &req)
+	if err != nil {
+		respondSavedSearchError(w, "Failed to update saved search", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, savedSearch)
+}
+
+// HandleDeleteSavedSearch : 検索条件を削除 (DELETE /saved-searches/{id})
+func (c *SavedSearchController) HandleDeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	if err := c.savedSearchUsecase.DeleteSavedSearch(ctx, r.PathValue("id"), userID); err != nil {
+		respondSavedSearchError(w, "Failed to delete saved search", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "saved search deleted"})
+}
+
+// respondSavedSearchError : 保存した検索条件のエラーをステータスコードに変換して返す
+func respondSavedSearchError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, model.ErrSavedSearchNotFound):
+		respondError(w, http.StatusNotFound, "Saved search not found", err)
+	case errors.Is(err, model.ErrUserNotFound):
+		respondError(w, http.StatusNotFound, "User not found", err)
+	case errors.Is(err, model.ErrSavedSearchLimitExceeded):
+		respondError(w, http.StatusConflict, "Too many saved searches", err)
+	case errors.Is(err, model.ErrInvalidRequest):
+		respondError(w, http.StatusBadRequest, "Keyword is required and price range must be valid", err)
+	default:
+		respondError(w, http.StatusInternalServerError, message, err)
+	}
+}
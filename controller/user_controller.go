@@ -15,6 +15,9 @@ type UserController struct {
 	search   usecase.UserSearch
 	get      usecase.UserGet
 	update   usecase.UserUpdate
+	delete   usecase.UserDelete
+	export   usecase.UserExport
+	stats    usecase.SellerStatsUsecase
 }
 
 func NewUserController(
@@ -22,12 +25,18 @@ func NewUserController(
 	s usecase.UserSearch,
 	g usecase.UserGet,
 	u usecase.UserUpdate,
+	d usecase.UserDelete,
+	e usecase.UserExport,
+	st usecase.SellerStatsUsecase,
 ) *UserController {
 	return &UserController{
 		register: r,
 		search:   s,
 		get:      g,
 		update:   u,
+		delete:   d,
+		export:   e,
+		stats:    st,
 	}
 }
 
@@ -48,12 +57,7 @@ func (c *UserController) HandleProfileRegister(w http.ResponseWriter, r *http.Re
 	}
 
 	if err := c.register.Register(ctx, uid, &req); err != nil {
-
-		if errors.Is(err, model.ErrInvalidRequest) {
-			respondError(w, http.StatusBadRequest, "Invalid input data", err)
-		} else {
-			respondError(w, http.StatusInternalServerError, "Internal server error", err)
-		}
+		respondUserSaveError(w, err)
 		return
 	}
 
@@ -61,6 +65,20 @@ func (c *UserController) HandleProfileRegister(w http.ResponseWriter, r *http.Re
 	respondJSON(w, http.StatusCreated, map[string]string{"id": uid})
 }
 
+// respondUserSaveError : 登録・更新のエラーをステータスコードに変換
+func respondUserSaveError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, model.ErrInvalidRequest):
+		respondError(w, http.StatusBadRequest, "Invalid input data", err)
+	case errors.Is(err, model.ErrHandleTaken):
+		respondError(w, http.StatusConflict, "Handle is already taken", err)
+	case errors.Is(err, model.ErrUserNotFound):
+		respondError(w, http.StatusNotFound, "User not found", err)
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error", err)
+	}
+}
+
 // HandleSearchUser : ユーザー検索 (GET /user)
 func (c *UserController) HandleSearchUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -89,9 +107,41 @@ func (c *UserController) HandleGetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	c.attachSellerStats(r, user)
 	respondJSON(w, http.StatusOK, user)
 }
 
+// HandleGetUserByHandle : ハンドルでユーザー取得 (GET /users/by-handle/{handle})
+func (c *UserController) HandleGetUserByHandle(w http.ResponseWriter, r *http.Request) {
+	// /users/{id}/items などと同じ形のパスなので、by-handle以外はここで404にする
+	if r.PathValue("lookup") != "by-handle" {
+		http.NotFound(w, r)
+		return
+	}
+
+	user, err := c.get.GetUserByHandle(r.Context(), r.PathValue("handle"))
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "User not found", err)
+		} else {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch user", err)
+		}
+		return
+	}
+
+	c.attachSellerStats(r, user)
+	respondJSON(w, http.StatusOK, user)
+}
+
+// attachSellerStats : 出品者としての実績を付ける (集計に失敗してもプロフィールは返す)
+func (c *UserController) attachSellerStats(r *http.Request, user *model.User) {
+	if stats, err := c.stats.GetSellerStats(r.Context(), user.Id); err != nil {
+		log.Printf("Warning: failed to get seller stats: %v", err)
+	} else {
+		user.SellerStats = stats
+	}
+}
+
 // HandleUpdateUser : ユーザー情報更新 (PUT /users/me)
 func (c *UserController) HandleUpdateUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -110,14 +160,62 @@ func (c *UserController) HandleUpdateUser(w http.ResponseWriter, r *http.Request
 	}
 
 	if err := c.update.UpdateUser(ctx, uid, &req); err != nil {
-		if errors.Is(err, model.ErrInvalidRequest) {
-			respondError(w, http.StatusBadRequest, "Invalid input data", err)
-		} else {
-			respondError(w, http.StatusInternalServerError, "Internal server error", err)
-		}
+		respondUserSaveError(w, err)
 		return
 	}
 
 	log.Printf("successfully updated user: id=%s", uid)
 	respondJSON(w, http.StatusOK, map[string]string{"message": "Profile updated successfully"})
 }
+
+// HandleDeleteUser : 退会 (DELETE /users/me)
+// Firebaseのアカウントもサーバー側で削除する
+func (c *UserController) HandleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uid, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	if err := c.delete.DeleteUser(ctx, uid); err != nil {
+		switch {
+		case errors.Is(err, model.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, "User not found", err)
+		case errors.Is(err, model.ErrUserHasOpenOrder):
+			respondError(w, http.StatusConflict, "Cannot delete account with an open order", err)
+		default:
+			respondError(w, http.StatusInternalServerError, "Internal server error", err)
+		}
+		return
+	}
+
+	log.Printf("successfully deleted user: id=%s", uid)
+	respondJSON(w, http.StatusOK, map[string]string{"message": "account deleted"})
+}
+
+// HandleExportUser : 個人データのエクスポート (GET /users/me/export)
+func (c *UserController) HandleExportUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uid, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	export, err := c.export.ExportUser(ctx, uid)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "User not found", err)
+		} else {
+			respondError(w, http.StatusInternalServerError, "Failed to export user data", err)
+		}
+		return
+	}
+
+	// ブラウザではファイルとして保存させる
+	w.Header().Set("Content-Disposition", `attachment; filename="export.json"`)
+	respondJSON(w, http.StatusOK, export)
+}
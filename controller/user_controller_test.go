@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"db/model"
+	"db/usecase"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// stubUserGet : ハンドル検索テスト用のusecase (未使用メソッドは埋め込みで省略)
+type stubUserGet struct {
+	usecase.UserGet
+	users map[string]*model.User
+}
+
+func (s *stubUserGet) GetUserByHandle(ctx context.Context, handle string) (*model.User, error) {
+	if user, ok := s.users[handle]; ok {
+		return user, nil
+	}
+	return nil, model.ErrUserNotFound
+}
+
+// stubSellerStats : 出品実績は常に空で返す
+type stubSellerStats struct{}
+
+func (stubSellerStats) GetSellerStats(ctx context.Context, sellerID string) (*model.SellerStats, error) {
+	return &model.SellerStats{}, nil
+}
+
+func TestUserController_HandleGetUserByHandle(t *testing.T) {
+	get := &stubUserGet{users: map[string]*model.User{"tanaka_shop": {Id: "user1", Handle: "tanaka_shop"}}}
+	c := NewUserController(nil, nil, get, nil, nil, nil, stubSellerStats{})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{lookup}/{handle}", c.HandleGetUserByHandle)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "成功: by-handleで取得", path: "/users/by-handle/tanaka_shop", wantStatus: http.StatusOK},
+		{name: "失敗: 存在しないハンドル", path: "/users/by-handle/sato", wantStatus: http.StatusNotFound},
+		{name: "失敗: by-handle以外は404", path: "/users/user1/unknown", wantStatus: http.StatusNotFound},
+		{name: "失敗: by-handle以外は登録済みハンドルでも404", path: "/users/user1/tanaka_shop", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + tt.path)
+			if err != nil {
+				t.Fatalf("GET %s error = %v", tt.path, err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("GET %s status = %v, want %v", tt.path, resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// TestReservedHandles_CoverUserSubpaths : main.goの/users/以下の固定パスはハンドルとして使えないこと
+// (GET /users/{lookup}/{handle} と同じ形なので、使えるとハンドル検索と紛らわしくなる)
+func TestReservedHandles_CoverUserSubpaths(t *testing.T) {
+	src, err := os.ReadFile("../main.go")
+	if err != nil {
+		t.Fatalf("failed to read main.go: %v", err)
+	}
+
+	routes := regexp.MustCompile(`"[A-Z]+ /users/([^"]+)"`).FindAllStringSubmatch(string(src), -1)
+	if len(routes) == 0 {
+		t.Fatal("no /users/ routes found in main.go")
+	}
+
+	for _, m := range routes {
+		for _, segment := range strings.Split(m[1], "/") {
+			// {id} などのワイルドカードは対象外
+			if strings.HasPrefix(segment, "{") {
+				continue
+			}
+			if model.IsValidHandle(segment) {
+				t.Errorf("route /users/%s: %q must be a reserved handle", m[1], segment)
+			}
+		}
+	}
+}
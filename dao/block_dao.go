@@ -0,0 +1,143 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"db/model"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+type BlockDAO interface {
+	Block(ctx context.Context, blockerID, blockedID string) error
+	Unblock(ctx context.Context, blockerID, blockedID string) error
+	IsBlocked(ctx context.Context, userID, otherID string) (bool, error)
+	GetBlockedUserIDs(ctx context.Context, userID string) ([]string, error)
+	GetBlockedUsers(ctx context.Context, userID string, limit int, offset int) ([]model.BlockedUser, error)
+}
+
+type blockDao struct {
+	DB *sql.DB
+}
+
+// NewBlockDao : BlockDAOの生成
+func NewBlockDao(db *sql.DB) BlockDAO {
+	return &blockDao{DB: db}
+}
+
+// Block : ブロックする (ブロック済みなら何もしない)
+// お互いのフォローも同時に解除する
+func (dao *blockDao) Block(ctx context.Context, blockerID, blockedID string) error {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("fail:txBegin(): %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("fail:tx.Rollback,%v\n", err)
+		}
+	}()
+
+	insertQuery := `INSERT IGNORE INTO blocks (blocker_id, blocked_id, created_at) VALUES (?, ?, ?)`
+	if _, err := tx.ExecContext(ctx, insertQuery, blockerID, blockedID, time.Now()); err != nil {
+		return fmt.Errorf("fail: insert block: %w", err)
+	}
+
+	deleteQuery := `
+		DELETE FROM follows
+		WHERE (follower_id = ? AND followee_id = ?) OR (follower_id = ? AND followee_id = ?)`
+	if _, err := tx.ExecContext(ctx, deleteQuery, blockerID, blockedID, blockedID, blockerID); err != nil {
+		return fmt.Errorf("fail: delete follows: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("fail:tx.Commit(): %w", err)
+	}
+	return nil
+}
+
+// Unblock : ブロックを解除する (ブロックしていなければ何もしない)
+func (dao *blockDao) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	query := `DELETE FROM blocks WHERE blocker_id = ? AND blocked_id = ?`
+	if _, err := dao.DB.ExecContext(ctx, query, blockerID, blockedID); err != nil {
+		return fmt.Errorf("fail: delete block: %w", err)
+	}
+	return nil
+}
+
+// IsBlocked : 2人の間にブロック関係があるか (どちらがブロックしていてもtrue)
+func (dao *blockDao) IsBlocked(ctx context.Context, userID, otherID string) (bool, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM blocks
+			WHERE (blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)
+		)`
+	if err := dao.DB.QueryRowContext(ctx, query, userID, otherID, otherID, userID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("fail: check block: %w", err)
+	}
+	return exists, nil
+}
+
+// GetBlockedUserIDs : ユーザーとブロック関係にある相手のID一覧 (ブロックした相手とブロックされた相手の両方)
+func (dao *blockDao) GetBlockedUserIDs(ctx context.Context, userID string) ([]string, error) {
+	query := `
+		SELECT blocked_id FROM blocks WHERE blocker_id = ?
+		UNION
+		SELECT blocker_id FROM blocks WHERE blocked_id = ?`
+
+	rows, err := dao.DB.QueryContext(ctx, query, userID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("fail:dao.DB.Query:%w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetBlockedUsers : ユーザーがブロックしている人をブロックした新しい順に取得
+func (dao *blockDao) GetBlockedUsers(ctx context.Context, userID string, limit int, offset int) ([]model.BlockedUser, error) {
+	query := `
+		SELECT u.id, u.name, COALESCE(u.icon_url, ''), b.created_at
+		FROM blocks b
+		INNER JOIN users u ON b.blocked_id = u.id
+		WHERE b.blocker_id = ?
+		ORDER BY b.created_at DESC
+		LIMIT ? OFFSET ?`
+
+	rows, err := dao.DB.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("fail:dao.DB.Query:%w", err)
+	}
+	defer rows.Close()
+
+	users := make([]model.BlockedUser, 0)
+	for rows.Next() {
+		var u model.BlockedUser
+		if err := rows.Scan(&u.Id, &u.Name, &u.IconURL, &u.BlockedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return users, nil
+}
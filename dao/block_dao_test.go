@@ -0,0 +1,61 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestBlockDao_Block(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewBlockDao(db)
+	ctx := context.Background()
+	insertQuery := regexp.QuoteMeta("INSERT IGNORE INTO blocks (blocker_id, blocked_id, created_at) VALUES (?, ?, ?)")
+	deleteQuery := regexp.QuoteMeta("DELETE FROM follows")
+
+	t.Run("成功: ブロックしてお互いのフォローを解除", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).
+			WithArgs("user1", "user2", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteQuery).
+			WithArgs("user1", "user2", "user2", "user1").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		if err := dao.Block(ctx, "user1", "user2"); err != nil {
+			t.Errorf("Block() error = %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: フォロー解除に失敗したらロールバック", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).
+			WithArgs("user1", "user2", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteQuery).
+			WithArgs("user1", "user2", "user2", "user1").
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		if err := dao.Block(ctx, "user1", "user2"); err == nil {
+			t.Errorf("Block() error = nil, want error")
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
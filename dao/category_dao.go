@@ -0,0 +1,89 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"db/model"
+	"fmt"
+)
+
+type CategoryDAO interface {
+	GetCategories(ctx context.Context) ([]model.Category, error)
+	GetCategoryItemCounts(ctx context.Context) (map[int]int, error)
+	CategoryExists(ctx context.Context, categoryID int) (bool, error)
+}
+
+type categoryDao struct {
+	DB *sql.DB
+}
+
+// NewCategoryDao : CategoryDAOの生成
+func NewCategoryDao(db *sql.DB) CategoryDAO {
+	return &categoryDao{DB: db}
+}
+
+// GetCategories : 全カテゴリを表示順で取得 (木の組み立てはusecaseで行う)
+func (dao *categoryDao) GetCategories(ctx context.Context) ([]model.Category, error) {
+	query := `SELECT id, COALESCE(parent_id, 0), name FROM categories ORDER BY sort_order, id`
+
+	rows, err := dao.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("fail: query categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := make([]model.Category, 0)
+	for rows.Next() {
+		var c model.Category
+		if err := rows.Scan(&c.Id, &c.ParentId, &c.Name); err != nil {
+			return nil, fmt.Errorf("fail: scan category: %w", err)
+		}
+		categories = append(categories, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return categories, nil
+}
+
+// GetCategoryItemCounts : カテゴリ直下の商品数を取得 (取り下げ済みは除く)
+func (dao *categoryDao) GetCategoryItemCounts(ctx context.Context) (map[int]int, error) {
+	query := `
+		SELECT category_id, COUNT(*)
+		FROM items
+		WHERE category_id IS NOT NULL AND status <> ?
+		GROUP BY category_id`
+
+	rows, err := dao.DB.QueryContext(ctx, query, model.StatusWithdrawn)
+	if err != nil {
+		return nil, fmt.Errorf("fail: query category item counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var categoryID, count int
+		if err := rows.Scan(&categoryID, &count); err != nil {
+			return nil, fmt.Errorf("fail: scan category item count: %w", err)
+		}
+		counts[categoryID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// CategoryExists : カテゴリが存在するか
+func (dao *categoryDao) CategoryExists(ctx context.Context, categoryID int) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM categories WHERE id = ?)`
+
+	var exists bool
+	if err := dao.DB.QueryRowContext(ctx, query, categoryID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("fail: check category: %w", err)
+	}
+
+	return exists, nil
+}
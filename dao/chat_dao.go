@@ -13,8 +13,16 @@ type ChatDAO interface {
 	GetChatRoom(ctx context.Context, itemID, buyerID string) (*model.ChatRoom, error)
 	GetChatRoomByID(ctx context.Context, roomID string) (*model.ChatRoom, error)
 	GetChatRoomsByItemID(ctx context.Context, itemID string) ([]model.ChatRoomInfo, error)
+	GetChatInbox(ctx context.Context, userID string, limit int, offset int) ([]model.ChatInboxEntry, error)
 	SaveMessage(ctx context.Context, msg *model.Message) error
-	GetMessages(ctx context.Context, roomID string) ([]model.Message, error)
+	GetMessages(ctx context.Context, roomID string, query model.MessagePageQuery) ([]model.Message, error)
+	GetUserMessages(ctx context.Context, userID string) ([]model.Message, error)
+	GetSellerInquiries(ctx context.Context, sellerID string, limit int) ([]model.SellerInquiry, error)
+	MessageExists(ctx context.Context, roomID, messageID string) (bool, error)
+	GetLatestMessageID(ctx context.Context, roomID string) (string, error)
+	UpsertReadState(ctx context.Context, state *model.ChatReadState) error
+	GetReadStates(ctx context.Context, roomID string) ([]model.ChatReadState, error)
+	GetUnreadCounts(ctx context.Context, userID string) (map[string]int, error)
 }
 
 type chatDao struct {
@@ -56,6 +64,9 @@ func (dao *chatDao) GetChatRoomByID(ctx context.Context, roomID string) (*model.
 	row := dao.DB.QueryRowContext(ctx, query, roomID)
 	var room model.ChatRoom
 	if err := row.Scan(&room.Id, &room.ItemId, &room.BuyerId, &room.SellerId, &room.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("get chat room by id failed: %w", err)
 	}
 	return &room, nil
@@ -91,10 +102,89 @@ func (dao *chatDao) GetChatRoomsByItemID(ctx context.Context, itemID string) ([]
 	return rooms, nil
 }
 
+// GetChatInbox : ユーザーが参加している全チャットルームを最終アクティビティ順に取得
+// 未読数は相手からのメッセージのうち、既読位置(chat_read_states)より新しいものを数える
+func (dao *chatDao) GetChatInbox(ctx context.Context, userID string, limit int, offset int) ([]model.ChatInboxEntry, error) {
+	query := `
+		SELECT
+			r.id,
+			r.item_id,
+			i.name,
+			COALESCE((SELECT image_url FROM item_images WHERE item_id = i.id LIMIT 1), '') AS item_image_url,
+			r.seller_id,
+			u.id,
+			u.name,
+			COALESCE(u.icon_url, '') AS counterpart_icon_url,
+			COALESCE(lm.content, '') AS last_message,
+			lm.created_at AS last_message_at,
+			(SELECT COUNT(*) FROM messages m
+				WHERE m.chat_room_id = r.id
+				AND m.sender_id <> ?
+				AND m.id > COALESCE(rs.last_read_message_id, '')) AS unread_count,
+			r.created_at
+		FROM chat_rooms r
+		INNER JOIN items i ON r.item_id = i.id
+		INNER JOIN users u ON u.id = CASE WHEN r.buyer_id = ? THEN r.seller_id ELSE r.buyer_id END
+		LEFT JOIN messages lm ON lm.id = (SELECT id FROM messages WHERE chat_room_id = r.id ORDER BY id DESC LIMIT 1)
+		LEFT JOIN chat_read_states rs ON rs.chat_room_id = r.id AND rs.user_id = ?
+		WHERE r.buyer_id = ? OR r.seller_id = ?
+		ORDER BY COALESCE(lm.created_at, r.created_at) DESC
+		LIMIT ? OFFSET ?`
+
+	rows, err := dao.DB.QueryContext(ctx, query, userID, userID, userID, userID, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat inbox: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]model.ChatInboxEntry, 0)
+	for rows.Next() {
+		var e model.ChatInboxEntry
+		var sellerID string
+		var lastMessageAt sql.NullTime
+		if err := rows.Scan(
+			&e.RoomID,
+			&e.ItemID,
+			&e.ItemName,
+			&e.ItemImageURL,
+			&sellerID,
+			&e.CounterpartID,
+			&e.CounterpartName,
+			&e.CounterpartIconURL,
+			&e.LastMessage,
+			&lastMessageAt,
+			&e.UnreadCount,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan chat inbox entry: %w", err)
+		}
+		e.Role = model.ChatRoleBuyer
+		if sellerID == userID {
+			e.Role = model.ChatRoleSeller
+		}
+		if lastMessageAt.Valid {
+			e.LastMessageAt = &lastMessageAt.Time
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return entries, nil
+}
+
 // SaveMessage : メッセージ保存
 func (dao *chatDao) SaveMessage(ctx context.Context, msg *model.Message) error {
-	query := `INSERT INTO messages (id, chat_room_id, sender_id, content, created_at) VALUES (?, ?, ?, ?, ?)`
-	_, err := dao.DB.ExecContext(ctx, query, msg.Id, msg.ChatRoomId, msg.SenderId, msg.Content, msg.CreatedAt)
+	query := `INSERT INTO messages (id, chat_room_id, sender_id, type, offer_id, content, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	// 通常のメッセージはoffer_idを持たない
+	var offerID interface{} = nil
+	if msg.OfferId != "" {
+		offerID = msg.OfferId
+	}
+	_, err := dao.DB.ExecContext(ctx, query, msg.Id, msg.ChatRoomId, msg.SenderId, msg.Type, offerID, msg.Content, msg.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("fail: insert message: %w", err)
 	}
@@ -102,22 +192,205 @@ func (dao *chatDao) SaveMessage(ctx context.Context, msg *model.Message) error {
 	return nil
 }
 
-// GetMessages : メッセージ一覧取得
-func (dao *chatDao) GetMessages(ctx context.Context, roomID string) ([]model.Message, error) {
-	query := `SELECT id, chat_room_id, sender_id, content, created_at FROM messages WHERE chat_room_id = ? ORDER BY created_at ASC`
-	rows, err := dao.DB.QueryContext(ctx, query, roomID)
+// GetMessages : メッセージ一覧取得 (ULIDのカーソルでページング、結果は古い順)
+// Afterが指定された場合はそれより新しいものを古い順に、それ以外はBefore(未指定なら最新)より古いものを新しい順に最大Limit件取得する
+func (dao *chatDao) GetMessages(ctx context.Context, roomID string, query model.MessagePageQuery) ([]model.Message, error) {
+	var (
+		q    string
+		args []interface{}
+	)
+	switch {
+	case query.After != "":
+		q = `SELECT id, chat_room_id, sender_id, type, COALESCE(offer_id, ''), content, created_at FROM messages WHERE chat_room_id = ? AND id > ? ORDER BY id ASC LIMIT ?`
+		args = []interface{}{roomID, query.After, query.Limit}
+	case query.Before != "":
+		q = `SELECT id, chat_room_id, sender_id, type, COALESCE(offer_id, ''), content, created_at FROM messages WHERE chat_room_id = ? AND id < ? ORDER BY id DESC LIMIT ?`
+		args = []interface{}{roomID, query.Before, query.Limit}
+	default:
+		q = `SELECT id, chat_room_id, sender_id, type, COALESCE(offer_id, ''), content, created_at FROM messages WHERE chat_room_id = ? ORDER BY id DESC LIMIT ?`
+		args = []interface{}{roomID, query.Limit}
+	}
+
+	rows, err := dao.DB.QueryContext(ctx, q, args...)
 	if err != nil {
 		return nil, fmt.Errorf("get messages failed: %w", err)
 	}
 	defer rows.Close()
 
-	var msgs []model.Message
+	msgs := make([]model.Message, 0)
 	for rows.Next() {
 		var m model.Message
-		if err := rows.Scan(&m.Id, &m.ChatRoomId, &m.SenderId, &m.Content, &m.CreatedAt); err != nil {
+		if err := rows.Scan(&m.Id, &m.ChatRoomId, &m.SenderId, &m.Type, &m.OfferId, &m.Content, &m.CreatedAt); err != nil {
 			return nil, fmt.Errorf("scan message failed: %w", err)
 		}
 		msgs = append(msgs, m)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	// 新しい順で取得した場合は古い順に並べ直す
+	if query.After == "" {
+		for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+			msgs[i], msgs[j] = msgs[j], msgs[i]
+		}
+	}
 	return msgs, nil
 }
+
+// GetUserMessages : ユーザーが送信したメッセージを古い順にすべて取得 (個人データのエクスポート用)
+func (dao *chatDao) GetUserMessages(ctx context.Context, userID string) ([]model.Message, error) {
+	q := `SELECT id, chat_room_id, sender_id, type, COALESCE(offer_id, ''), content, created_at FROM messages WHERE sender_id = ? ORDER BY id ASC`
+
+	rows, err := dao.DB.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user messages failed: %w", err)
+	}
+	defer rows.Close()
+
+	msgs := make([]model.Message, 0)
+	for rows.Next() {
+		var m model.Message
+		if err := rows.Scan(&m.Id, &m.ChatRoomId, &m.SenderId, &m.Type, &m.OfferId, &m.Content, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan message failed: %w", err)
+		}
+		msgs = append(msgs, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return msgs, nil
+}
+
+// GetSellerInquiries : 出品者のチャットルームごとに、購入希望者の最初のメッセージと出品者の最初の返信の時刻を新しい順に最大limit件取得
+func (dao *chatDao) GetSellerInquiries(ctx context.Context, sellerID string, limit int) ([]model.SellerInquiry, error) {
+	q := `
+		SELECT
+			q.id,
+			q.inquired_at,
+			(SELECT MIN(m.created_at) FROM messages m
+			 WHERE m.chat_room_id = q.id AND m.sender_id = q.seller_id AND m.created_at >= q.inquired_at)
+		FROM (
+			SELECT r.id, r.seller_id, MIN(m.created_at) AS inquired_at
+			FROM chat_rooms r
+			INNER JOIN messages m ON m.chat_room_id = r.id AND m.sender_id = r.buyer_id
+			WHERE r.seller_id = ?
+			GROUP BY r.id, r.seller_id
+		) q
+		ORDER BY q.inquired_at DESC
+		LIMIT ?`
+
+	rows, err := dao.DB.QueryContext(ctx, q, sellerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get seller inquiries failed: %w", err)
+	}
+	defer rows.Close()
+
+	inquiries := make([]model.SellerInquiry, 0)
+	for rows.Next() {
+		var in model.SellerInquiry
+		if err := rows.Scan(&in.ChatRoomId, &in.InquiredAt, &in.FirstReplyAt); err != nil {
+			return nil, fmt.Errorf("scan inquiry failed: %w", err)
+		}
+		inquiries = append(inquiries, in)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return inquiries, nil
+}
+
+// MessageExists : 指定ルームにメッセージが存在するか
+func (dao *chatDao) MessageExists(ctx context.Context, roomID, messageID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM messages WHERE id = ? AND chat_room_id = ?)`
+	var exists bool
+	if err := dao.DB.QueryRowContext(ctx, query, messageID, roomID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check message existence: %w", err)
+	}
+	return exists, nil
+}
+
+// GetLatestMessageID : ルームの最新メッセージIDを取得 (メッセージがなければ空文字)
+func (dao *chatDao) GetLatestMessageID(ctx context.Context, roomID string) (string, error) {
+	query := `SELECT id FROM messages WHERE chat_room_id = ? ORDER BY id DESC LIMIT 1`
+	var id string
+	if err := dao.DB.QueryRowContext(ctx, query, roomID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get latest message id: %w", err)
+	}
+	return id, nil
+}
+
+// UpsertReadState : 既読位置を保存 (既に先まで読んでいる場合は巻き戻さない)
+func (dao *chatDao) UpsertReadState(ctx context.Context, state *model.ChatReadState) error {
+	query := `
+		INSERT INTO chat_read_states (chat_room_id, user_id, last_read_message_id, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			last_read_message_id = GREATEST(last_read_message_id, VALUES(last_read_message_id)),
+			updated_at = VALUES(updated_at)`
+
+	_, err := dao.DB.ExecContext(ctx, query, state.ChatRoomId, state.UserId, state.LastReadMessageId, state.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert read state: %w", err)
+	}
+	return nil
+}
+
+// GetReadStates : ルーム参加者の既読位置一覧を取得
+func (dao *chatDao) GetReadStates(ctx context.Context, roomID string) ([]model.ChatReadState, error) {
+	query := `SELECT chat_room_id, user_id, last_read_message_id, updated_at FROM chat_read_states WHERE chat_room_id = ?`
+	rows, err := dao.DB.QueryContext(ctx, query, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get read states: %w", err)
+	}
+	defer rows.Close()
+
+	states := make([]model.ChatReadState, 0)
+	for rows.Next() {
+		var s model.ChatReadState
+		if err := rows.Scan(&s.ChatRoomId, &s.UserId, &s.LastReadMessageId, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan read state: %w", err)
+		}
+		states = append(states, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return states, nil
+}
+
+// GetUnreadCounts : ユーザーが参加しているルームごとの未読数を取得 (未読のあるルームのみ)
+func (dao *chatDao) GetUnreadCounts(ctx context.Context, userID string) (map[string]int, error) {
+	query := `
+		SELECT r.id, COUNT(m.id)
+		FROM chat_rooms r
+		LEFT JOIN chat_read_states rs ON rs.chat_room_id = r.id AND rs.user_id = ?
+		INNER JOIN messages m ON m.chat_room_id = r.id
+			AND m.sender_id <> ?
+			AND m.id > COALESCE(rs.last_read_message_id, '')
+		WHERE r.buyer_id = ? OR r.seller_id = ?
+		GROUP BY r.id`
+
+	rows, err := dao.DB.QueryContext(ctx, query, userID, userID, userID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unread counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var roomID string
+		var count int
+		if err := rows.Scan(&roomID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan unread count: %w", err)
+		}
+		counts[roomID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return counts, nil
+}
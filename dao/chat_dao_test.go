@@ -0,0 +1,177 @@
+package dao
+
+import (
+	"context"
+	"db/model"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestChatDao_GetMessages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewChatDao(db)
+	ctx := context.Background()
+	now := time.Now()
+	columns := []string{"id", "chat_room_id", "sender_id", "type", "offer_id", "content", "created_at"}
+
+	// 最新ページ: 新しい順で取得し、古い順に並べ直して返す
+	t.Run("成功: カーソルなし", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("FROM messages WHERE chat_room_id = ? ORDER BY id DESC LIMIT ?")).
+			WithArgs("room1", 2).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("msg3", "room1", "user1", model.MessageTypeText, "", "c", now).
+				AddRow("msg2", "room1", "user2", model.MessageTypeText, "", "b", now))
+
+		msgs, err := dao.GetMessages(ctx, "room1", model.MessagePageQuery{Limit: 2})
+		if err != nil {
+			t.Fatalf("GetMessages() error = %v", err)
+		}
+		if len(msgs) != 2 || msgs[0].Id != "msg2" || msgs[1].Id != "msg3" {
+			t.Errorf("GetMessages() = %+v, want [msg2 msg3]", msgs)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("成功: beforeカーソル", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("FROM messages WHERE chat_room_id = ? AND id < ? ORDER BY id DESC LIMIT ?")).
+			WithArgs("room1", "msg3", 2).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("msg2", "room1", "user2", model.MessageTypeText, "", "b", now).
+				AddRow("msg1", "room1", "user1", model.MessageTypeText, "", "a", now))
+
+		msgs, err := dao.GetMessages(ctx, "room1", model.MessagePageQuery{Before: "msg3", Limit: 2})
+		if err != nil {
+			t.Fatalf("GetMessages() error = %v", err)
+		}
+		if len(msgs) != 2 || msgs[0].Id != "msg1" || msgs[1].Id != "msg2" {
+			t.Errorf("GetMessages() = %+v, want [msg1 msg2]", msgs)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("成功: afterカーソル", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("FROM messages WHERE chat_room_id = ? AND id > ? ORDER BY id ASC LIMIT ?")).
+			WithArgs("room1", "msg1", 2).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("msg2", "room1", "user2", model.MessageTypeText, "", "b", now).
+				AddRow("msg3", "room1", "user1", model.MessageTypeText, "", "c", now))
+
+		msgs, err := dao.GetMessages(ctx, "room1", model.MessagePageQuery{After: "msg1", Limit: 2})
+		if err != nil {
+			t.Fatalf("GetMessages() error = %v", err)
+		}
+		if len(msgs) != 2 || msgs[0].Id != "msg2" || msgs[1].Id != "msg3" {
+			t.Errorf("GetMessages() = %+v, want [msg2 msg3]", msgs)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: クエリエラー", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("FROM messages")).
+			WillReturnError(sqlmock.ErrCancelled)
+
+		if _, err := dao.GetMessages(ctx, "room1", model.MessagePageQuery{Limit: 2}); err == nil {
+			t.Errorf("GetMessages() expected error, got nil")
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestChatDao_GetChatInbox(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewChatDao(db)
+	ctx := context.Background()
+	now := time.Now()
+	columns := []string{
+		"id", "item_id", "name", "item_image_url", "seller_id",
+		"id", "name", "counterpart_icon_url", "last_message", "last_message_at", "unread_count", "created_at",
+	}
+
+	t.Run("成功: 購入希望者・出品者の両方のルームを取得", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("FROM chat_rooms r")).
+			WithArgs("user1", "user1", "user1", "user1", "user1", 20, 0).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("room1", "item1", "Item 1", "http://example.com/1.jpg", "seller1", "seller1", "Seller", "", "hello", now, 2, now).
+				AddRow("room2", "item2", "Item 2", "", "user1", "buyer2", "Buyer", "http://example.com/icon.jpg", "", nil, 0, now))
+
+		entries, err := dao.GetChatInbox(ctx, "user1", 20, 0)
+		if err != nil {
+			t.Fatalf("GetChatInbox() error = %v", err)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("GetChatInbox() got length = %v, want 2", len(entries))
+		}
+		if entries[0].Role != model.ChatRoleBuyer || entries[0].UnreadCount != 2 || entries[0].LastMessageAt == nil {
+			t.Errorf("entries[0] = %+v", entries[0])
+		}
+		if entries[1].Role != model.ChatRoleSeller || entries[1].LastMessageAt != nil {
+			t.Errorf("entries[1] = %+v", entries[1])
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestChatDao_GetSellerInquiries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewChatDao(db)
+	ctx := context.Background()
+	inquiredAt := time.Date(2026, 4, 1, 10, 0, 0, 0, time.UTC)
+	repliedAt := inquiredAt.Add(30 * time.Minute)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE r.seller_id = ?")+".*"+regexp.QuoteMeta("ORDER BY q.inquired_at DESC")).
+		WithArgs("seller1", 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "inquired_at", "first_reply_at"}).
+			AddRow("room2", inquiredAt.Add(time.Hour), nil).
+			AddRow("room1", inquiredAt, repliedAt))
+
+	inquiries, err := dao.GetSellerInquiries(ctx, "seller1", 100)
+	if err != nil {
+		t.Fatalf("GetSellerInquiries() error = %v", err)
+	}
+	if len(inquiries) != 2 {
+		t.Fatalf("GetSellerInquiries() returned %d inquiries, want 2", len(inquiries))
+	}
+	// 未返信の問い合わせは返信時刻がnil
+	if inquiries[0].ChatRoomId != "room2" || inquiries[0].FirstReplyAt != nil {
+		t.Errorf("inquiries[0] = %+v, want unanswered room2", inquiries[0])
+	}
+	if inquiries[1].ChatRoomId != "room1" || !inquiries[1].InquiredAt.Equal(inquiredAt) || inquiries[1].FirstReplyAt == nil || !inquiries[1].FirstReplyAt.Equal(repliedAt) {
+		t.Errorf("inquiries[1] = %+v, want room1 replied at %v", inquiries[1], repliedAt)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
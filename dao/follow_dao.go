@@ -0,0 +1,142 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"db/model"
+	"fmt"
+	"time"
+)
+
+type FollowDAO interface {
+	Follow(ctx context.Context, followerID, followeeID string) error
+	Unfollow(ctx context.Context, followerID, followeeID string) error
+	IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error)
+	GetFollowers(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error)
+	GetFollowing(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error)
+	GetFollowerIDs(ctx context.Context, userID string) ([]string, error)
+	GetFollowCounts(ctx context.Context, userID string) (*model.FollowCounts, error)
+}
+
+type followDao struct {
+	DB *sql.DB
+}
+
+// NewFollowDao : FollowDAOの生成
+func NewFollowDao(db *sql.DB) FollowDAO {
+	return &followDao{DB: db}
+}
+
+// Follow : フォローする (フォロー済みなら何もしない)
+func (dao *followDao) Follow(ctx context.Context, followerID, followeeID string) error {
+	query := `INSERT IGNORE INTO follows (follower_id, followee_id, created_at) VALUES (?, ?, ?)`
+	if _, err := dao.DB.ExecContext(ctx, query, followerID, followeeID, time.Now()); err != nil {
+		return fmt.Errorf("fail: insert follow: %w", err)
+	}
+	return nil
+}
+
+// Unfollow : フォローを解除する (フォローしていなければ何もしない)
+func (dao *followDao) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	query := `DELETE FROM follows WHERE follower_id = ? AND followee_id = ?`
+	if _, err := dao.DB.ExecContext(ctx, query, followerID, followeeID); err != nil {
+		return fmt.Errorf("fail: delete follow: %w", err)
+	}
+	return nil
+}
+
+// IsFollowing : followerIDがfolloweeIDをフォローしているか
+func (dao *followDao) IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM follows WHERE follower_id = ? AND followee_id = ?)`
+	if err := dao.DB.QueryRowContext(ctx, query, followerID, followeeID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("fail: check follow: %w", err)
+	}
+	return exists, nil
+}
+
+// GetFollowers : ユーザーのフォロワーをフォローされた新しい順に取得
+func (dao *followDao) GetFollowers(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error) {
+	query := `
+		SELECT u.id, u.name, COALESCE(u.icon_url, ''), f.created_at
+		FROM follows f
+		INNER JOIN users u ON f.follower_id = u.id
+		WHERE f.followee_id = ?
+		ORDER BY f.created_at DESC
+		LIMIT ? OFFSET ?`
+	return dao.queryFollowUsers(ctx, query, userID, limit, offset)
+}
+
+// GetFollowing : ユーザーがフォローしている人をフォローした新しい順に取得
+func (dao *followDao) GetFollowing(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error) {
+	query := `
+		SELECT u.id, u.name, COALESCE(u.icon_url, ''), f.created_at
+		FROM follows f
+		INNER JOIN users u ON f.followee_id = u.id
+		WHERE f.follower_id = ?
+		ORDER BY f.created_at DESC
+		LIMIT ? OFFSET ?`
+	return dao.queryFollowUsers(ctx, query, userID, limit, offset)
+}
+
+// queryFollowUsers : フォロー・フォロワー一覧のクエリを実行する
+func (dao *followDao) queryFollowUsers(ctx context.Context, query string, args ...interface{}) ([]model.FollowUser, error) {
+	rows, err := dao.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fail:dao.DB.Query:%w", err)
+	}
+	defer rows.Close()
+
+	users := make([]model.FollowUser, 0)
+	for rows.Next() {
+		var u model.FollowUser
+		if err := rows.Scan(&u.Id, &u.Name, &u.IconURL, &u.FollowedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetFollowerIDs : ユーザーのフォロワーのID一覧 (新着出品の通知先)
+func (dao *followDao) GetFollowerIDs(ctx context.Context, userID string) ([]string, error) {
+	rows, err := dao.DB.QueryContext(ctx, `SELECT follower_id FROM follows WHERE followee_id = ?`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("fail:dao.DB.Query:%w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetFollowCounts : フォロワー数とフォロー数
+func (dao *followDao) GetFollowCounts(ctx context.Context, userID string) (*model.FollowCounts, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM follows WHERE followee_id = ?),
+			(SELECT COUNT(*) FROM follows WHERE follower_id = ?)`
+
+	var counts model.FollowCounts
+	if err := dao.DB.QueryRowContext(ctx, query, userID, userID).Scan(&counts.Followers, &counts.Following); err != nil {
+		return nil, fmt.Errorf("fail: count follows: %w", err)
+	}
+	return &counts, nil
+}
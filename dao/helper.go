@@ -0,0 +1,23 @@
+package dao
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlErrDuplicateEntry : UNIQUE/PRIMARY KEY制約違反のエラー番号
+const mysqlErrDuplicateEntry = 1062
+
+// isDuplicateEntry : 一意制約違反のエラーか判定
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
+
+// isDuplicateKey : 指定した名前のUNIQUE KEYへの制約違反か判定 (MySQLのメッセージは "for key 'users.user_handle'" など)
+func isDuplicateKey(err error, key string) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry && strings.Contains(mysqlErr.Message, key+"'")
+}
@@ -14,14 +14,22 @@ import (
 
 type ItemDAO interface {
 	ItemInsert(ctx context.Context, item *model.Item) error
-	GetItemList(ctx context.Context, limit int, offset int) ([]model.ItemSimple, error)
-	SearchItems(ctx context.Context, keyword string, limit int, offset int) ([]model.ItemSimple, error)
+	GetItemList(ctx context.Context, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error)
+	SearchItems(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error)
+	CountItems(ctx context.Context, keyword string, filter model.ItemFilter) (int, error)
+	FilterItemIDs(ctx context.Context, itemIDs []string, filter model.ItemFilter) ([]string, error)
+	GetSearchDocuments(ctx context.Context) ([]model.ItemSearchDocument, error)
 	GetMyItems(ctx context.Context, sellerID string) ([]model.ItemSimple, error)
 	GetUserItems(ctx context.Context, userID string) ([]model.ItemSimple, error)
 	GetItem(ctx context.Context, itemID string) (*model.Item, error)
 	GetItemsByIDs(ctx context.Context, itemIDs []string) ([]model.ItemSimple, error)
-	PurchaseItem(ctx context.Context, itemID string, buyerID string) error
-	UpdateItem(ctx context.Context, itemID string, userID string, name string, price int, description string, imageURLs []string, embedding []float32) error
+	GetFollowingFeed(ctx context.Context, userID string, before string, limit int) ([]model.FeedItem, error)
+	GetSellerItemStats(ctx context.Context, sellerID string) (*model.SellerItemStats, error)
+	GetPurchasedItems(ctx context.Context, buyerID string, limit int, offset int) ([]model.PurchasedItem, error)
+	GetSoldItems(ctx context.Context, sellerID string, limit int, offset int) ([]model.SoldItem, error)
+	PurchaseItem(ctx context.Context, itemID string, buyerID string, orderID string, price int, reservationID string) error
+	UpdateItem(ctx context.Context, item *model.Item) (int, error)
+	WithdrawItem(ctx context.Context, itemID string, userID string) error
 	GetAllItemEmbeddings(ctx context.Context) (map[string][]float32, error)
 	GetItemEmbedding(ctx context.Context, itemID string) ([]float32, error)
 }
@@ -57,8 +65,8 @@ func (dao *itemDao) ItemInsert(ctx context.Context, item *model.Item) error {
 
 	now := time.Now()
 	queryItem := `INSERT INTO items 
-                  (id, user_id, name, description, price, embedding, created_at, updated_at) 
-                  VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+                  (id, user_id, name, description, price, category_id, item_condition, brand, shipping_payer, shipping_method, embedding, created_at, updated_at) 
+                  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err = tx.ExecContext(ctx, queryItem,
 		item.ItemId,
@@ -66,6 +74,11 @@ func (dao *itemDao) ItemInsert(ctx context.Context, item *model.Item) error {
 		item.Name,
 		item.Description,
 		item.Price,
+		nullableCategoryID(item.CategoryId),
+		item.Condition,
+		item.Brand,
+		item.ShippingPayer,
+		item.ShippingMethod,
 		embeddingJSON,
 		now,
 		now)
@@ -91,89 +104,126 @@ func (dao *itemDao) ItemInsert(ctx context.Context, item *model.Item) error {
 }
 
 // GetItemList : 商品一覧を取得
-func (dao *itemDao) GetItemList(ctx context.Context, limit int, offset int) ([]model.ItemSimple, error) {
+func (dao *itemDao) GetItemList(ctx context.Context, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
+	return dao.queryItems(ctx, "", filter, limit, offset)
+}
 
-	query := `
-		SELECT 
-			i.id, 
-			i.name, 
-			i.price, 
-			COALESCE((SELECT image_url FROM item_images WHERE item_id = i.id LIMIT 1), '') as image_url,
-			i.status
-		FROM items i
-		ORDER BY i.created_at DESC
-		LIMIT ? OFFSET ?`
+// SearchItems : キーワードで商品を検索
+func (dao *itemDao) SearchItems(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
+	return dao.queryItems(ctx, keyword, filter, limit, offset)
+}
+
+// CountItems : 一覧・検索と同じ条件に一致する商品の総数を取得 (keywordが空なら一覧)
+func (dao *itemDao) CountItems(ctx context.Context, keyword string, filter model.ItemFilter) (int, error) {
+	whereClause, args := itemWhereClause(keyword, filter)
+
+	var total int
+	query := `SELECT COUNT(*) FROM items i ` + whereClause
+	if err := dao.DB.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("fail:dao.DB.QueryRow:%w", err)
+	}
+
+	return total, nil
+}
+
+// FilterItemIDs : 指定した商品IDのうち、絞り込み条件に一致するものを返す (順序は保証しない)
+// ベクトル検索など、候補をアプリ側で選んだ後に一覧と同じ条件を適用するために使う
+func (dao *itemDao) FilterItemIDs(ctx context.Context, itemIDs []string, filter model.ItemFilter) ([]string, error) {
+	if len(itemIDs) == 0 {
+		return []string{}, nil
+	}
+
+	whereClause, args := itemWhereClause("", filter)
+	placeholders := make([]string, len(itemIDs))
+	for i, id := range itemIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	query := fmt.Sprintf(`SELECT i.id FROM items i %s AND i.id IN (%s)`, whereClause, strings.Join(placeholders, ","))
 
-	rows, err := dao.DB.QueryContext(ctx, query, limit, offset)
+	rows, err := dao.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("fail:dao.DB.Query:%w", err)
 	}
 	defer rows.Close()
 
-	// スライス（配列）の初期化
-	items := make([]model.ItemSimple, 0)
+	ids := make([]string, 0, len(itemIDs))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetSearchDocuments : キーワード検索インデックスの構築用に、取り下げ済み以外の商品の名前と説明文を取得
+func (dao *itemDao) GetSearchDocuments(ctx context.Context) ([]model.ItemSearchDocument, error) {
+	query := `SELECT id, name, COALESCE(description, '') FROM items WHERE status <> ?`
 
+	rows, err := dao.DB.QueryContext(ctx, query, model.StatusWithdrawn)
+	if err != nil {
+		return nil, fmt.Errorf("fail:dao.DB.Query:%w", err)
+	}
+	defer rows.Close()
+
+	docs := make([]model.ItemSearchDocument, 0)
 	for rows.Next() {
-		var i model.ItemSimple
-		if err := rows.Scan(&i.ItemId, &i.Name, &i.Price, &i.ImageURL, &i.Status); err != nil {
+		var doc model.ItemSearchDocument
+		if err := rows.Scan(&doc.ItemId, &doc.Name, &doc.Description); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
-		items = append(items, i)
+		docs = append(docs, doc)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
-	return items, nil
+	return docs, nil
 }
 
-// SearchItems : キーワードで商品を検索
-func (dao *itemDao) SearchItems(ctx context.Context, keyword string, limit int, offset int) ([]model.ItemSimple, error) {
-	query := `
+// likeCountExpr : 商品(i)のいいね数。各行でlikesの(item_id)インデックスを引くだけなので、商品ごとに別クエリを投げずに済む
+const likeCountExpr = `(SELECT COUNT(*) FROM likes l WHERE l.item_id = i.id)`
+
+// queryItems : 絞り込み条件と並び順を組み立てて商品を取得する
+func (dao *itemDao) queryItems(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
+	whereClause, args := itemWhereClause(keyword, filter)
+	orderClause, orderArgs := itemOrderClause(keyword, filter.Sort)
+
+	query := fmt.Sprintf(`
 		SELECT 
 			i.id, 
 			i.name, 
 			i.price, 
 			COALESCE((SELECT image_url FROM item_images WHERE item_id = i.id LIMIT 1), '') as image_url,
-			i.status
+			i.status,
+			%s AS like_count
 		FROM items i
-		WHERE (i.name LIKE ? OR i.name LIKE ? OR i.description LIKE ?)
-		ORDER BY 
-			CASE 
-				WHEN i.name LIKE ? THEN 1
-				WHEN i.name LIKE ? THEN 2
-				ELSE 3
-			END,
-			i.created_at DESC
-		LIMIT ? OFFSET ?`
+		%s
+		ORDER BY %s
+		LIMIT ? OFFSET ?`, likeCountExpr, whereClause, orderClause)
 
-	// LIKE特殊文字をエスケープ
-	escapedKeyword := escapeLikeString(keyword)
-	// 前方一致用
-	prefixKeyword := escapedKeyword + "%"
-	// 部分一致用
-	partialKeyword := "%" + escapedKeyword + "%"
-
-	rows, err := dao.DB.QueryContext(ctx, query,
-		prefixKeyword,  // WHERE i.name LIKE ?（前方一致）
-		partialKeyword, // OR i.name LIKE ?（部分一致）
-		partialKeyword, // OR i.description LIKE ?（説明文部分一致）
-		prefixKeyword,  // CASE WHEN i.name LIKE ?（前方一致判定）
-		partialKeyword, // WHEN i.name LIKE ?（部分一致判定）
-		limit,
-		offset,
-	)
+	args = append(args, orderArgs...)
+	args = append(args, limit, offset)
+	rows, err := dao.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("fail:dao.DB.Query:%w", err)
 	}
 	defer rows.Close()
 
+	// スライス（配列）の初期化
 	items := make([]model.ItemSimple, 0)
 
 	for rows.Next() {
 		var i model.ItemSimple
-		if err := rows.Scan(&i.ItemId, &i.Name, &i.Price, &i.ImageURL, &i.Status); err != nil {
+		if err := rows.Scan(&i.ItemId, &i.Name, &i.Price, &i.ImageURL, &i.Status, &i.LikeCount); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		items = append(items, i)
@@ -186,6 +236,126 @@ func (dao *itemDao) SearchItems(ctx context.Context, keyword string, limit int,
 	return items, nil
 }
 
+// itemWhereClause : キーワードと絞り込み条件をWHERE句と引数に変換する
+// 値はすべてプレースホルダで渡し、SQLに埋め込むのは固定の列名・演算子のみ
+func itemWhereClause(keyword string, filter model.ItemFilter) (string, []interface{}) {
+	var clause strings.Builder
+	args := make([]interface{}, 0)
+
+	if filter.Status != "" {
+		clause.WriteString("WHERE i.status = ?")
+		args = append(args, filter.Status)
+	} else {
+		// 取り下げ済みは常に除外
+		clause.WriteString("WHERE i.status <> ?")
+		args = append(args, model.StatusWithdrawn)
+	}
+
+	if keyword != "" {
+		// LIKE特殊文字をエスケープ
+		escapedKeyword := escapeLikeString(keyword)
+		clause.WriteString(" AND (i.name LIKE ? OR i.name LIKE ? OR i.description LIKE ?)")
+		args = append(args,
+			escapedKeyword+"%",     // i.name LIKE ?（前方一致）
+			"%"+escapedKeyword+"%", // OR i.name LIKE ?（部分一致）
+			"%"+escapedKeyword+"%", // OR i.description LIKE ?（説明文部分一致）
+		)
+	}
+
+	if len(filter.CategoryIds) > 0 {
+		placeholders := make([]string, len(filter.CategoryIds))
+		for i, id := range filter.CategoryIds {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		clause.WriteString(fmt.Sprintf(" AND i.category_id IN (%s)", strings.Join(placeholders, ",")))
+	}
+	if filter.Condition != "" {
+		clause.WriteString(" AND i.item_condition = ?")
+		args = append(args, filter.Condition)
+	}
+	if filter.Brand != "" {
+		clause.WriteString(" AND i.brand = ?")
+		args = append(args, filter.Brand)
+	}
+	if filter.ShippingPayer != "" {
+		clause.WriteString(" AND i.shipping_payer = ?")
+		args = append(args, filter.ShippingPayer)
+	}
+	if filter.ShippingMethod != "" {
+		clause.WriteString(" AND i.shipping_method = ?")
+		args = append(args, filter.ShippingMethod)
+	}
+	if filter.MinPrice > 0 {
+		clause.WriteString(" AND i.price >= ?")
+		args = append(args, filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		clause.WriteString(" AND i.price <= ?")
+		args = append(args, filter.MaxPrice)
+	}
+	if filter.SellerId != "" {
+		clause.WriteString(" AND i.user_id = ?")
+		args = append(args, filter.SellerId)
+	}
+	if len(filter.ItemIds) > 0 {
+		placeholders := make([]string, len(filter.ItemIds))
+		for i, id := range filter.ItemIds {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		clause.WriteString(fmt.Sprintf(" AND i.id IN (%s)", strings.Join(placeholders, ",")))
+	}
+	if len(filter.ExcludeSellerIds) > 0 {
+		placeholders := make([]string, len(filter.ExcludeSellerIds))
+		for i, id := range filter.ExcludeSellerIds {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		clause.WriteString(fmt.Sprintf(" AND i.user_id NOT IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	return clause.String(), args
+}
+
+// itemOrderClause : 並び順をORDER BY句と引数に変換する
+// 未指定の場合、キーワード検索は一致度順(名前の前方一致→部分一致→説明文)、一覧は新着順
+func itemOrderClause(keyword string, sort string) (string, []interface{}) {
+	switch sort {
+	case model.SortPriceAsc:
+		return "i.price ASC, i.created_at DESC", nil
+	case model.SortPriceDesc:
+		return "i.price DESC, i.created_at DESC", nil
+	case model.SortPopular:
+		return likeCountExpr + " DESC, i.created_at DESC", nil
+	case model.SortNewest:
+		return "i.created_at DESC", nil
+	}
+
+	if keyword == "" {
+		return "i.created_at DESC", nil
+	}
+	escapedKeyword := escapeLikeString(keyword)
+	return `
+			CASE 
+				WHEN i.name LIKE ? THEN 1
+				WHEN i.name LIKE ? THEN 2
+				ELSE 3
+			END,
+			i.created_at DESC`, []interface{}{
+		escapedKeyword + "%",       // CASE WHEN i.name LIKE ?（前方一致判定）
+		"%" + escapedKeyword + "%", // WHEN i.name LIKE ?（部分一致判定）
+	}
+}
+
+// nullableCategoryID : 未指定(0)のカテゴリはNULLとして保存する
+func nullableCategoryID(categoryID int) interface{} {
+	if categoryID == 0 {
+		return nil
+	}
+	return categoryID
+}
+
 // escapeLikeString LIKE句の特殊文字をエスケープ
 func escapeLikeString(s string) string {
 	s = strings.ReplaceAll(s, "\\", "\\\\")
@@ -202,7 +372,8 @@ func (dao *itemDao) GetMyItems(ctx context.Context, sellerID string) ([]model.It
 			i.name, 
 			i.price, 
 			i.status,
-			COALESCE(MIN(img.image_url), '') AS image_url
+			COALESCE(MIN(img.image_url), '') AS image_url,
+			` + likeCountExpr + ` AS like_count
 		FROM items i
 		LEFT JOIN item_images img ON i.id = img.item_id
 		WHERE i.user_id = ?
@@ -219,7 +390,7 @@ func (dao *itemDao) GetMyItems(ctx context.Context, sellerID string) ([]model.It
 	items := make([]model.ItemSimple, 0)
 	for rows.Next() {
 		var item model.ItemSimple
-		if err := rows.Scan(&item.ItemId, &item.Name, &item.Price, &item.Status, &item.ImageURL); err != nil {
+		if err := rows.Scan(&item.ItemId, &item.Name, &item.Price, &item.Status, &item.ImageURL, &item.LikeCount); err != nil {
 			return nil, fmt.Errorf("fail:rows.Scan:%w", err)
 		}
 		items = append(items, item)
@@ -232,7 +403,7 @@ func (dao *itemDao) GetMyItems(ctx context.Context, sellerID string) ([]model.It
 	return items, nil
 }
 
-// GetUserItems : 指定ユーザーの出品商品一覧を取得（プロフィールページ用）
+// GetUserItems : 指定ユーザーの出品商品一覧を取得（プロフィールページ用、取り下げ済みは除く）
 func (dao *itemDao) GetUserItems(ctx context.Context, userID string) ([]model.ItemSimple, error) {
 	query := `
 		SELECT 
@@ -240,15 +411,16 @@ func (dao *itemDao) GetUserItems(ctx context.Context, userID string) ([]model.It
 			i.name, 
 			i.price, 
 			i.status,
-			COALESCE(MIN(img.image_url), '') AS image_url
+			COALESCE(MIN(img.image_url), '') AS image_url,
+			` + likeCountExpr + ` AS like_count
 		FROM items i
 		LEFT JOIN item_images img ON i.id = img.item_id
-		WHERE i.user_id = ?
+		WHERE i.user_id = ? AND i.status <> ?
 		GROUP BY i.id, i.name, i.price, i.status
 		ORDER BY i.created_at DESC
 	`
 
-	rows, err := dao.DB.QueryContext(ctx, query, userID)
+	rows, err := dao.DB.QueryContext(ctx, query, userID, model.StatusWithdrawn)
 	if err != nil {
 		return nil, fmt.Errorf("fail:db.Query:%w", err)
 	}
@@ -257,7 +429,7 @@ func (dao *itemDao) GetUserItems(ctx context.Context, userID string) ([]model.It
 	items := make([]model.ItemSimple, 0)
 	for rows.Next() {
 		var item model.ItemSimple
-		if err := rows.Scan(&item.ItemId, &item.Name, &item.Price, &item.Status, &item.ImageURL); err != nil {
+		if err := rows.Scan(&item.ItemId, &item.Name, &item.Price, &item.Status, &item.ImageURL, &item.LikeCount); err != nil {
 			return nil, fmt.Errorf("fail:rows.Scan:%w", err)
 		}
 		items = append(items, item)
@@ -287,7 +459,20 @@ func (dao *itemDao) GetItem(ctx context.Context, itemID string) (*model.Item, er
 			i.created_at, 
 			i.updated_at,
 			u.name as seller_name,
-			COALESCE(u.icon_url, '') as seller_icon_url
+			COALESCE(u.icon_url, '') as seller_icon_url,
+			COALESCE(i.buyer_id, '') as buyer_id,
+			COALESCE(i.category_id, 0) as category_id,
+			i.item_condition,
+			i.brand,
+			i.shipping_payer,
+			i.shipping_method,
+			COALESCE((
+				SELECT h.old_price FROM item_price_history h
+				WHERE h.item_id = i.id
+				ORDER BY h.changed_at DESC, h.id DESC
+				LIMIT 1
+			), 0) as previous_price,
+			` + likeCountExpr + ` as like_count
 		FROM items i
 		INNER JOIN users u ON i.user_id = u.id
 		WHERE i.id = ?
@@ -295,6 +480,7 @@ func (dao *itemDao) GetItem(ctx context.Context, itemID string) (*model.Item, er
 	row := dao.DB.QueryRowContext(ctx, queryItem, itemID)
 
 	var item model.Item
+	var previousPrice int
 	if err := row.Scan(
 		&item.ItemId,
 		&item.UserId,
@@ -306,9 +492,24 @@ func (dao *itemDao) GetItem(ctx context.Context, itemID string) (*model.Item, er
 		&item.UpdatedAt,
 		&item.SellerName,
 		&item.SellerIconURL,
+		&item.BuyerId,
+		&item.CategoryId,
+		&item.Condition,
+		&item.Brand,
+		&item.ShippingPayer,
+		&item.ShippingMethod,
+		&previousPrice,
+		&item.LikeCount,
 	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrItemNotFound
+		}
 		return nil, fmt.Errorf("fail: fetch item: %w", err)
 	}
+	// 直近の価格変更が値下げの場合のみ元の価格を見せる
+	if previousPrice > item.Price {
+		item.PreviousPrice = previousPrice
+	}
 
 	// 画像一覧を取得
 	queryImages := "SELECT image_url FROM item_images WHERE item_id = ?"
@@ -351,7 +552,8 @@ func (dao *itemDao) GetItemsByIDs(ctx context.Context, itemIDs []string) ([]mode
 			i.name, 
 			i.price, 
 			i.status,
-			COALESCE(MIN(img.image_url), '') AS image_url
+			COALESCE(MIN(img.image_url), '') AS image_url,
+			`+likeCountExpr+` AS like_count
 		FROM items i
 		LEFT JOIN item_images img ON i.id = img.item_id
 		WHERE i.id IN (%s)
@@ -368,7 +570,7 @@ func (dao *itemDao) GetItemsByIDs(ctx context.Context, itemIDs []string) ([]mode
 	itemMap := make(map[string]model.ItemSimple)
 	for rows.Next() {
 		var item model.ItemSimple
-		if err := rows.Scan(&item.ItemId, &item.Name, &item.Price, &item.Status, &item.ImageURL); err != nil {
+		if err := rows.Scan(&item.ItemId, &item.Name, &item.Price, &item.Status, &item.ImageURL, &item.LikeCount); err != nil {
 			return nil, fmt.Errorf("fail:rows.Scan:%w", err)
 		}
 		itemMap[item.ItemId] = item
@@ -389,8 +591,205 @@ func (dao *itemDao) GetItemsByIDs(ctx context.Context, itemIDs []string) ([]mode
 	return results, nil
 }
 
-// PurchaseItem : 指定されたitemIDの商品を購入済みにする
-func (dao *itemDao) PurchaseItem(ctx context.Context, itemID string, buyerID string) error {
+// GetSellerItemStats : 出品者の出品数・売れた数・出品から購入までの平均秒数 (取り下げ済みは含めない)
+func (dao *itemDao) GetSellerItemStats(ctx context.Context, sellerID string) (*model.SellerItemStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(status = ?), 0),
+			AVG(CASE WHEN status = ? AND purchased_at IS NOT NULL THEN TIMESTAMPDIFF(SECOND, created_at, purchased_at) END)
+		FROM items
+		WHERE user_id = ? AND status <> ?`
+
+	var stats model.SellerItemStats
+	var avg sql.NullFloat64
+	err := dao.DB.QueryRowContext(ctx, query, model.StatusSold, model.StatusSold, sellerID, model.StatusWithdrawn).
+		Scan(&stats.ListingCount, &stats.SoldCount, &avg)
+	if err != nil {
+		return nil, fmt.Errorf("fail: fetch seller item stats: %w", err)
+	}
+	if avg.Valid {
+		stats.AvgTimeToSellSeconds = &avg.Float64
+	}
+
+	return &stats, nil
+}
+
+// GetFollowingFeed : フォロー中の出品者の販売中の商品を新しい順に取得
+// beforeには前のページの最後の商品ID(ULID)を渡し、それより古いものを最大limit件返す
+func (dao *itemDao) GetFollowingFeed(ctx context.Context, userID string, before string, limit int) ([]model.FeedItem, error) {
+	query := `
+		SELECT
+			i.id,
+			i.name,
+			i.price,
+			i.status,
+			COALESCE((SELECT image_url FROM item_images WHERE item_id = i.id LIMIT 1), '') AS image_url,
+			` + likeCountExpr + ` AS like_count,
+			i.user_id,
+			u.name,
+			COALESCE(u.icon_url, ''),
+			i.created_at
+		FROM follows f
+		INNER JOIN items i ON i.user_id = f.followee_id
+		INNER JOIN users u ON i.user_id = u.id
+		WHERE f.follower_id = ? AND i.status = ?`
+	args := []interface{}{userID, model.StatusOnSale}
+	if before != "" {
+		query += ` AND i.id < ?`
+		args = append(args, before)
+	}
+	query += ` ORDER BY i.id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := dao.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fail:db.Query:%w", err)
+	}
+	defer rows.Close()
+
+	items := make([]model.FeedItem, 0)
+	for rows.Next() {
+		var item model.FeedItem
+		if err := rows.Scan(
+			&item.ItemId,
+			&item.Name,
+			&item.Price,
+			&item.Status,
+			&item.ImageURL,
+			&item.LikeCount,
+			&item.SellerId,
+			&item.SellerName,
+			&item.SellerIconURL,
+			&item.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("fail:rows.Scan:%w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetPurchasedItems : 指定ユーザーが購入した商品を購入日時の新しい順に取得
+// 支払った金額はキャンセルされていない取引(orders)の金額を優先し、取引がない購入は商品価格を使う
+func (dao *itemDao) GetPurchasedItems(ctx context.Context, buyerID string, limit int, offset int) ([]model.PurchasedItem, error) {
+	query := `
+		SELECT
+			i.id,
+			i.name,
+			COALESCE((SELECT image_url FROM item_images WHERE item_id = i.id LIMIT 1), '') AS image_url,
+			COALESCE(o.price, i.price) AS price,
+			COALESCE(i.purchased_at, i.updated_at) AS purchased_at,
+			COALESCE(o.id, '') AS order_id,
+			COALESCE(o.status, '') AS order_status,
+			u.id,
+			u.name,
+			COALESCE(u.icon_url, '')
+		FROM items i
+		INNER JOIN users u ON i.user_id = u.id
+		LEFT JOIN orders o ON o.item_id = i.id AND o.buyer_id = i.buyer_id AND o.status <> ?
+		WHERE i.buyer_id = ? AND i.status = ?
+		ORDER BY purchased_at DESC, i.id DESC
+		LIMIT ? OFFSET ?`
+
+	rows, err := dao.DB.QueryContext(ctx, query, model.OrderStatusCancelled, buyerID, model.StatusSold, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("fail:db.Query:%w", err)
+	}
+	defer rows.Close()
+
+	items := make([]model.PurchasedItem, 0)
+	for rows.Next() {
+		var item model.PurchasedItem
+		if err := rows.Scan(
+			&item.ItemId,
+			&item.Name,
+			&item.ImageURL,
+			&item.Price,
+			&item.PurchasedAt,
+			&item.OrderId,
+			&item.OrderStatus,
+			&item.SellerId,
+			&item.SellerName,
+			&item.SellerIconURL,
+		); err != nil {
+			return nil, fmt.Errorf("fail:rows.Scan:%w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetSoldItems : 指定ユーザーが出品して売れた商品を購入日時の新しい順に取得 (購入者名つき)
+func (dao *itemDao) GetSoldItems(ctx context.Context, sellerID string, limit int, offset int) ([]model.SoldItem, error) {
+	query := `
+		SELECT
+			i.id,
+			i.name,
+			COALESCE((SELECT image_url FROM item_images WHERE item_id = i.id LIMIT 1), '') AS image_url,
+			COALESCE(o.price, i.price) AS price,
+			COALESCE(i.purchased_at, i.updated_at) AS sold_at,
+			COALESCE(o.id, '') AS order_id,
+			COALESCE(o.status, '') AS order_status,
+			u.id,
+			u.name,
+			COALESCE(u.icon_url, '')
+		FROM items i
+		INNER JOIN users u ON i.buyer_id = u.id
+		LEFT JOIN orders o ON o.item_id = i.id AND o.buyer_id = i.buyer_id AND o.status <> ?
+		WHERE i.user_id = ? AND i.status = ?
+		ORDER BY sold_at DESC, i.id DESC
+		LIMIT ? OFFSET ?`
+
+	rows, err := dao.DB.QueryContext(ctx, query, model.OrderStatusCancelled, sellerID, model.StatusSold, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("fail:db.Query:%w", err)
+	}
+	defer rows.Close()
+
+	items := make([]model.SoldItem, 0)
+	for rows.Next() {
+		var item model.SoldItem
+		if err := rows.Scan(
+			&item.ItemId,
+			&item.Name,
+			&item.ImageURL,
+			&item.Price,
+			&item.SoldAt,
+			&item.OrderId,
+			&item.OrderStatus,
+			&item.BuyerId,
+			&item.BuyerName,
+			&item.BuyerIconURL,
+		); err != nil {
+			return nil, fmt.Errorf("fail:rows.Scan:%w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return items, nil
+}
+
+// PurchaseItem : 指定されたitemIDの商品を購入済みにし、支払い待ちの取引(orders)を作成する
+// priceは取引金額 (通常は出品価格、価格交渉で合意した場合はその金額)
+// reservationIDは購入者自身の取り置き(合意済みの価格交渉)のID (なければ空)。商品の行をロックしてから
+// 取り置きを確かめ直し、他の購入希望者の取り置きがあればErrItemReserved、自分の取り置きが切れていればErrInvalidOfferTransitionを返す
+// 取り置きで購入した場合は価格交渉も同じトランザクションで購入済みにする
+func (dao *itemDao) PurchaseItem(ctx context.Context, itemID string, buyerID string, orderID string, price int, reservationID string) error {
 
 	tx, err := dao.DB.BeginTx(ctx, nil)
 	if err != nil {
@@ -402,9 +801,30 @@ func (dao *itemDao) PurchaseItem(ctx context.Context, itemID string, buyerID str
 		}
 	}()
 
-	query := `UPDATE items SET status = ?, buyer_id = ?, purchased_at = ? WHERE id = ? AND status = ?`
+	// 価格交渉の承諾と同時に走っても取り置きを見落とさないよう、商品の行をロックしてから確かめる
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM items WHERE id = ? FOR UPDATE`, itemID).Scan(&status)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("fail: lock item: %w", err)
+	}
+	if errors.Is(err, sql.ErrNoRows) || status != model.StatusOnSale {
+		return fmt.Errorf("item not found or already sold")
+	}
 
 	now := time.Now()
+	activeID, _, err := activeReservation(ctx, tx, itemID, now)
+	if err != nil {
+		return err
+	}
+	if activeID != reservationID {
+		if activeID != "" {
+			return model.ErrItemReserved
+		}
+		return fmt.Errorf("%w: reservation expired", model.ErrInvalidOfferTransition)
+	}
+
+	query := `UPDATE items SET status = ?, buyer_id = ?, purchased_at = ? WHERE id = ? AND status = ?`
+
 	result, err := tx.ExecContext(ctx, query, model.StatusSold, buyerID, now, itemID, model.StatusOnSale)
 	if err != nil {
 		return fmt.Errorf("fail: update item status: %w", err)
@@ -419,6 +839,20 @@ func (dao *itemDao) PurchaseItem(ctx context.Context, itemID string, buyerID str
 		return fmt.Errorf("item not found or already sold")
 	}
 
+	// 出品者は商品から引き継ぐ
+	orderQuery := `INSERT INTO orders (id, item_id, buyer_id, seller_id, price, status, created_at, updated_at)
+	               SELECT ?, id, ?, user_id, ?, ?, ?, ? FROM items WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, orderQuery, orderID, buyerID, price, model.OrderStatusAwaitingPayment, now, now, itemID); err != nil {
+		return fmt.Errorf("fail: insert order: %w", err)
+	}
+
+	if reservationID != "" {
+		offerQuery := `UPDATE offers SET status = ?, updated_at = ? WHERE id = ? AND status = ?`
+		if _, err := tx.ExecContext(ctx, offerQuery, model.OfferStatusPurchased, now, reservationID, model.OfferStatusAccepted); err != nil {
+			return fmt.Errorf("fail: mark offer purchased: %w", err)
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("fail: tx.Commit(): %w", err)
 	}
@@ -426,11 +860,13 @@ func (dao *itemDao) PurchaseItem(ctx context.Context, itemID string, buyerID str
 	return nil
 }
 
-// UpdateItem : 商品情報を更新
-func (dao *itemDao) UpdateItem(ctx context.Context, itemID string, userID string, name string, price int, description string, imageURLs []string, embedding []float32) error {
+// UpdateItem : 商品情報を更新し、行ロック下で読んだ更新前の価格を返す (値下げ通知の判定に使う)
+func (dao *itemDao) UpdateItem(ctx context.Context, item *model.Item) (int, error) {
+	itemID := item.ItemId
+
 	tx, err := dao.DB.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() {
 		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
@@ -438,66 +874,166 @@ func (dao *itemDao) UpdateItem(ctx context.Context, itemID string, userID string
 		}
 	}()
 
-	// 商品の所有者確認と売却済みチェック
+	// 商品の所有者確認と売却済みチェック (価格履歴を正しく残すため行ロック)
 	var ownerID string
 	var status string
-	checkQuery := `SELECT user_id, status FROM items WHERE id = ?`
-	err = tx.QueryRowContext(ctx, checkQuery, itemID).Scan(&ownerID, &status)
+	var oldPrice int
+	checkQuery := `SELECT user_id, status, price FROM items WHERE id = ? FOR UPDATE`
+	err = tx.QueryRowContext(ctx, checkQuery, itemID).Scan(&ownerID, &status, &oldPrice)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("item not found")
+			return 0, fmt.Errorf("item not found")
 		}
-		return fmt.Errorf("failed to check item owner: %w", err)
+		return 0, fmt.Errorf("failed to check item owner: %w", err)
 	}
 
 	// 所有者チェック
-	if ownerID != userID {
-		return model.ErrNotAuthorized
+	if ownerID != item.UserId {
+		return 0, model.ErrNotAuthorized
 	}
 
 	// 売却済みチェック
 	if status == model.StatusSold {
-		return model.ErrCannotUpdateSoldItem
+		return 0, model.ErrCannotUpdateSoldItem
+	}
+
+	// 取り下げ済みの商品は存在しない扱い
+	if status == model.StatusWithdrawn {
+		return 0, model.ErrItemNotFound
 	}
 
 	// 商品情報を更新
 	var embeddingJSON interface{} = nil
-	if len(embedding) > 0 {
-		b, err := json.Marshal(embedding)
+	if len(item.Embedding) > 0 {
+		b, err := json.Marshal(item.Embedding)
 		if err == nil {
 			embeddingJSON = string(b)
 		}
 	}
 	now := time.Now()
-	updateQuery := `UPDATE items SET name = ?, price = ?, description = ?, embedding = ?, updated_at = ? WHERE id = ?`
-	result, err := tx.ExecContext(ctx, updateQuery, name, price, description, embeddingJSON, now, itemID)
+	updateQuery := `
+		UPDATE items
+		SET name = ?, price = ?, description = ?, category_id = ?,
+			item_condition = ?, brand = ?, shipping_payer = ?, shipping_method = ?,
+			embedding = ?, updated_at = ?
+		WHERE id = ?`
+	result, err := tx.ExecContext(ctx, updateQuery,
+		item.Name,
+		item.Price,
+		item.Description,
+		nullableCategoryID(item.CategoryId),
+		item.Condition,
+		item.Brand,
+		item.ShippingPayer,
+		item.ShippingMethod,
+		embeddingJSON,
+		now,
+		itemID)
 	if err != nil {
-		return fmt.Errorf("failed to update item: %w", err)
+		return 0, fmt.Errorf("failed to update item: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("item not found")
+		return 0, fmt.Errorf("item not found")
+	}
+
+	// 価格が変わった場合は履歴を残す (詳細画面の「値下げ前の価格」に使う)
+	if item.Price != oldPrice {
+		historyQuery := `INSERT INTO item_price_history (item_id, old_price, new_price, changed_at) VALUES (?, ?, ?, ?)`
+		if _, err := tx.ExecContext(ctx, historyQuery, itemID, oldPrice, item.Price, now); err != nil {
+			return 0, fmt.Errorf("failed to insert price history: %w", err)
+		}
 	}
 
 	// 既存の画像を削除
 	deleteImagesQuery := `DELETE FROM item_images WHERE item_id = ?`
 	_, err = tx.ExecContext(ctx, deleteImagesQuery, itemID)
 	if err != nil {
-		return fmt.Errorf("failed to delete old images: %w", err)
+		return 0, fmt.Errorf("failed to delete old images: %w", err)
 	}
 
 	// 新しい画像を挿入
 	insertImageQuery := `INSERT INTO item_images (item_id, image_url, created_at) VALUES (?, ?, ?)`
-	for _, imgURL := range imageURLs {
+	for _, imgURL := range item.ImageURLs {
 		_, err := tx.ExecContext(ctx, insertImageQuery, itemID, imgURL, now)
 		if err != nil {
-			return fmt.Errorf("failed to insert image: %w", err)
+			return 0, fmt.Errorf("failed to insert image: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return oldPrice, nil
+}
+
+// WithdrawItem : 出品を取り下げる (所有者のみ、売却済み・取り置き中は不可)
+// 取り下げた商品へのいいねの削除と回答待ちの価格交渉の拒否は同じトランザクションで行う
+func (dao *itemDao) WithdrawItem(ctx context.Context, itemID string, userID string) error {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("failed to rollback transaction: %v\n", err)
+		}
+	}()
+
+	// 商品の所有者確認と状態チェック (購入と競合しないよう行ロック)
+	var ownerID string
+	var status string
+	checkQuery := `SELECT user_id, status FROM items WHERE id = ? FOR UPDATE`
+	err = tx.QueryRowContext(ctx, checkQuery, itemID).Scan(&ownerID, &status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrItemNotFound
 		}
+		return fmt.Errorf("failed to check item owner: %w", err)
+	}
+
+	// 所有者チェック
+	if ownerID != userID {
+		return model.ErrNotAuthorized
+	}
+
+	switch status {
+	case model.StatusSold:
+		// 購入後の取引がある商品は取り下げられない
+		return model.ErrItemInTransaction
+	case model.StatusWithdrawn:
+		return model.ErrItemNotFound
+	}
+
+	// 価格交渉で取り置き中なら取引中として扱う (ロック後に確かめるので承諾と入れ違いにならない)
+	now := time.Now()
+	reservationID, _, err := activeReservation(ctx, tx, itemID, now)
+	if err != nil {
+		return err
+	}
+	if reservationID != "" {
+		return model.ErrItemInTransaction
+	}
+
+	updateQuery := `UPDATE items SET status = ?, updated_at = ? WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, updateQuery, model.StatusWithdrawn, now, itemID); err != nil {
+		return fmt.Errorf("failed to withdraw item: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM likes WHERE item_id = ?`, itemID); err != nil {
+		return fmt.Errorf("failed to delete likes: %w", err)
+	}
+
+	// 回答待ちの価格交渉は取り下げた商品では進められないので拒否にする
+	offerQuery := `UPDATE offers SET status = ?, updated_at = ? WHERE item_id = ? AND status = ?`
+	if _, err := tx.ExecContext(ctx, offerQuery, model.OfferStatusRejected, now, itemID, model.OfferStatusPending); err != nil {
+		return fmt.Errorf("failed to reject offers: %w", err)
 	}
 
 	if err := tx.Commit(); err != nil {
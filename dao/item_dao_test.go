@@ -3,8 +3,11 @@ package dao
 import (
 	"context"
 	"db/model"
+	"errors"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 )
@@ -28,6 +31,8 @@ func TestItemDao_ItemInsert(t *testing.T) {
 		Price:       1000,
 		Embedding:   []float32{0.1, 0.2},
 		ImageURLs:   []string{"http://example.com/1.jpg"},
+		Condition:   model.ConditionLikeNew,
+		Brand:       "Brand",
 	}
 
 	// 成功ケース
@@ -44,6 +49,11 @@ func TestItemDao_ItemInsert(t *testing.T) {
 				item.Name,
 				item.Description,
 				item.Price,
+				nil, // category_id (未指定はNULL)
+				item.Condition,
+				item.Brand,
+				"",               // shipping_payer (未指定)
+				"",               // shipping_method (未指定)
 				sqlmock.AnyArg(), // embedding JSON
 				sqlmock.AnyArg(), // created_at
 				sqlmock.AnyArg(), // updated_at
@@ -103,11 +113,13 @@ func TestItemDao_PurchaseItem(t *testing.T) {
 
 	itemID := "item1"
 	buyerID := "buyer1"
+	orderID := "order1"
+	price := 800
 
-	// 成功ケース
-	t.Run("成功: 購入処理", func(t *testing.T) {
-		mock.ExpectBegin()
+	lockQuery := regexp.QuoteMeta("SELECT status FROM items WHERE id = ? FOR UPDATE")
+	reservationQuery := regexp.QuoteMeta("SELECT id, buyer_id FROM offers WHERE item_id = ? AND status = ? AND expires_at > ?")
 
+	expectPurchase := func() {
 		mock.ExpectExec(regexp.QuoteMeta("UPDATE items SET status = ?, buyer_id = ?, purchased_at = ? WHERE id = ? AND status = ?")).
 			WithArgs(
 				model.StatusSold,
@@ -118,9 +130,32 @@ func TestItemDao_PurchaseItem(t *testing.T) {
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1)) // 1行更新
 
+		// 取引(orders)の作成
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO orders")).
+			WithArgs(
+				orderID,
+				buyerID,
+				price,
+				model.OrderStatusAwaitingPayment,
+				sqlmock.AnyArg(), // created_at
+				sqlmock.AnyArg(), // updated_at
+				itemID,
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	// 成功ケース
+	t.Run("成功: 購入処理", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(itemID).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.StatusOnSale))
+		mock.ExpectQuery(reservationQuery).
+			WithArgs(itemID, model.OfferStatusAccepted, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "buyer_id"}))
+		expectPurchase()
 		mock.ExpectCommit()
 
-		err := dao.PurchaseItem(ctx, itemID, buyerID)
+		err := dao.PurchaseItem(ctx, itemID, buyerID, orderID, price, "")
 		if err != nil {
 			t.Errorf("PurchaseItem() error = %v", err)
 		}
@@ -130,16 +165,74 @@ func TestItemDao_PurchaseItem(t *testing.T) {
 		}
 	})
 
-	// 失敗ケース: 更新対象なし (既に売れている場合など)
-	t.Run("失敗: 更新対象なし", func(t *testing.T) {
+	t.Run("成功: 取り置きで購入すると価格交渉も購入済みにする", func(t *testing.T) {
 		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(itemID).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.StatusOnSale))
+		mock.ExpectQuery(reservationQuery).
+			WithArgs(itemID, model.OfferStatusAccepted, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "buyer_id"}).AddRow("offer1", buyerID))
+		expectPurchase()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE offers SET status = ?, updated_at = ? WHERE id = ? AND status = ?")).
+			WithArgs(model.OfferStatusPurchased, sqlmock.AnyArg(), "offer1", model.OfferStatusAccepted).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
-		mock.ExpectExec(regexp.QuoteMeta("UPDATE items SET status = ?, buyer_id = ?, purchased_at = ? WHERE id = ? AND status = ?")).
-			WillReturnResult(sqlmock.NewResult(0, 0)) // 0行更新
+		if err := dao.PurchaseItem(ctx, itemID, buyerID, orderID, price, "offer1"); err != nil {
+			t.Errorf("PurchaseItem() error = %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: ロック後に他の購入希望者の取り置きが見つかる", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(itemID).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.StatusOnSale))
+		mock.ExpectQuery(reservationQuery).
+			WithArgs(itemID, model.OfferStatusAccepted, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "buyer_id"}).AddRow("offer2", "buyer2"))
+		mock.ExpectRollback()
+
+		err := dao.PurchaseItem(ctx, itemID, buyerID, orderID, price, "")
+		if !errors.Is(err, model.ErrItemReserved) {
+			t.Errorf("PurchaseItem() error = %v, want %v", err, model.ErrItemReserved)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: 自分の取り置きの期限が切れている", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(itemID).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.StatusOnSale))
+		mock.ExpectQuery(reservationQuery).
+			WithArgs(itemID, model.OfferStatusAccepted, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "buyer_id"}))
+		mock.ExpectRollback()
+
+		err := dao.PurchaseItem(ctx, itemID, buyerID, orderID, price, "offer1")
+		if !errors.Is(err, model.ErrInvalidOfferTransition) {
+			t.Errorf("PurchaseItem() error = %v, want %v", err, model.ErrInvalidOfferTransition)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
 
+	// 失敗ケース: 既に売れている場合など
+	t.Run("失敗: 販売中でない", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(itemID).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.StatusSold))
 		mock.ExpectRollback()
 
-		err := dao.PurchaseItem(ctx, itemID, buyerID)
+		err := dao.PurchaseItem(ctx, itemID, buyerID, orderID, price, "")
 		if err == nil {
 			t.Errorf("PurchaseItem() expected error, got nil")
 		} else if err.Error() != "item not found or already sold" {
@@ -151,3 +244,286 @@ func TestItemDao_PurchaseItem(t *testing.T) {
 		}
 	})
 }
+
+func TestItemDao_GetPurchasedItems(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewItemDao(db)
+	ctx := context.Background()
+	now := time.Now()
+	columns := []string{
+		"id", "name", "image_url", "price", "purchased_at", "order_id", "order_status", "id", "name", "icon_url",
+	}
+
+	t.Run("成功: 取引の金額と出品者の概要を返す", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE i.buyer_id = ? AND i.status = ?")).
+			WithArgs(model.OrderStatusCancelled, "buyer1", model.StatusSold, 20, 0).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("item2", "Item 2", "http://example.com/2.jpg", 800, now, "order2", model.OrderStatusPaid, "seller1", "Seller", "").
+				AddRow("item1", "Item 1", "", 1000, now.Add(-time.Hour), "", "", "seller2", "Seller 2", "http://example.com/icon.jpg"))
+
+		items, err := dao.GetPurchasedItems(ctx, "buyer1", 20, 0)
+		if err != nil {
+			t.Fatalf("GetPurchasedItems() error = %v", err)
+		}
+		if len(items) != 2 {
+			t.Fatalf("GetPurchasedItems() got length = %v, want 2", len(items))
+		}
+		if items[0].Price != 800 || items[0].OrderId != "order2" || items[0].SellerName != "Seller" {
+			t.Errorf("items[0] = %+v", items[0])
+		}
+		if items[1].OrderId != "" || items[1].SellerId != "seller2" {
+			t.Errorf("items[1] = %+v", items[1])
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestItemDao_GetSoldItems(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewItemDao(db)
+	ctx := context.Background()
+	now := time.Now()
+	columns := []string{
+		"id", "name", "image_url", "price", "sold_at", "order_id", "order_status", "id", "name", "icon_url",
+	}
+
+	t.Run("成功: 取引の金額と購入者の概要を返す", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE i.user_id = ? AND i.status = ?")).
+			WithArgs(model.OrderStatusCancelled, "seller1", model.StatusSold, 20, 0).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("item2", "Item 2", "http://example.com/2.jpg", 800, now, "order2", model.OrderStatusShipped, "buyer1", "Buyer", "").
+				AddRow("item1", "Item 1", "", 1000, now.Add(-time.Hour), "", "", "buyer2", "Buyer 2", "http://example.com/icon.jpg"))
+
+		items, err := dao.GetSoldItems(ctx, "seller1", 20, 0)
+		if err != nil {
+			t.Fatalf("GetSoldItems() error = %v", err)
+		}
+		if len(items) != 2 {
+			t.Fatalf("GetSoldItems() got length = %v, want 2", len(items))
+		}
+		if items[0].Price != 800 || items[0].OrderStatus != model.OrderStatusShipped || items[0].BuyerName != "Buyer" {
+			t.Errorf("items[0] = %+v", items[0])
+		}
+		if items[1].OrderId != "" || items[1].BuyerId != "buyer2" || items[1].BuyerIconURL != "http://example.com/icon.jpg" {
+			t.Errorf("items[1] = %+v", items[1])
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: DBエラー", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE i.user_id = ? AND i.status = ?")).
+			WithArgs(model.OrderStatusCancelled, "seller1", model.StatusSold, 20, 0).
+			WillReturnError(errors.New("db error"))
+
+		if _, err := dao.GetSoldItems(ctx, "seller1", 20, 0); err == nil {
+			t.Errorf("GetSoldItems() error = nil, want error")
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestItemDao_WithdrawItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewItemDao(db)
+	ctx := context.Background()
+	checkQuery := regexp.QuoteMeta("SELECT user_id, status FROM items WHERE id = ? FOR UPDATE")
+	reservationQuery := regexp.QuoteMeta("SELECT id, buyer_id FROM offers WHERE item_id = ? AND status = ? AND expires_at > ?")
+
+	t.Run("成功: 取り下げていいねを削除し、回答待ちの価格交渉を拒否", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(checkQuery).
+			WithArgs("item1").
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "status"}).AddRow("seller1", model.StatusOnSale))
+		mock.ExpectQuery(reservationQuery).
+			WithArgs("item1", model.OfferStatusAccepted, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "buyer_id"}))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE items SET status = ?, updated_at = ? WHERE id = ?")).
+			WithArgs(model.StatusWithdrawn, sqlmock.AnyArg(), "item1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM likes WHERE item_id = ?")).
+			WithArgs("item1").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE offers SET status = ?, updated_at = ? WHERE item_id = ? AND status = ?")).
+			WithArgs(model.OfferStatusRejected, sqlmock.AnyArg(), "item1", model.OfferStatusPending).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		if err := dao.WithdrawItem(ctx, "item1", "seller1"); err != nil {
+			t.Errorf("WithdrawItem() error = %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: ロック後に取り置きが見つかる", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(checkQuery).
+			WithArgs("item1").
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "status"}).AddRow("seller1", model.StatusOnSale))
+		mock.ExpectQuery(reservationQuery).
+			WithArgs("item1", model.OfferStatusAccepted, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "buyer_id"}).AddRow("offer1", "buyer1"))
+		mock.ExpectRollback()
+
+		if err := dao.WithdrawItem(ctx, "item1", "seller1"); !errors.Is(err, model.ErrItemInTransaction) {
+			t.Errorf("WithdrawItem() error = %v, want %v", err, model.ErrItemInTransaction)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		owner   string
+		status  string
+		wantErr error
+	}{
+		{name: "失敗: 出品者以外", owner: "other", status: model.StatusOnSale, wantErr: model.ErrNotAuthorized},
+		{name: "失敗: 売却済み", owner: "seller1", status: model.StatusSold, wantErr: model.ErrItemInTransaction},
+		{name: "失敗: 取り下げ済み", owner: "seller1", status: model.StatusWithdrawn, wantErr: model.ErrItemNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			mock.ExpectQuery(checkQuery).
+				WithArgs("item1").
+				WillReturnRows(sqlmock.NewRows([]string{"user_id", "status"}).AddRow(tt.owner, tt.status))
+			mock.ExpectRollback()
+
+			if err := dao.WithdrawItem(ctx, "item1", "seller1"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("WithdrawItem() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestItemWhereClause(t *testing.T) {
+	tests := []struct {
+		name       string
+		keyword    string
+		filter     model.ItemFilter
+		wantClause string
+		wantArgs   []interface{}
+	}{
+		{
+			name:       "成功: 条件なしは取り下げ済みのみ除外",
+			wantClause: "WHERE i.status <> ?",
+			wantArgs:   []interface{}{model.StatusWithdrawn},
+		},
+		{
+			name:       "成功: キーワードはエスケープしてプレースホルダで渡す",
+			keyword:    "100%_off",
+			wantClause: "WHERE i.status <> ? AND (i.name LIKE ? OR i.name LIKE ? OR i.description LIKE ?)",
+			wantArgs:   []interface{}{model.StatusWithdrawn, `100\%\_off%`, `%100\%\_off%`, `%100\%\_off%`},
+		},
+		{
+			name:       "成功: 価格帯・販売状況・出品者を組み合わせる",
+			keyword:    "靴",
+			filter:     model.ItemFilter{MinPrice: 1000, MaxPrice: 5000, Status: model.StatusOnSale, SellerId: "seller1"},
+			wantClause: "WHERE i.status = ? AND (i.name LIKE ? OR i.name LIKE ? OR i.description LIKE ?) AND i.price >= ? AND i.price <= ? AND i.user_id = ?",
+			wantArgs:   []interface{}{model.StatusOnSale, "靴%", "%靴%", "%靴%", 1000, 5000, "seller1"},
+		},
+		{
+			name:       "成功: カテゴリと属性",
+			filter:     model.ItemFilter{CategoryIds: []int{1, 2}, ItemAttributes: model.ItemAttributes{Condition: model.ConditionNew}},
+			wantClause: "WHERE i.status <> ? AND i.category_id IN (?,?) AND i.item_condition = ?",
+			wantArgs:   []interface{}{model.StatusWithdrawn, 1, 2, model.ConditionNew},
+		},
+		{
+			name:       "成功: ブロック関係の出品者を除外",
+			filter:     model.ItemFilter{ViewerId: "viewer1", ExcludeSellerIds: []string{"seller1", "seller2"}},
+			wantClause: "WHERE i.status <> ? AND i.user_id NOT IN (?,?)",
+			wantArgs:   []interface{}{model.StatusWithdrawn, "seller1", "seller2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clause, args := itemWhereClause(tt.keyword, tt.filter)
+			if clause != tt.wantClause {
+				t.Errorf("clause = %q, want %q", clause, tt.wantClause)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestItemDao_GetSellerItemStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewItemDao(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta("FROM items") + ".*" + regexp.QuoteMeta("WHERE user_id = ? AND status <> ?")
+
+	t.Run("成功: 売れた商品がある", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(model.StatusSold, model.StatusSold, "seller1", model.StatusWithdrawn).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "sold", "avg"}).AddRow(5, 2, 3600.5))
+
+		stats, err := dao.GetSellerItemStats(ctx, "seller1")
+		if err != nil {
+			t.Fatalf("GetSellerItemStats() error = %v", err)
+		}
+		if stats.ListingCount != 5 || stats.SoldCount != 2 || stats.AvgTimeToSellSeconds == nil || *stats.AvgTimeToSellSeconds != 3600.5 {
+			t.Errorf("GetSellerItemStats() = %+v", stats)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("成功: 売れた商品がなければ平均はnil", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(model.StatusSold, model.StatusSold, "seller1", model.StatusWithdrawn).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "sold", "avg"}).AddRow(3, 0, nil))
+
+		stats, err := dao.GetSellerItemStats(ctx, "seller1")
+		if err != nil {
+			t.Fatalf("GetSellerItemStats() error = %v", err)
+		}
+		if stats.ListingCount != 3 || stats.SoldCount != 0 || stats.AvgTimeToSellSeconds != nil {
+			t.Errorf("GetSellerItemStats() = %+v", stats)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
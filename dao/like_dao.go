@@ -5,13 +5,19 @@ import (
 	"database/sql"
 	"db/model"
 	"fmt"
+	"strings"
 	"time"
 )
 
 type LikeDAO interface {
-	ToggleLike(ctx context.Context, userID, itemID string) error
+	AddLike(ctx context.Context, userID, itemID string) error
+	RemoveLike(ctx context.Context, userID, itemID string) error
+	ToggleLike(ctx context.Context, userID, itemID string) (bool, error)
+	CountLikes(ctx context.Context, itemID string) (int, error)
 	GetLikedItems(ctx context.Context, userID string) ([]model.ItemSimple, error)
 	GetLikedItemIDs(ctx context.Context, userID string) ([]string, error)
+	GetLikerIDs(ctx context.Context, itemID string) ([]string, error)
+	FilterLikedItemIDs(ctx context.Context, userID string, itemIDs []string) (map[string]bool, error)
 }
 
 type likeDao struct {
@@ -22,33 +28,52 @@ func NewLikeDao(db *sql.DB) LikeDAO {
 	return &likeDao{db: db}
 }
 
-// ToggleLike: 既にいいね済の場合: DELETE 文を実行して「いいね解除」/まだいいねしていない場合: INSERT 文を実行して「いいね登録」
-func (dao *likeDao) ToggleLike(ctx context.Context, userID, itemID string) error {
-	// Check if like already exists
-	var exists bool
-	checkQuery := `SELECT EXISTS(SELECT 1 FROM likes WHERE user_id = ? AND item_id = ?)`
-	err := dao.db.QueryRowContext(ctx, checkQuery, userID, itemID).Scan(&exists)
+// AddLike : いいねを登録する (登録済みなら何もしない。連打・再送しても結果は同じ)
+func (dao *likeDao) AddLike(ctx context.Context, userID, itemID string) error {
+	query := `INSERT IGNORE INTO likes (user_id, item_id, created_at) VALUES (?, ?, ?)`
+	if _, err := dao.db.ExecContext(ctx, query, userID, itemID, time.Now()); err != nil {
+		return fmt.Errorf("failed to insert like: %w", err)
+	}
+	return nil
+}
+
+// RemoveLike : いいねを解除する (未登録なら何もしない)
+func (dao *likeDao) RemoveLike(ctx context.Context, userID, itemID string) error {
+	query := `DELETE FROM likes WHERE user_id = ? AND item_id = ?`
+	if _, err := dao.db.ExecContext(ctx, query, userID, itemID); err != nil {
+		return fmt.Errorf("failed to delete like: %w", err)
+	}
+	return nil
+}
+
+// ToggleLike : いいね済みなら解除し、未登録なら登録する。切り替え後にいいねしているかを返す
+// 先にDELETEを試し、消せなかった場合だけ登録するので、確認と更新の間に状態が変わることはない
+func (dao *likeDao) ToggleLike(ctx context.Context, userID, itemID string) (bool, error) {
+	result, err := dao.db.ExecContext(ctx, `DELETE FROM likes WHERE user_id = ? AND item_id = ?`, userID, itemID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete like: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to check like existence: %w", err)
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected > 0 {
+		return false, nil
 	}
 
-	if exists {
-		// Delete the like
-		deleteQuery := `DELETE FROM likes WHERE user_id = ? AND item_id = ?`
-		_, err = dao.db.ExecContext(ctx, deleteQuery, userID, itemID)
-		if err != nil {
-			return fmt.Errorf("failed to delete like: %w", err)
-		}
-	} else {
-		// Insert the like
-		insertQuery := `INSERT INTO likes (user_id, item_id, created_at) VALUES (?, ?, ?)`
-		_, err = dao.db.ExecContext(ctx, insertQuery, userID, itemID, time.Now())
-		if err != nil {
-			return fmt.Errorf("failed to insert like: %w", err)
-		}
+	if err := dao.AddLike(ctx, userID, itemID); err != nil {
+		return false, err
 	}
+	return true, nil
+}
 
-	return nil
+// CountLikes : 商品のいいね数
+func (dao *likeDao) CountLikes(ctx context.Context, itemID string) (int, error) {
+	var count int
+	if err := dao.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM likes WHERE item_id = ?`, itemID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count likes: %w", err)
+	}
+	return count, nil
 }
 
 // GetLikedItems 「マイページの『いいねした商品』タブ」 で表示するためのデータ取得メソッド
@@ -60,6 +85,7 @@ func (dao *likeDao) GetLikedItems(ctx context.Context, userID string) ([]model.I
 			i.price, 
 			i.status,
 			COALESCE(MIN(img.image_url), '') AS image_url,
+			(SELECT COUNT(*) FROM likes lc WHERE lc.item_id = i.id) AS like_count,
 			MAX(l.created_at) AS liked_at
 		FROM likes l
 		INNER JOIN items i ON l.item_id = i.id
@@ -79,7 +105,7 @@ func (dao *likeDao) GetLikedItems(ctx context.Context, userID string) ([]model.I
 	for rows.Next() {
 		var item model.ItemSimple
 		var likedAt time.Time
-		if err := rows.Scan(&item.ItemId, &item.Name, &item.Price, &item.Status, &item.ImageURL, &likedAt); err != nil {
+		if err := rows.Scan(&item.ItemId, &item.Name, &item.Price, &item.Status, &item.ImageURL, &item.LikeCount, &likedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan liked item: %w", err)
 		}
 		items = append(items, item)
@@ -117,3 +143,66 @@ func (dao *likeDao) GetLikedItemIDs(ctx context.Context, userID string) ([]strin
 
 	return itemIDs, nil
 }
+
+// GetLikerIDs : 指定商品にいいねしたユーザーのID一覧を取得
+func (dao *likeDao) GetLikerIDs(ctx context.Context, itemID string) ([]string, error) {
+	query := `SELECT user_id FROM likes WHERE item_id = ?`
+
+	rows, err := dao.db.QueryContext(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query liker IDs: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := make([]string, 0)
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan user ID: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// FilterLikedItemIDs : 指定した商品のうち、ユーザーがいいね済みのものを1回のクエリでまとめて取得
+func (dao *likeDao) FilterLikedItemIDs(ctx context.Context, userID string, itemIDs []string) (map[string]bool, error) {
+	liked := make(map[string]bool)
+	if len(itemIDs) == 0 {
+		return liked, nil
+	}
+
+	placeholders := make([]string, len(itemIDs))
+	args := make([]interface{}, 0, len(itemIDs)+1)
+	args = append(args, userID)
+	for i, id := range itemIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`SELECT item_id FROM likes WHERE user_id = ? AND item_id IN (%s)`, strings.Join(placeholders, ","))
+	rows, err := dao.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query liked item IDs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID string
+		if err := rows.Scan(&itemID); err != nil {
+			return nil, fmt.Errorf("failed to scan item ID: %w", err)
+		}
+		liked[itemID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return liked, nil
+}
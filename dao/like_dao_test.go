@@ -0,0 +1,55 @@
+package dao
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestLikeDao_ToggleLike(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewLikeDao(db)
+	ctx := context.Background()
+	deleteQuery := regexp.QuoteMeta("DELETE FROM likes WHERE user_id = ? AND item_id = ?")
+	insertQuery := regexp.QuoteMeta("INSERT IGNORE INTO likes (user_id, item_id, created_at) VALUES (?, ?, ?)")
+
+	t.Run("成功: いいね済みなら解除", func(t *testing.T) {
+		mock.ExpectExec(deleteQuery).
+			WithArgs("user1", "item1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		liked, err := dao.ToggleLike(ctx, "user1", "item1")
+		if err != nil || liked {
+			t.Errorf("ToggleLike() = %v, %v, want false, nil", liked, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("成功: 未登録なら登録", func(t *testing.T) {
+		mock.ExpectExec(deleteQuery).
+			WithArgs("user1", "item1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(insertQuery).
+			WithArgs("user1", "item1", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		liked, err := dao.ToggleLike(ctx, "user1", "item1")
+		if err != nil || !liked {
+			t.Errorf("ToggleLike() = %v, %v, want true, nil", liked, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
@@ -17,6 +17,7 @@ type NotificationDAO interface {
 	GetUnreadCount(ctx context.Context, userId string) (int, error)
 	MarkAsRead(ctx context.Context, notificationId string, userId string) error
 	MarkAllAsRead(ctx context.Context, userId string) error
+	MarkChatRoomNotificationsAsRead(ctx context.Context, userId string, chatRoomId string, messageId string) error
 }
 
 type notificationDao struct {
@@ -39,8 +40,20 @@ func (dao *notificationDao) CreateNotification(ctx context.Context, notification
 		notification.CreatedAt = time.Now()
 	}
 
-	query := `INSERT INTO notifications (id, user_id, type, item_id, item_name, message, is_read, created_at)
-	          VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	// 追加情報がなければNULLにする
+	var payload interface{}
+	if len(notification.Payload) > 0 {
+		payload = string(notification.Payload)
+	}
+
+	// チャットルームに紐づかない通知はNULLにする
+	var chatRoomID interface{}
+	if notification.ChatRoomId != "" {
+		chatRoomID = notification.ChatRoomId
+	}
+
+	query := `INSERT INTO notifications (id, user_id, type, item_id, item_name, chat_room_id, message, payload, is_read, created_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err := dao.DB.ExecContext(ctx, query,
 		notification.Id,
@@ -48,7 +61,9 @@ func (dao *notificationDao) CreateNotification(ctx context.Context, notification
 		notification.Type,
 		notification.ItemId,
 		notification.ItemName,
+		chatRoomID,
 		notification.Message,
+		payload,
 		notification.IsRead,
 		notification.CreatedAt,
 	)
@@ -61,7 +76,7 @@ func (dao *notificationDao) CreateNotification(ctx context.Context, notification
 
 // GetUserNotifications : ユーザーの通知一覧を取得
 func (dao *notificationDao) GetUserNotifications(ctx context.Context, userId string, limit int) ([]model.Notification, error) {
-	query := `SELECT id, user_id, type, item_id, item_name, message, is_read, created_at
+	query := `SELECT id, user_id, type, item_id, item_name, COALESCE(chat_room_id, ''), message, payload, is_read, created_at
 	          FROM notifications
 	          WHERE user_id = ?
 	          ORDER BY created_at DESC
@@ -76,9 +91,13 @@ func (dao *notificationDao) GetUserNotifications(ctx context.Context, userId str
 	notifications := make([]model.Notification, 0)
 	for rows.Next() {
 		var n model.Notification
-		if err := rows.Scan(&n.Id, &n.UserId, &n.Type, &n.ItemId, &n.ItemName, &n.Message, &n.IsRead, &n.CreatedAt); err != nil {
+		var payload []byte
+		if err := rows.Scan(&n.Id, &n.UserId, &n.Type, &n.ItemId, &n.ItemName, &n.ChatRoomId, &n.Message, &payload, &n.IsRead, &n.CreatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan notification: %w", err)
 		}
+		if len(payload) > 0 {
+			n.Payload = payload
+		}
 		notifications = append(notifications, n)
 	}
 
@@ -134,3 +153,19 @@ func (dao *notificationDao) MarkAllAsRead(ctx context.Context, userId string) er
 
 	return nil
 }
+
+// MarkChatRoomNotificationsAsRead : 指定チャットルームのコメント通知のうち、messageIdのメッセージまでのものを既読にする
+// (同じ商品の他のルームの通知や、まだ読んでいない後続メッセージの通知は残す)
+func (dao *notificationDao) MarkChatRoomNotificationsAsRead(ctx context.Context, userId string, chatRoomId string, messageId string) error {
+	query := `
+		UPDATE notifications SET is_read = 1
+		WHERE user_id = ? AND chat_room_id = ? AND type = ? AND is_read = 0
+		  AND created_at <= (SELECT created_at FROM messages WHERE id = ?)`
+
+	_, err := dao.DB.ExecContext(ctx, query, userId, chatRoomId, model.NotificationTypeComment, messageId)
+	if err != nil {
+		return fmt.Errorf("failed to mark chat room notifications as read: %w", err)
+	}
+
+	return nil
+}
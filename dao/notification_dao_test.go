@@ -0,0 +1,34 @@
+package dao
+
+import (
+	"context"
+	"db/model"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestNotificationDao_MarkChatRoomNotificationsAsRead(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewNotificationDAO(db)
+
+	t.Run("成功: 既読にしたメッセージまでのコメント通知だけを既読にする", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta("AND type = ? AND is_read = 0")+".*"+regexp.QuoteMeta("created_at <= (SELECT created_at FROM messages WHERE id = ?)")).
+			WithArgs("seller1", "room1", model.NotificationTypeComment, "msg1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if err := dao.MarkChatRoomNotificationsAsRead(context.Background(), "seller1", "room1", "msg1"); err != nil {
+			t.Errorf("MarkChatRoomNotificationsAsRead() error = %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
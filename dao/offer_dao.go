@@ -0,0 +1,218 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"db/model"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+type OfferDAO interface {
+	CreateOffer(ctx context.Context, offer *model.Offer) error
+	GetOffer(ctx context.Context, offerID string) (*model.Offer, error)
+	GetPendingOffer(ctx context.Context, itemID, buyerID string) (*model.Offer, error)
+	GetActiveReservation(ctx context.Context, itemID string, now time.Time) (*model.Offer, error)
+	RespondOffer(ctx context.Context, offer *model.Offer, prevProposedBy string) error
+}
+
+type offerDao struct {
+	DB *sql.DB
+}
+
+// NewOfferDao : OfferDAOの生成
+func NewOfferDao(db *sql.DB) OfferDAO {
+	return &offerDao{DB: db}
+}
+
+const offerColumns = `id, item_id, chat_room_id, buyer_id, seller_id, price, proposed_by, status, expires_at, created_at, updated_at`
+
+// scanOffer : offerColumnsの順で1行を読み取る
+func scanOffer(row *sql.Row) (*model.Offer, error) {
+	var offer model.Offer
+	var expiresAt sql.NullTime
+	if err := row.Scan(
+		&offer.Id,
+		&offer.ItemId,
+		&offer.ChatRoomId,
+		&offer.BuyerId,
+		&offer.SellerId,
+		&offer.Price,
+		&offer.ProposedBy,
+		&offer.Status,
+		&expiresAt,
+		&offer.CreatedAt,
+		&offer.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		offer.ExpiresAt = &expiresAt.Time
+	}
+	return &offer, nil
+}
+
+// CreateOffer : 価格交渉を作成
+func (dao *offerDao) CreateOffer(ctx context.Context, offer *model.Offer) error {
+	query := `INSERT INTO offers (` + offerColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := dao.DB.ExecContext(ctx, query,
+		offer.Id,
+		offer.ItemId,
+		offer.ChatRoomId,
+		offer.BuyerId,
+		offer.SellerId,
+		offer.Price,
+		offer.ProposedBy,
+		offer.Status,
+		offer.ExpiresAt,
+		offer.CreatedAt,
+		offer.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("fail: insert offer: %w", err)
+	}
+
+	return nil
+}
+
+// GetOffer : 指定されたIDの価格交渉を取得
+func (dao *offerDao) GetOffer(ctx context.Context, offerID string) (*model.Offer, error) {
+	query := `SELECT ` + offerColumns + ` FROM offers WHERE id = ?`
+
+	offer, err := scanOffer(dao.DB.QueryRowContext(ctx, query, offerID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrOfferNotFound
+		}
+		return nil, fmt.Errorf("fail: fetch offer: %w", err)
+	}
+
+	return offer, nil
+}
+
+// GetPendingOffer : 購入希望者の回答待ちの価格交渉を取得 (なければnil)
+func (dao *offerDao) GetPendingOffer(ctx context.Context, itemID, buyerID string) (*model.Offer, error) {
+	query := `SELECT ` + offerColumns + ` FROM offers WHERE item_id = ? AND buyer_id = ? AND status = ? LIMIT 1`
+
+	offer, err := scanOffer(dao.DB.QueryRowContext(ctx, query, itemID, buyerID, model.OfferStatusPending))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fail: fetch pending offer: %w", err)
+	}
+
+	return offer, nil
+}
+
+// GetActiveReservation : 取り置き期間内の合意済み価格交渉を取得 (なければnil)
+func (dao *offerDao) GetActiveReservation(ctx context.Context, itemID string, now time.Time) (*model.Offer, error) {
+	query := `SELECT ` + offerColumns + ` FROM offers WHERE item_id = ? AND status = ? AND expires_at > ? ORDER BY expires_at DESC LIMIT 1`
+
+	offer, err := scanOffer(dao.DB.QueryRowContext(ctx, query, itemID, model.OfferStatusAccepted, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fail: fetch reservation: %w", err)
+	}
+
+	return offer, nil
+}
+
+// lockItemForReservation : 取り置きの作成・購入を直列にするため商品の行をロックし、販売中か確かめる
+func lockItemForReservation(ctx context.Context, tx *sql.Tx, itemID string) error {
+	var status string
+	err := tx.QueryRowContext(ctx, `SELECT status FROM items WHERE id = ? FOR UPDATE`, itemID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrItemNotFound
+		}
+		return fmt.Errorf("fail: lock item: %w", err)
+	}
+	if status != model.StatusOnSale {
+		return fmt.Errorf("%w: item is not on sale", model.ErrInvalidOfferTransition)
+	}
+	return nil
+}
+
+// activeReservation : 取り置き期間内の合意済み価格交渉のIDと購入者を取得 (なければ空)
+// lockItemForReservationで商品の行をロックしたトランザクション内で呼ぶ
+func activeReservation(ctx context.Context, tx *sql.Tx, itemID string, now time.Time) (string, string, error) {
+	query := `SELECT id, buyer_id FROM offers WHERE item_id = ? AND status = ? AND expires_at > ? ORDER BY expires_at DESC LIMIT 1`
+
+	var offerID, buyerID string
+	err := tx.QueryRowContext(ctx, query, itemID, model.OfferStatusAccepted, now).Scan(&offerID, &buyerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", nil
+		}
+		return "", "", fmt.Errorf("fail: fetch reservation: %w", err)
+	}
+	return offerID, buyerID, nil
+}
+
+// RespondOffer : 回答待ちの価格交渉に回答結果(状態・金額・提示者・取り置き期限)を反映
+// prevProposedByのまま回答待ちの場合のみ更新し、同時操作による二重回答を防ぐ
+// 承諾は商品の行をロックしてから販売中か・他の購入希望者の取り置きがないかを確かめ、取り置きが2件できないようにする
+func (dao *offerDao) RespondOffer(ctx context.Context, offer *model.Offer, prevProposedBy string) error {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("fail: txBegin(): %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("fail: tx.Rollback, %v\n", err)
+		}
+	}()
+
+	if offer.Status == model.OfferStatusAccepted {
+		if err := lockItemForReservation(ctx, tx, offer.ItemId); err != nil {
+			return err
+		}
+		_, buyerID, err := activeReservation(ctx, tx, offer.ItemId, offer.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		if buyerID != "" && buyerID != offer.BuyerId {
+			return model.ErrItemReserved
+		}
+	}
+
+	query := `
+		UPDATE offers
+		SET status = ?, price = ?, proposed_by = ?, expires_at = ?, updated_at = ?
+		WHERE id = ? AND status = ? AND proposed_by = ?`
+
+	result, err := tx.ExecContext(ctx, query,
+		offer.Status,
+		offer.Price,
+		offer.ProposedBy,
+		offer.ExpiresAt,
+		offer.UpdatedAt,
+		offer.Id,
+		model.OfferStatusPending,
+		prevProposedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("fail: update offer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("fail: get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return model.ErrInvalidOfferTransition
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("fail: tx.Commit(): %w", err)
+	}
+
+	return nil
+}
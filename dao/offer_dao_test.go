@@ -0,0 +1,104 @@
+package dao
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestOfferDao_RespondOffer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewOfferDao(db)
+	ctx := context.Background()
+	lockQuery := regexp.QuoteMeta("SELECT status FROM items WHERE id = ? FOR UPDATE")
+	reservationQuery := regexp.QuoteMeta("SELECT id, buyer_id FROM offers WHERE item_id = ? AND status = ? AND expires_at > ?")
+	updateQuery := regexp.QuoteMeta("UPDATE offers")
+
+	now := time.Now()
+	expiresAt := now.Add(model.OfferReservationWindow)
+	accepted := func() *model.Offer {
+		return &model.Offer{Id: "offer1", ItemId: "item1", BuyerId: "buyer1", SellerId: "seller1", Price: 800, ProposedBy: "buyer1", Status: model.OfferStatusAccepted, ExpiresAt: &expiresAt, UpdatedAt: now}
+	}
+
+	t.Run("成功: 商品をロックして承諾", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("item1").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.StatusOnSale))
+		mock.ExpectQuery(reservationQuery).
+			WithArgs("item1", model.OfferStatusAccepted, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "buyer_id"}))
+		mock.ExpectExec(updateQuery).
+			WithArgs(model.OfferStatusAccepted, 800, "buyer1", &expiresAt, now, "offer1", model.OfferStatusPending, "buyer1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := dao.RespondOffer(ctx, accepted(), "buyer1"); err != nil {
+			t.Errorf("RespondOffer() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: ロック後に他の購入希望者の取り置きが見つかる", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("item1").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.StatusOnSale))
+		mock.ExpectQuery(reservationQuery).
+			WithArgs("item1", model.OfferStatusAccepted, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "buyer_id"}).AddRow("offer2", "buyer2"))
+		mock.ExpectRollback()
+
+		err := dao.RespondOffer(ctx, accepted(), "buyer1")
+		if !errors.Is(err, model.ErrItemReserved) {
+			t.Errorf("RespondOffer() error = %v, want %v", err, model.ErrItemReserved)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: ロック時点で売り切れ", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("item1").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.StatusSold))
+		mock.ExpectRollback()
+
+		err := dao.RespondOffer(ctx, accepted(), "buyer1")
+		if !errors.Is(err, model.ErrInvalidOfferTransition) {
+			t.Errorf("RespondOffer() error = %v, want %v", err, model.ErrInvalidOfferTransition)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("成功: 拒否は商品をロックしない", func(t *testing.T) {
+		offer := accepted()
+		offer.Status = model.OfferStatusRejected
+		offer.ExpiresAt = nil
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).
+			WithArgs(model.OfferStatusRejected, 800, "buyer1", nil, now, "offer1", model.OfferStatusPending, "buyer1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := dao.RespondOffer(ctx, offer, "buyer1"); err != nil {
+			t.Errorf("RespondOffer() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
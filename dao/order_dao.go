@@ -0,0 +1,142 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"db/model"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+type OrderDAO interface {
+	GetOrder(ctx context.Context, orderID string) (*model.Order, error)
+	GetCompletedOrderByItem(ctx context.Context, itemID string) (*model.Order, error)
+	UpdateOrderStatus(ctx context.Context, orderID string, fromStatus string, toStatus string) error
+	CancelOrder(ctx context.Context, orderID string, fromStatus string) error
+}
+
+type orderDao struct {
+	DB *sql.DB
+}
+
+// NewOrderDao : OrderDAOの生成
+func NewOrderDao(db *sql.DB) OrderDAO {
+	return &orderDao{DB: db}
+}
+
+// GetOrder : 指定されたIDの取引を取得
+func (dao *orderDao) GetOrder(ctx context.Context, orderID string) (*model.Order, error) {
+	return dao.getOrder(ctx, "o.id = ?", orderID)
+}
+
+// GetCompletedOrderByItem : 指定された商品の完了した取引を取得 (なければErrOrderNotFound)
+// キャンセル後に再出品された商品は複数の取引を持つが、完了するのは最後の1件だけ
+func (dao *orderDao) GetCompletedOrderByItem(ctx context.Context, itemID string) (*model.Order, error) {
+	return dao.getOrder(ctx, "o.item_id = ? AND o.status = ?", itemID, model.OrderStatusCompleted)
+}
+
+// getOrder : 条件に一致する取引を1件取得
+func (dao *orderDao) getOrder(ctx context.Context, where string, args ...interface{}) (*model.Order, error) {
+	query := `
+		SELECT
+			o.id,
+			o.item_id,
+			i.name,
+			o.buyer_id,
+			o.seller_id,
+			o.price,
+			o.status,
+			o.created_at,
+			o.updated_at
+		FROM orders o
+		INNER JOIN items i ON o.item_id = i.id
+		WHERE ` + where
+
+	var order model.Order
+	err := dao.DB.QueryRowContext(ctx, query, args...).Scan(
+		&order.Id,
+		&order.ItemId,
+		&order.ItemName,
+		&order.BuyerId,
+		&order.SellerId,
+		&order.Price,
+		&order.Status,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("fail: fetch order: %w", err)
+	}
+
+	return &order, nil
+}
+
+// UpdateOrderStatus : 取引の状態を更新 (fromStatusのままの場合のみ更新し、同時操作による二重遷移を防ぐ)
+func (dao *orderDao) UpdateOrderStatus(ctx context.Context, orderID string, fromStatus string, toStatus string) error {
+	query := `UPDATE orders SET status = ?, updated_at = ? WHERE id = ? AND status = ?`
+
+	result, err := dao.DB.ExecContext(ctx, query, toStatus, time.Now(), orderID, fromStatus)
+	if err != nil {
+		return fmt.Errorf("fail: update order status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("fail: get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return model.ErrInvalidOrderTransition
+	}
+
+	return nil
+}
+
+// CancelOrder : 取引をキャンセルし、商品を販売中に戻す
+func (dao *orderDao) CancelOrder(ctx context.Context, orderID string, fromStatus string) error {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("fail: txBegin(): %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("fail: tx.Rollback, %v\n", err)
+		}
+	}()
+
+	now := time.Now()
+	orderQuery := `UPDATE orders SET status = ?, updated_at = ? WHERE id = ? AND status = ?`
+	result, err := tx.ExecContext(ctx, orderQuery, model.OrderStatusCancelled, now, orderID, fromStatus)
+	if err != nil {
+		return fmt.Errorf("fail: cancel order: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("fail: get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return model.ErrInvalidOrderTransition
+	}
+
+	itemQuery := `
+		UPDATE items i
+		INNER JOIN orders o ON o.item_id = i.id
+		SET i.status = ?, i.buyer_id = NULL, i.purchased_at = NULL, i.updated_at = ?
+		WHERE o.id = ? AND i.status = ?`
+	if _, err := tx.ExecContext(ctx, itemQuery, model.StatusOnSale, now, orderID, model.StatusSold); err != nil {
+		return fmt.Errorf("fail: reopen item: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("fail: tx.Commit(): %w", err)
+	}
+
+	return nil
+}
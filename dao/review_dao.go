@@ -0,0 +1,121 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"db/model"
+	"fmt"
+	"log"
+)
+
+type ReviewDAO interface {
+	CreateReview(ctx context.Context, review *model.Review) error
+	GetUserReviews(ctx context.Context, userID string, limit int, offset int) ([]model.Review, error)
+	GetRatingSummary(ctx context.Context, userID string) (*model.RatingSummary, error)
+}
+
+type reviewDao struct {
+	DB *sql.DB
+}
+
+// NewReviewDao : ReviewDAOの生成
+func NewReviewDao(db *sql.DB) ReviewDAO {
+	return &reviewDao{DB: db}
+}
+
+// CreateReview : 評価を保存 (同じ取引に同じ評価者が2回評価した場合はErrAlreadyReviewed)
+func (dao *reviewDao) CreateReview(ctx context.Context, review *model.Review) error {
+	query := `
+		INSERT INTO reviews (id, order_id, item_id, reviewer_id, reviewee_id, reviewer_role, rating, comment, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := dao.DB.ExecContext(ctx, query,
+		review.Id,
+		review.OrderId,
+		review.ItemId,
+		review.ReviewerId,
+		review.RevieweeId,
+		review.ReviewerRole,
+		review.Rating,
+		review.Comment,
+		review.CreatedAt,
+	)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return model.ErrAlreadyReviewed
+		}
+		return fmt.Errorf("fail: insert review: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserReviews : 指定ユーザーが受けた評価を新しい順に取得
+func (dao *reviewDao) GetUserReviews(ctx context.Context, userID string, limit int, offset int) ([]model.Review, error) {
+	query := `
+		SELECT
+			r.id,
+			r.order_id,
+			r.item_id,
+			r.reviewer_id,
+			r.reviewee_id,
+			r.reviewer_role,
+			r.rating,
+			r.comment,
+			u.name,
+			COALESCE(u.icon_url, ''),
+			r.created_at
+		FROM reviews r
+		INNER JOIN users u ON r.reviewer_id = u.id
+		WHERE r.reviewee_id = ?
+		ORDER BY r.created_at DESC, r.id DESC
+		LIMIT ? OFFSET ?`
+
+	rows, err := dao.DB.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("fail: query reviews: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("fail: rows.Close, %v\n", err)
+		}
+	}()
+
+	reviews := make([]model.Review, 0)
+	for rows.Next() {
+		var r model.Review
+		if err := rows.Scan(
+			&r.Id,
+			&r.OrderId,
+			&r.ItemId,
+			&r.ReviewerId,
+			&r.RevieweeId,
+			&r.ReviewerRole,
+			&r.Rating,
+			&r.Comment,
+			&r.ReviewerName,
+			&r.ReviewerIconURL,
+			&r.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("fail: scan review: %w", err)
+		}
+		reviews = append(reviews, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fail: rows.Err: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// GetRatingSummary : 指定ユーザーが受けた評価の平均と件数を取得
+func (dao *reviewDao) GetRatingSummary(ctx context.Context, userID string) (*model.RatingSummary, error) {
+	query := `SELECT COALESCE(AVG(rating), 0), COUNT(*) FROM reviews WHERE reviewee_id = ?`
+
+	var summary model.RatingSummary
+	if err := dao.DB.QueryRowContext(ctx, query, userID).Scan(&summary.Average, &summary.Count); err != nil {
+		return nil, fmt.Errorf("fail: fetch rating summary: %w", err)
+	}
+
+	return &summary, nil
+}
@@ -0,0 +1,171 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"db/model"
+	"errors"
+	"fmt"
+	"log"
+)
+
+type SavedSearchDAO interface {
+	CreateSavedSearch(ctx context.Context, savedSearch *model.SavedSearch, limit int) error
+	GetSavedSearch(ctx context.Context, savedSearchID string) (*model.SavedSearch, error)
+	GetUserSavedSearches(ctx context.Context, userID string) ([]model.SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, savedSearch *model.SavedSearch) error
+	DeleteSavedSearch(ctx context.Context, savedSearchID string) error
+	GetSavedSearchesForPrice(ctx context.Context, price int, excludeUserID string) ([]model.SavedSearch, error)
+}
+
+type savedSearchDao struct {
+	DB *sql.DB
+}
+
+// NewSavedSearchDao : SavedSearchDAOの生成
+func NewSavedSearchDao(db *sql.DB) SavedSearchDAO {
+	return &savedSearchDao{DB: db}
+}
+
+const savedSearchColumns = `id, user_id, keyword, min_price, max_price, created_at, updated_at`
+
+// scanSavedSearches : savedSearchColumnsの順で全行を読み取る
+func scanSavedSearches(rows *sql.Rows) ([]model.SavedSearch, error) {
+	defer rows.Close()
+
+	savedSearches := make([]model.SavedSearch, 0)
+	for rows.Next() {
+		var s model.SavedSearch
+		if err := rows.Scan(&s.Id, &s.UserId, &s.Keyword, &s.MinPrice, &s.MaxPrice, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		savedSearches = append(savedSearches, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return savedSearches, nil
+}
+
+// CreateSavedSearch : 検索条件を保存 (ユーザーの保存件数がlimitに達していればErrSavedSearchLimitExceeded)
+// 同時に保存されても上限を超えないよう、ユーザーの行をロックしてから件数を数える
+func (dao *savedSearchDao) CreateSavedSearch(ctx context.Context, s *model.SavedSearch, limit int) error {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("fail:txBegin(): %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("fail:tx.Rollback,%v\n", err)
+		}
+	}()
+
+	var userID string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM users WHERE id = ? AND deleted_at IS NULL FOR UPDATE`, s.UserId).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrUserNotFound
+		}
+		return fmt.Errorf("fail: lock user: %w", err)
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM saved_searches WHERE user_id = ?`, s.UserId).Scan(&count); err != nil {
+		return fmt.Errorf("fail: count saved searches: %w", err)
+	}
+	if count >= limit {
+		return model.ErrSavedSearchLimitExceeded
+	}
+
+	query := `INSERT INTO saved_searches (` + savedSearchColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	if _, err := tx.ExecContext(ctx, query, s.Id, s.UserId, s.Keyword, s.MinPrice, s.MaxPrice, s.CreatedAt, s.UpdatedAt); err != nil {
+		return fmt.Errorf("fail: insert saved search: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("fail:tx.Commit(): %w", err)
+	}
+	return nil
+}
+
+// GetSavedSearch : 指定されたIDの保存した検索条件を取得
+func (dao *savedSearchDao) GetSavedSearch(ctx context.Context, savedSearchID string) (*model.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE id = ?`
+
+	var s model.SavedSearch
+	err := dao.DB.QueryRowContext(ctx, query, savedSearchID).Scan(&s.Id, &s.UserId, &s.Keyword, &s.MinPrice, &s.MaxPrice, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrSavedSearchNotFound
+		}
+		return nil, fmt.Errorf("fail: fetch saved search: %w", err)
+	}
+
+	return &s, nil
+}
+
+// GetUserSavedSearches : ユーザーの保存した検索条件を新しい順に取得
+func (dao *savedSearchDao) GetUserSavedSearches(ctx context.Context, userID string) ([]model.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE user_id = ? ORDER BY created_at DESC`
+
+	rows, err := dao.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("fail:dao.DB.Query:%w", err)
+	}
+	return scanSavedSearches(rows)
+}
+
+// UpdateSavedSearch : 検索条件を更新
+func (dao *savedSearchDao) UpdateSavedSearch(ctx context.Context, s *model.SavedSearch) error {
+	query := `UPDATE saved_searches SET keyword = ?, min_price = ?, max_price = ?, updated_at = ? WHERE id = ?`
+
+	result, err := dao.DB.ExecContext(ctx, query, s.Keyword, s.MinPrice, s.MaxPrice, s.UpdatedAt, s.Id)
+	if err != nil {
+		return fmt.Errorf("fail: update saved search: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return model.ErrSavedSearchNotFound
+	}
+
+	return nil
+}
+
+// DeleteSavedSearch : 検索条件を削除
+func (dao *savedSearchDao) DeleteSavedSearch(ctx context.Context, savedSearchID string) error {
+	result, err := dao.DB.ExecContext(ctx, `DELETE FROM saved_searches WHERE id = ?`, savedSearchID)
+	if err != nil {
+		return fmt.Errorf("fail: delete saved search: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return model.ErrSavedSearchNotFound
+	}
+
+	return nil
+}
+
+// GetSavedSearchesForPrice : 価格帯にpriceが収まる他のユーザーの検索条件を取得 (キーワードの判定は呼び出し側で行う)
+// excludeUserID(出品者)とブロック関係にあるユーザーの検索条件は含めない
+func (dao *savedSearchDao) GetSavedSearchesForPrice(ctx context.Context, price int, excludeUserID string) ([]model.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches s
+		WHERE (s.min_price = 0 OR s.min_price <= ?) AND (s.max_price = 0 OR s.max_price >= ?) AND s.user_id <> ?
+		AND NOT EXISTS (
+			SELECT 1 FROM blocks b
+			WHERE (b.blocker_id = s.user_id AND b.blocked_id = ?) OR (b.blocker_id = ? AND b.blocked_id = s.user_id)
+		)`
+
+	rows, err := dao.DB.QueryContext(ctx, query, price, price, excludeUserID, excludeUserID, excludeUserID)
+	if err != nil {
+		return nil, fmt.Errorf("fail:dao.DB.Query:%w", err)
+	}
+	return scanSavedSearches(rows)
+}
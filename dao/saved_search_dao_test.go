@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSavedSearchDao_CreateSavedSearch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewSavedSearchDao(db)
+	ctx := context.Background()
+	lockQuery := regexp.QuoteMeta("SELECT id FROM users WHERE id = ? AND deleted_at IS NULL FOR UPDATE")
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM saved_searches WHERE user_id = ?")
+	insertQuery := regexp.QuoteMeta("INSERT INTO saved_searches")
+
+	now := time.Now()
+	savedSearch := &model.SavedSearch{Id: "search1", UserId: "user1", Keyword: "ジャケット", MaxPrice: 5000, CreatedAt: now, UpdatedAt: now}
+
+	t.Run("成功: ユーザーをロックして件数を確かめてから保存", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("user1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user1"))
+		mock.ExpectQuery(countQuery).WithArgs("user1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectExec(insertQuery).
+			WithArgs("search1", "user1", "ジャケット", 0, 5000, now, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := dao.CreateSavedSearch(ctx, savedSearch, 3); err != nil {
+			t.Errorf("CreateSavedSearch() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: 上限に達していれば保存しない", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("user1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user1"))
+		mock.ExpectQuery(countQuery).WithArgs("user1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectRollback()
+
+		err := dao.CreateSavedSearch(ctx, savedSearch, 3)
+		if !errors.Is(err, model.ErrSavedSearchLimitExceeded) {
+			t.Errorf("CreateSavedSearch() error = %v, want %v", err, model.ErrSavedSearchLimitExceeded)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: 退会済みのユーザー", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("user1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		err := dao.CreateSavedSearch(ctx, savedSearch, 3)
+		if !errors.Is(err, model.ErrUserNotFound) {
+			t.Errorf("CreateSavedSearch() error = %v, want %v", err, model.ErrUserNotFound)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
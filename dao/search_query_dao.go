@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"db/model"
+	"fmt"
+	"time"
+)
+
+type SearchQueryDAO interface {
+	LogQuery(ctx context.Context, query string, normalizedQuery string) error
+	GetPopularQueries(ctx context.Context, since time.Time, minCount int, limit int) ([]model.PopularQuery, error)
+}
+
+type searchQueryDao struct {
+	DB *sql.DB
+}
+
+// NewSearchQueryDao : SearchQueryDAOの生成
+func NewSearchQueryDao(db *sql.DB) SearchQueryDAO {
+	return &searchQueryDao{DB: db}
+}
+
+// LogQuery : 検索語を記録する (正規化した検索語で集計する)
+func (dao *searchQueryDao) LogQuery(ctx context.Context, query string, normalizedQuery string) error {
+	insertQuery := `INSERT INTO search_queries (query, normalized_query, created_at) VALUES (?, ?, ?)`
+	if _, err := dao.DB.ExecContext(ctx, insertQuery, query, normalizedQuery, time.Now()); err != nil {
+		return fmt.Errorf("fail:insert search query: %w", err)
+	}
+	return nil
+}
+
+// GetPopularQueries : since以降にminCount回以上検索された検索語を回数の多い順に取得
+// 回数は正規化した検索語ごとに合算し、表示には最も多く検索された表記を使う
+func (dao *searchQueryDao) GetPopularQueries(ctx context.Context, since time.Time, minCount int, limit int) ([]model.PopularQuery, error) {
+	query := `
+		SELECT query, cnt
+		FROM (
+			SELECT
+				query,
+				SUM(spelling_cnt) OVER (PARTITION BY normalized_query) AS cnt,
+				ROW_NUMBER() OVER (PARTITION BY normalized_query ORDER BY spelling_cnt DESC, query) AS rn
+			FROM (
+				SELECT normalized_query, query, COUNT(*) AS spelling_cnt
+				FROM search_queries
+				WHERE created_at >= ?
+				GROUP BY normalized_query, query
+			) spellings
+		) ranked
+		WHERE rn = 1 AND cnt >= ?
+		ORDER BY cnt DESC
+		LIMIT ?`
+
+	rows, err := dao.DB.QueryContext(ctx, query, since, minCount, limit)
+	if err != nil {
+		return nil, fmt.Errorf("fail:dao.DB.Query:%w", err)
+	}
+	defer rows.Close()
+
+	queries := make([]model.PopularQuery, 0)
+	for rows.Next() {
+		var q model.PopularQuery
+		if err := rows.Scan(&q.Query, &q.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		queries = append(queries, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return queries, nil
+}
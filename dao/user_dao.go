@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 )
 
@@ -14,9 +15,15 @@ type UserDAO interface {
 	List(ctx context.Context) ([]model.User, error)
 	DBInsert(ctx context.Context, user *model.User) error
 	GetUser(ctx context.Context, id string) (*model.User, error)
-	UpdateUser(ctx context.Context, user *model.User) error
+	GetUserByHandle(ctx context.Context, handle string) (*model.User, error)
+	GetMentionedUserIDs(ctx context.Context, authorID string, handles []string) ([]string, error)
+	UpdateUser(ctx context.Context, user *model.User, clearHandle bool) error
+	DeleteUser(ctx context.Context, id string) ([]string, error)
 }
 
+// userHandleKey : users.handle のUNIQUE KEY名
+const userHandleKey = "user_handle"
+
 type userDao struct {
 	DB *sql.DB
 }
@@ -29,7 +36,7 @@ func NewUserDao(db *sql.DB) UserDAO {
 // List : ユーザー一覧を取得する
 func (dao *userDao) List(ctx context.Context) ([]model.User, error) {
 
-	query := "SELECT id, name, icon_url FROM users"
+	query := "SELECT id, name, COALESCE(handle, ''), icon_url FROM users WHERE deleted_at IS NULL"
 	rows, err := dao.DB.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("fail:dao.DB.Query:%w", err)
@@ -44,7 +51,7 @@ func (dao *userDao) List(ctx context.Context) ([]model.User, error) {
 	users := make([]model.User, 0)
 	for rows.Next() {
 		var u model.User
-		if err := rows.Scan(&u.Id, &u.Name, &u.IconURL); err != nil {
+		if err := rows.Scan(&u.Id, &u.Name, &u.Handle, &u.IconURL); err != nil {
 			return nil, fmt.Errorf("fail:ows.Scan:%w", err)
 		}
 		users = append(users, u)
@@ -56,31 +63,53 @@ func (dao *userDao) List(ctx context.Context) ([]model.User, error) {
 	return users, nil
 }
 
-// DBInsert 指定されたuerをinsertする
+// nullableHandle : 未設定(空)のハンドルはNULLとして保存する (UNIQUE KEYはNULLの重複を許す)
+func nullableHandle(handle string) interface{} {
+	if handle == "" {
+		return nil
+	}
+	return handle
+}
+
+// DBInsert 指定されたuerをinsertする (ハンドルが使用済みならErrHandleTaken)
 func (dao *userDao) DBInsert(ctx context.Context, user *model.User) error {
 
 	now := time.Now()
 	query := `INSERT INTO users 
-              (id, name, age, email, bio, icon_url, created_at, updated_at) 
-              VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+              (id, name, handle, age, email, bio, icon_url, created_at, updated_at) 
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	_, err := dao.DB.ExecContext(ctx, query, user.Id, user.Name, user.Age, user.Email, user.Bio, user.IconURL, now, now)
+	_, err := dao.DB.ExecContext(ctx, query, user.Id, user.Name, nullableHandle(user.Handle), user.Age, user.Email, user.Bio, user.IconURL, now, now)
 	if err != nil {
+		if isDuplicateKey(err, userHandleKey) {
+			return model.ErrHandleTaken
+		}
 		return fmt.Errorf("fail:db.Exec: %w", err)
 	}
 
 	return nil
 }
 
-// GetUser : 指定されたIDのユーザーを取得
+// GetUser : 指定されたIDのユーザーを取得 (退会済みならErrUserNotFound)
 func (dao *userDao) GetUser(ctx context.Context, id string) (*model.User, error) {
-	query := `SELECT id, name, age, email, bio, icon_url, created_at, updated_at 
-              FROM users WHERE id = ?`
+	return dao.getUser(ctx, "id = ? AND deleted_at IS NULL", id)
+}
+
+// GetUserByHandle : 指定されたハンドル(正規化済み)のユーザーを取得
+func (dao *userDao) GetUserByHandle(ctx context.Context, handle string) (*model.User, error) {
+	return dao.getUser(ctx, "handle = ? AND deleted_at IS NULL", handle)
+}
+
+// getUser : 条件に一致するユーザーを1件取得
+func (dao *userDao) getUser(ctx context.Context, where string, arg interface{}) (*model.User, error) {
+	query := `SELECT id, name, COALESCE(handle, ''), age, email, bio, icon_url, created_at, updated_at 
+              FROM users WHERE ` + where
 
 	var user model.User
-	err := dao.DB.QueryRowContext(ctx, query, id).Scan(
+	err := dao.DB.QueryRowContext(ctx, query, arg).Scan(
 		&user.Id,
 		&user.Name,
+		&user.Handle,
 		&user.Age,
 		&user.Email,
 		&user.Bio,
@@ -91,7 +120,7 @@ func (dao *userDao) GetUser(ctx context.Context, id string) (*model.User, error)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("user not found: %w", err)
+			return nil, model.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("fail:dao.DB.QueryRow:%w", err)
 	}
@@ -99,15 +128,19 @@ func (dao *userDao) GetUser(ctx context.Context, id string) (*model.User, error)
 	return &user, nil
 }
 
-// UpdateUser : ユーザー情報を更新
-func (dao *userDao) UpdateUser(ctx context.Context, user *model.User) error {
+// UpdateUser : ユーザー情報を更新 (ハンドルが空なら変更しない。clearHandleなら削除する。使用済みならErrHandleTaken)
+// 退会済みのユーザーは匿名化した内容を戻せないようErrUserNotFoundとする
+func (dao *userDao) UpdateUser(ctx context.Context, user *model.User, clearHandle bool) error {
 	now := time.Now()
 	query := `UPDATE users 
-              SET name = ?, age = ?, bio = ?, icon_url = ?, updated_at = ? 
-              WHERE id = ?`
+              SET name = ?, handle = CASE WHEN ? THEN NULL ELSE COALESCE(?, handle) END, age = ?, bio = ?, icon_url = ?, updated_at = ? 
+              WHERE id = ? AND deleted_at IS NULL`
 
-	result, err := dao.DB.ExecContext(ctx, query, user.Name, user.Age, user.Bio, user.IconURL, now, user.Id)
+	result, err := dao.DB.ExecContext(ctx, query, user.Name, clearHandle, nullableHandle(user.Handle), user.Age, user.Bio, user.IconURL, now, user.Id)
 	if err != nil {
+		if isDuplicateKey(err, userHandleKey) {
+			return model.ErrHandleTaken
+		}
 		return fmt.Errorf("fail:db.Exec: %w", err)
 	}
 
@@ -117,8 +150,165 @@ func (dao *userDao) UpdateUser(ctx context.Context, user *model.User) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("user not found")
+		return model.ErrUserNotFound
 	}
 
 	return nil
 }
+
+// GetMentionedUserIDs : メンションされたハンドル(正規化済み)のユーザーIDを取得
+// 書いた本人・退会済みのユーザー・書いた本人とブロック関係にあるユーザーは除く
+func (dao *userDao) GetMentionedUserIDs(ctx context.Context, authorID string, handles []string) ([]string, error) {
+	if len(handles) == 0 {
+		return []string{}, nil
+	}
+
+	placeholders := make([]string, len(handles))
+	args := make([]interface{}, 0, len(handles)+3)
+	for i, handle := range handles {
+		placeholders[i] = "?"
+		args = append(args, handle)
+	}
+	args = append(args, authorID, authorID, authorID)
+
+	query := `
+		SELECT u.id FROM users u
+		WHERE u.handle IN (` + strings.Join(placeholders, ",") + `)
+		  AND u.deleted_at IS NULL
+		  AND u.id <> ?
+		  AND NOT EXISTS (
+			SELECT 1 FROM blocks b
+			WHERE (b.blocker_id = ? AND b.blocked_id = u.id) OR (b.blocker_id = u.id AND b.blocked_id = ?)
+		  )`
+
+	rows, err := dao.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fail: select mentioned users: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := make([]string, 0, len(handles))
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return userIDs, nil
+}
+
+// DeleteUser : 退会処理。ユーザーの行は取引・チャット・評価から参照されるため削除せず匿名化する
+// 販売中の商品は取り下げ、進行中の価格交渉は拒否にし、いいね・フォロー・ブロック・保存した検索条件・通知は削除する
+// 取引が完了していない場合はErrUserHasOpenOrderを返す。取り下げた商品のIDを返す
+func (dao *userDao) DeleteUser(ctx context.Context, id string) ([]string, error) {
+	tx, err := dao.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fail:txBegin(): %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("fail:tx.Rollback,%v\n", err)
+		}
+	}()
+
+	// 退会済みのユーザーは存在しないものとして扱う
+	var userID string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM users WHERE id = ? AND deleted_at IS NULL FOR UPDATE`, id).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("fail: lock user: %w", err)
+	}
+
+	// 支払い・発送・受け取りの途中の取引があれば退会できない
+	var hasOpenOrder bool
+	orderQuery := `
+		SELECT EXISTS(
+			SELECT 1 FROM orders
+			WHERE (buyer_id = ? OR seller_id = ?) AND status NOT IN (?, ?)
+		)`
+	if err := tx.QueryRowContext(ctx, orderQuery, id, id, model.OrderStatusCompleted, model.OrderStatusCancelled).Scan(&hasOpenOrder); err != nil {
+		return nil, fmt.Errorf("fail: check open orders: %w", err)
+	}
+	if hasOpenOrder {
+		return nil, model.ErrUserHasOpenOrder
+	}
+
+	// 販売中の商品を取り下げる (購入と競合しないよう行ロック)
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM items WHERE user_id = ? AND status = ? FOR UPDATE`, id, model.StatusOnSale)
+	if err != nil {
+		return nil, fmt.Errorf("fail: select items: %w", err)
+	}
+	itemIDs := make([]string, 0)
+	for rows.Next() {
+		var itemID string
+		if err := rows.Scan(&itemID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		itemIDs = append(itemIDs, itemID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	now := time.Now()
+	if len(itemIDs) > 0 {
+		placeholders := make([]string, len(itemIDs))
+		args := make([]interface{}, 0, len(itemIDs)+2)
+		args = append(args, model.StatusWithdrawn, now)
+		for i, itemID := range itemIDs {
+			placeholders[i] = "?"
+			args = append(args, itemID)
+		}
+		in := strings.Join(placeholders, ",")
+		if _, err := tx.ExecContext(ctx, `UPDATE items SET status = ?, updated_at = ? WHERE id IN (`+in+`)`, args...); err != nil {
+			return nil, fmt.Errorf("fail: withdraw items: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM likes WHERE item_id IN (`+in+`)`, args[2:]...); err != nil {
+			return nil, fmt.Errorf("fail: delete item likes: %w", err)
+		}
+	}
+
+	offerQuery := `UPDATE offers SET status = ?, updated_at = ? WHERE (buyer_id = ? OR seller_id = ?) AND status IN (?, ?)`
+	if _, err := tx.ExecContext(ctx, offerQuery, model.OfferStatusRejected, now, id, id, model.OfferStatusPending, model.OfferStatusAccepted); err != nil {
+		return nil, fmt.Errorf("fail: reject offers: %w", err)
+	}
+
+	deletes := []struct {
+		table string
+		query string
+		args  []interface{}
+	}{
+		{"likes", `DELETE FROM likes WHERE user_id = ?`, []interface{}{id}},
+		{"follows", `DELETE FROM follows WHERE follower_id = ? OR followee_id = ?`, []interface{}{id, id}},
+		{"blocks", `DELETE FROM blocks WHERE blocker_id = ? OR blocked_id = ?`, []interface{}{id, id}},
+		{"saved_searches", `DELETE FROM saved_searches WHERE user_id = ?`, []interface{}{id}},
+		{"notifications", `DELETE FROM notifications WHERE user_id = ?`, []interface{}{id}},
+	}
+	for _, d := range deletes {
+		if _, err := tx.ExecContext(ctx, d.query, d.args...); err != nil {
+			return nil, fmt.Errorf("fail: delete %s: %w", d.table, err)
+		}
+	}
+
+	// 個人情報を消して表示名だけ残す (ハンドルは他のユーザーが使えるよう解放する)
+	anonymizeQuery := `
+		UPDATE users
+		SET name = ?, handle = NULL, age = -1, email = '', bio = '', icon_url = '', updated_at = ?, deleted_at = ?
+		WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, anonymizeQuery, model.DeletedUserName, now, now, id); err != nil {
+		return nil, fmt.Errorf("fail: anonymize user: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("fail:tx.Commit(): %w", err)
+	}
+	return itemIDs, nil
+}
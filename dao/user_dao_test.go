@@ -0,0 +1,240 @@
+package dao
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestUserDao_DBInsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewUserDao(db)
+	query := regexp.QuoteMeta("INSERT INTO users")
+
+	tests := []struct {
+		name       string
+		user       *model.User
+		wantHandle interface{}
+		execErr    error
+		wantErr    error
+	}{
+		{name: "成功: ハンドルあり", user: &model.User{Id: "user1", Name: "Taro", Handle: "tanaka_shop"}, wantHandle: "tanaka_shop"},
+		{name: "成功: ハンドル未設定はNULL", user: &model.User{Id: "user1", Name: "Taro"}, wantHandle: nil},
+		{
+			name:       "失敗: ハンドルが使用済み",
+			user:       &model.User{Id: "user1", Name: "Taro", Handle: "tanaka_shop"},
+			wantHandle: "tanaka_shop",
+			execErr:    &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'tanaka_shop' for key 'users.user_handle'"},
+			wantErr:    model.ErrHandleTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := mock.ExpectExec(query).
+				WithArgs(tt.user.Id, tt.user.Name, tt.wantHandle, 0, "", "", "", sqlmock.AnyArg(), sqlmock.AnyArg())
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			err := dao.DBInsert(context.Background(), tt.user)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("DBInsert() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("DBInsert() error = %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+
+	t.Run("失敗: ID重複はハンドル使用済みとして扱わない", func(t *testing.T) {
+		mock.ExpectExec(query).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'user1' for key 'users.PRIMARY'"})
+
+		err := dao.DBInsert(context.Background(), &model.User{Id: "user1", Name: "Taro"})
+		if err == nil || errors.Is(err, model.ErrHandleTaken) {
+			t.Errorf("DBInsert() error = %v, want non-handle error", err)
+		}
+	})
+}
+
+func TestUserDao_GetMentionedUserIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewUserDao(db)
+	ctx := context.Background()
+
+	t.Run("成功: 本人とブロック関係を除いて取得", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE u.handle IN (?,?)")).
+			WithArgs("tanaka_shop", "sato", "seller1", "seller1", "seller1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user1"))
+
+		userIDs, err := dao.GetMentionedUserIDs(ctx, "seller1", []string{"tanaka_shop", "sato"})
+		if err != nil {
+			t.Fatalf("GetMentionedUserIDs() error = %v", err)
+		}
+		if want := []string{"user1"}; !reflect.DeepEqual(userIDs, want) {
+			t.Errorf("GetMentionedUserIDs() = %v, want %v", userIDs, want)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("成功: ハンドルがなければクエリしない", func(t *testing.T) {
+		userIDs, err := dao.GetMentionedUserIDs(ctx, "seller1", nil)
+		if err != nil || len(userIDs) != 0 {
+			t.Errorf("GetMentionedUserIDs() = %v, %v, want empty", userIDs, err)
+		}
+	})
+}
+
+func TestUserDao_DeleteUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewUserDao(db)
+	ctx := context.Background()
+	lockQuery := regexp.QuoteMeta("SELECT id FROM users WHERE id = ? AND deleted_at IS NULL FOR UPDATE")
+	orderQuery := regexp.QuoteMeta("SELECT EXISTS(")
+	itemsQuery := regexp.QuoteMeta("SELECT id FROM items WHERE user_id = ? AND status = ? FOR UPDATE")
+
+	t.Run("成功: 商品を取り下げて匿名化", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("user1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user1"))
+		mock.ExpectQuery(orderQuery).
+			WithArgs("user1", "user1", model.OrderStatusCompleted, model.OrderStatusCancelled).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectQuery(itemsQuery).WithArgs("user1", model.StatusOnSale).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("item1").AddRow("item2"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE items SET status = ?, updated_at = ? WHERE id IN (?,?)")).
+			WithArgs(model.StatusWithdrawn, sqlmock.AnyArg(), "item1", "item2").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM likes WHERE item_id IN (?,?)")).
+			WithArgs("item1", "item2").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE offers SET status = ?")).
+			WithArgs(model.OfferStatusRejected, sqlmock.AnyArg(), "user1", "user1", model.OfferStatusPending, model.OfferStatusAccepted).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM likes WHERE user_id = ?")).WithArgs("user1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM follows")).WithArgs("user1", "user1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM blocks")).WithArgs("user1", "user1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM saved_searches")).WithArgs("user1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM notifications")).WithArgs("user1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("SET name = ?, handle = NULL")).
+			WithArgs(model.DeletedUserName, sqlmock.AnyArg(), sqlmock.AnyArg(), "user1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		itemIDs, err := dao.DeleteUser(ctx, "user1")
+		if err != nil {
+			t.Fatalf("DeleteUser() error = %v", err)
+		}
+		if want := []string{"item1", "item2"}; !reflect.DeepEqual(itemIDs, want) {
+			t.Errorf("DeleteUser() = %v, want %v", itemIDs, want)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: 完了していない取引がある", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("user1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user1"))
+		mock.ExpectQuery(orderQuery).
+			WithArgs("user1", "user1", model.OrderStatusCompleted, model.OrderStatusCancelled).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		if _, err := dao.DeleteUser(ctx, "user1"); !errors.Is(err, model.ErrUserHasOpenOrder) {
+			t.Errorf("DeleteUser() error = %v, want %v", err, model.ErrUserHasOpenOrder)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("失敗: 退会済み", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("user1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		if _, err := dao.DeleteUser(ctx, "user1"); !errors.Is(err, model.ErrUserNotFound) {
+			t.Errorf("DeleteUser() error = %v, want %v", err, model.ErrUserNotFound)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestUserDao_UpdateUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dao := NewUserDao(db)
+	query := regexp.QuoteMeta("WHERE id = ? AND deleted_at IS NULL")
+	user := &model.User{Id: "user1", Name: "Taro", Age: -1, Bio: "よろしくお願いします"}
+
+	tests := []struct {
+		name         string
+		clearHandle  bool
+		rowsAffected int64
+		wantErr      error
+	}{
+		{name: "成功: 更新", rowsAffected: 1},
+		{name: "成功: ハンドルを削除", clearHandle: true, rowsAffected: 1},
+		{name: "失敗: 退会後は更新できない", rowsAffected: 0, wantErr: model.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectExec(query).
+				WithArgs(user.Name, tt.clearHandle, nil, user.Age, user.Bio, user.IconURL, sqlmock.AnyArg(), user.Id).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			if err := dao.UpdateUser(context.Background(), user, tt.clearHandle); !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateUser() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package hub
+
+import (
+	"context"
+	"db/model"
+	"log"
+	"sync"
+)
+
+// subscriberBuffer : 1購読者あたりのバッファサイズ(読み出しが遅い購読者でPublishを詰まらせないため)
+const subscriberBuffer = 16
+
+// ChatHub : チャットルーム単位でメッセージを配信するpub/sub
+// 複数インスタンス構成にする場合はRedisなどを使った実装に差し替える
+type ChatHub interface {
+	Publish(ctx context.Context, roomID string, msg model.Message) error
+	Subscribe(ctx context.Context, roomID string) (<-chan model.Message, func(), error)
+}
+
+// localChatHub : プロセス内で完結するChatHubの実装
+type localChatHub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan model.Message]struct{}
+}
+
+// NewLocalChatHub : インメモリのChatHubを生成
+func NewLocalChatHub() ChatHub {
+	return &localChatHub{
+		subscribers: make(map[string]map[chan model.Message]struct{}),
+	}
+}
+
+// Publish : ルームの購読者全員にメッセージを配信(バッファが溢れている購読者はスキップ)
+func (h *localChatHub) Publish(ctx context.Context, roomID string, msg model.Message) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[roomID] {
+		select {
+		case ch <- msg:
+		default:
+			log.Printf("Warning: chat hub subscriber is full, message dropped: room=%s", roomID)
+		}
+	}
+	return nil
+}
+
+// Subscribe : ルームを購読する。返り値の関数で購読を解除する(チャネルはcloseされる)
+func (h *localChatHub) Subscribe(ctx context.Context, roomID string) (<-chan model.Message, func(), error) {
+	ch := make(chan model.Message, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[roomID] == nil {
+		h.subscribers[roomID] = make(map[chan model.Message]struct{})
+	}
+	h.subscribers[roomID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			delete(h.subscribers[roomID], ch)
+			if len(h.subscribers[roomID]) == 0 {
+				delete(h.subscribers, roomID)
+			}
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe, nil
+}
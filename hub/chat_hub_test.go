@@ -0,0 +1,45 @@
+package hub
+
+import (
+	"context"
+	"db/model"
+	"testing"
+)
+
+func TestLocalChatHub_PublishSubscribe(t *testing.T) {
+	ctx := context.Background()
+	h := NewLocalChatHub()
+
+	ch1, unsubscribe1, _ := h.Subscribe(ctx, "room1")
+	ch2, unsubscribe2, _ := h.Subscribe(ctx, "room2")
+	defer unsubscribe2()
+
+	if err := h.Publish(ctx, "room1", model.Message{Id: "msg1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	select {
+	case msg := <-ch1:
+		if msg.Id != "msg1" {
+			t.Errorf("room1 received %v, want msg1", msg.Id)
+		}
+	default:
+		t.Errorf("room1 subscriber did not receive message")
+	}
+
+	select {
+	case msg := <-ch2:
+		t.Errorf("room2 subscriber should not receive room1 message: %v", msg.Id)
+	default:
+	}
+
+	// 購読解除後はチャネルがcloseされ、Publishしても届かない
+	unsubscribe1()
+	unsubscribe1() // 2回呼んでもpanicしない
+	if err := h.Publish(ctx, "room1", model.Message{Id: "msg2"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if _, ok := <-ch1; ok {
+		t.Errorf("channel should be closed after unsubscribe")
+	}
+}
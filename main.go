@@ -6,7 +6,9 @@ import (
 	"db/cache"
 	"db/controller"
 	"db/dao"
+	"db/hub"
 	"db/middleware"
+	"db/search"
 	"db/service"
 	"db/usecase"
 	"fmt"
@@ -15,6 +17,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/auth"
@@ -23,6 +26,12 @@ import (
 	"github.com/joho/godotenv"
 )
 
+// suggestRefreshInterval : 検索語の補完候補を作り直す間隔
+const suggestRefreshInterval = 10 * time.Minute
+
+// sellerStatsTTL : 出品者の実績をキャッシュする期間 (購入・メッセージ送信ではその場で破棄する)
+const sellerStatsTTL = 10 * time.Minute
+
 // DBInit :環境変数からDB接続情報を取得し、DB接続を初期化
 func DBInit() (*sql.DB, error) {
 
@@ -95,46 +104,108 @@ func main() {
 	// --- 依存性の注入 (DI) ---
 	// --- user ---
 	userDAO := dao.NewUserDao(db)
+	reviewDAO := dao.NewReviewDao(db)
+	followDAO := dao.NewFollowDao(db)
+	blockDAO := dao.NewBlockDao(db)
 	userRegister := usecase.NewUserRegister(userDAO)
 	userSearch := usecase.NewUserSearch(userDAO)
-	userGet := usecase.NewUserGet(userDAO)
+	userGet := usecase.NewUserGet(userDAO, reviewDAO, followDAO)
 	userUpdate := usecase.NewUserUpdate(userDAO)
-	userController := controller.NewUserController(userRegister, userSearch, userGet, userUpdate)
 
 	// --- notification ---
 	notificationDAO := dao.NewNotificationDAO(db)
 
 	// --- item ---
 	itemDAO := dao.NewItemDao(db)
+	offerDAO := dao.NewOfferDao(db)
+	likeDAO := dao.NewLikeDao(db)
+	categoryDAO := dao.NewCategoryDao(db)
+	searchQueryDAO := dao.NewSearchQueryDao(db)
+	savedSearchDAO := dao.NewSavedSearchDao(db)
 	// --- embedding cache (インメモリキャッシュで高速化) ---
 	embeddingCache := cache.NewEmbeddingCache(itemDAO)
-
-	itemRegister := usecase.NewItemRegister(itemDAO, geminiService, embeddingCache)
-	itemList := usecase.NewItemList(itemDAO)
+	// --- search index (キーワード検索用の転置インデックス) ---
+	searchIndex := search.NewInvertedIndex(itemDAO)
+	// --- seller stats cache (出品者の実績のインメモリキャッシュ) ---
+	sellerStatsCache := cache.NewSellerStatsCache(sellerStatsTTL)
+
+	itemRegister := usecase.NewItemRegister(itemDAO, categoryDAO, geminiService, embeddingCache, searchIndex, savedSearchDAO, notificationDAO, followDAO, userDAO)
+	itemList := usecase.NewItemList(itemDAO, categoryDAO, blockDAO, searchIndex)
+	itemSemanticSearch := usecase.NewItemSemanticSearch(itemDAO, categoryDAO, blockDAO, geminiService, embeddingCache, searchIndex)
+	itemSuggest := usecase.NewItemSuggest(itemDAO, searchQueryDAO, search.NewTrieSuggester())
+	// 補完候補は起動時に作り、以降は定期的に作り直す
+	if err := itemSuggest.Refresh(context.Background()); err != nil {
+		log.Printf("Warning: failed to build search suggestions: %v", err)
+	}
+	go func() {
+		for range time.Tick(suggestRefreshInterval) {
+			if err := itemSuggest.Refresh(context.Background()); err != nil {
+				log.Printf("Warning: failed to refresh search suggestions: %v", err)
+			}
+		}
+	}()
 	myItemsList := usecase.NewMyItemsList(itemDAO)
 	userItemsList := usecase.NewUserItemsList(itemDAO)
 	itemGet := usecase.NewItemGet(itemDAO)
-	itemPurchase := usecase.NewItemPurchase(itemDAO, notificationDAO, embeddingCache)
-	itemUpdate := usecase.NewItemUpdate(itemDAO, geminiService, embeddingCache)
+	purchaseHistory := usecase.NewPurchaseHistory(itemDAO)
+	itemPurchase := usecase.NewItemPurchase(itemDAO, offerDAO, notificationDAO, blockDAO, embeddingCache, sellerStatsCache)
+	itemUpdate := usecase.NewItemUpdate(itemDAO, categoryDAO, geminiService, embeddingCache, searchIndex, likeDAO, notificationDAO, userDAO)
+	itemWithdraw := usecase.NewItemWithdraw(itemDAO, offerDAO, likeDAO, notificationDAO, embeddingCache, searchIndex, sellerStatsCache)
 	descriptionGenerate := usecase.NewDescriptionGenerate(geminiService)
 
+	likeUsecase := usecase.NewLikeUsecase(likeDAO, itemDAO)
+
 	// Item controllers (refactored into 3 specialized controllers)
-	itemQueryController := controller.NewItemQueryController(itemList, myItemsList, userItemsList, itemGet)
-	itemCommandController := controller.NewItemCommandController(itemRegister, itemUpdate, itemPurchase)
+	itemQueryController := controller.NewItemQueryController(itemList, itemSemanticSearch, itemSuggest, myItemsList, userItemsList, itemGet, purchaseHistory, likeUsecase)
+	itemCommandController := controller.NewItemCommandController(itemRegister, itemUpdate, itemPurchase, itemWithdraw)
 	itemAIController := controller.NewItemAIController(descriptionGenerate)
 
-	// --- chat ---
+	// --- user (退会・エクスポート・出品者の実績は商品・チャットのDAOを使うためここで生成) ---
 	chatDAO := dao.NewChatDao(db)
-	chatUsecase := usecase.NewChatUsecase(chatDAO, itemDAO, notificationDAO)
+	userDelete := usecase.NewUserDelete(userDAO, embeddingCache, searchIndex, authClient)
+	userExport := usecase.NewUserExport(userDAO, itemDAO, likeDAO, chatDAO, notificationDAO)
+	sellerStats := usecase.NewSellerStatsUsecase(userDAO, itemDAO, chatDAO, sellerStatsCache)
+	userController := controller.NewUserController(userRegister, userSearch, userGet, userUpdate, userDelete, userExport, sellerStats)
+
+	// --- category ---
+	categoryUsecase := usecase.NewCategoryUsecase(categoryDAO)
+	categoryController := controller.NewCategoryController(categoryUsecase)
+
+	// --- follow ---
+	followUsecase := usecase.NewFollowUsecase(followDAO, userDAO, itemDAO, blockDAO)
+	followController := controller.NewFollowController(followUsecase)
+
+	// --- block ---
+	blockUsecase := usecase.NewBlockUsecase(blockDAO, userDAO)
+	blockController := controller.NewBlockController(blockUsecase)
+
+	// --- saved search ---
+	savedSearchUsecase := usecase.NewSavedSearchUsecase(savedSearchDAO)
+	savedSearchController := controller.NewSavedSearchController(savedSearchUsecase)
+
+	// --- order ---
+	orderDAO := dao.NewOrderDao(db)
+	orderUsecase := usecase.NewOrderUsecase(orderDAO, itemDAO, notificationDAO, embeddingCache, sellerStatsCache)
+	orderController := controller.NewOrderController(orderUsecase)
+
+	// --- review ---
+	reviewUsecase := usecase.NewReviewUsecase(reviewDAO, orderDAO, notificationDAO)
+	reviewController := controller.NewReviewController(reviewUsecase)
+
+	// --- chat ---
+	chatHub := hub.NewLocalChatHub()
+	chatUsecase := usecase.NewChatUsecase(chatDAO, itemDAO, notificationDAO, blockDAO, chatHub, sellerStatsCache)
 	chatController := controller.NewChatController(chatUsecase)
 
+	// --- offer ---
+	offerUsecase := usecase.NewOfferUsecase(offerDAO, itemDAO, chatDAO, notificationDAO, blockDAO, chatHub, sellerStatsCache)
+	offerController := controller.NewOfferController(offerUsecase)
+
 	// --- like ---
-	likeDAO := dao.NewLikeDao(db)
-	likeUsecase := usecase.NewLikeUsecase(likeDAO)
 	likeController := controller.NewLikeController(likeUsecase)
 
 	// --- recommend ---
-	recommendUsecase := usecase.NewRecommendUsecase(itemDAO, likeDAO, embeddingCache)
+	recommendUsecase := usecase.NewRecommendUsecase(itemDAO, likeDAO, blockDAO, embeddingCache)
 	recommendController := controller.NewRecommendController(recommendUsecase)
 
 	// --- notification controller ---
@@ -147,26 +218,73 @@ func main() {
 	mux.HandleFunc("GET /user", userController.HandleSearchUser)
 	mux.Handle("POST /register", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(userController.HandleProfileRegister)))
 	mux.HandleFunc("GET /users/{id}", userController.HandleGetUser)
+	// GET /users/by-handle/{handle} は GET /users/{id}/items などと衝突して登録できないため2階層のパターンで受ける
+	// (items などの固定パスが優先されるので、それらはハンドルの予約語にしている)
+	mux.HandleFunc("GET /users/{lookup}/{handle}", userController.HandleGetUserByHandle)
 	mux.Handle("PUT /users/me", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(userController.HandleUpdateUser)))
+	mux.Handle("DELETE /users/me", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(userController.HandleDeleteUser)))
+	mux.Handle("GET /users/me/export", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(userController.HandleExportUser)))
 
 	// Item Query Endpoints
-	mux.HandleFunc("GET /items", itemQueryController.HandleItemList)
-	mux.HandleFunc("GET /items/{id}", itemQueryController.HandleItemDetail)
+	mux.Handle("GET /items", middleware.OptionalFirebaseAuthMiddleware(authClient, http.HandlerFunc(itemQueryController.HandleItemList)))
+	mux.Handle("GET /items/{id}", middleware.OptionalFirebaseAuthMiddleware(authClient, http.HandlerFunc(itemQueryController.HandleItemDetail)))
+	mux.HandleFunc("GET /items/suggest", itemQueryController.HandleSuggest)
 	mux.Handle("GET /items/my", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(itemQueryController.HandleMyItems)))
-	mux.HandleFunc("GET /users/{userId}/items", itemQueryController.HandleUserItems)
-	mux.HandleFunc("GET /items/{id}/recommend", recommendController.HandleGetRecommendations)
+	mux.Handle("GET /items/purchased", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(itemQueryController.HandlePurchasedItems)))
+	mux.Handle("GET /items/sold", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(itemQueryController.HandleSoldItems)))
+	mux.Handle("GET /users/{userId}/items", middleware.OptionalFirebaseAuthMiddleware(authClient, http.HandlerFunc(itemQueryController.HandleUserItems)))
+	mux.Handle("GET /items/{id}/recommend", middleware.OptionalFirebaseAuthMiddleware(authClient, http.HandlerFunc(recommendController.HandleGetRecommendations)))
 	mux.Handle("GET /items/recommend", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(recommendController.HandleGetPersonalizedRecommendations)))
 
+	// Category Endpoints
+	mux.HandleFunc("GET /categories", categoryController.HandleGetCategories)
+
 	// 商品出品 (POST /items)
 	mux.Handle("POST /items", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(itemCommandController.HandleItemRegister)))
 	// 商品購入 (POST /items/{id}/purchase)
 	mux.Handle("POST /items/{id}/purchase", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(itemCommandController.HandleItemPurchase)))
 	// 商品更新 (PUT /items/{id})
 	mux.Handle("PUT /items/{id}", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(itemCommandController.HandleItemUpdate)))
+	// 出品取り下げ (DELETE /items/{id})
+	mux.Handle("DELETE /items/{id}", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(itemCommandController.HandleItemWithdraw)))
 	// AI商品説明生成 (POST /items/generate-description)
 	mux.Handle("POST /items/generate-description", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(itemAIController.HandleGenerateDescription)))
 
+	// Follow Endpoints (フォローとフォロー中の出品者の新着)
+	mux.Handle("PUT /users/{id}/follow", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(followController.HandleFollow)))
+	mux.Handle("DELETE /users/{id}/follow", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(followController.HandleUnfollow)))
+	mux.HandleFunc("GET /users/{id}/followers", followController.HandleGetFollowers)
+	mux.HandleFunc("GET /users/{id}/following", followController.HandleGetFollowing)
+	mux.Handle("GET /items/feed", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(followController.HandleGetFeed)))
+
+	// Block Endpoints (ブロックした相手とはチャット・購入できず、お互いの商品も表示しない)
+	mux.Handle("PUT /users/{id}/block", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(blockController.HandleBlock)))
+	mux.Handle("DELETE /users/{id}/block", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(blockController.HandleUnblock)))
+	mux.Handle("GET /users/me/blocks", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(blockController.HandleGetBlockedUsers)))
+
+	// Saved Search Endpoints (保存した検索条件と新着通知)
+	mux.Handle("POST /saved-searches", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(savedSearchController.HandleCreateSavedSearch)))
+	mux.Handle("GET /saved-searches", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(savedSearchController.HandleGetSavedSearches)))
+	mux.Handle("PUT /saved-searches/{id}", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(savedSearchController.HandleUpdateSavedSearch)))
+	mux.Handle("DELETE /saved-searches/{id}", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(savedSearchController.HandleDeleteSavedSearch)))
+
+	// Order Endpoints (購入後の取引)
+	mux.Handle("GET /orders/{id}", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(orderController.HandleGetOrder)))
+	mux.Handle("POST /orders/{id}/{action}", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(orderController.HandleOrderTransition)))
+
+	// Offer Endpoints (価格交渉)
+	mux.Handle("POST /items/{id}/offers", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(offerController.HandleCreateOffer)))
+	mux.Handle("GET /offers/{id}", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(offerController.HandleGetOffer)))
+	mux.Handle("POST /offers/{id}/{action}", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(offerController.HandleRespondOffer)))
+
+	// Review Endpoints (売買成立後の相互評価)
+	mux.Handle("POST /items/{id}/reviews", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(reviewController.HandleCreateReview)))
+	mux.HandleFunc("GET /users/{id}/reviews", reviewController.HandleGetUserReviews)
+
 	// Like Endpoints
+	mux.Handle("PUT /items/{id}/like", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(likeController.HandleLike)))
+	mux.Handle("DELETE /items/{id}/like", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(likeController.HandleUnlike)))
+	// 互換性のため残しているトグル (切り替え後の状態を返す)
 	mux.Handle("POST /items/{id}/like", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(likeController.HandleToggleLike)))
 	mux.Handle("GET /items/liked", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(likeController.HandleGetLikedItems)))
 	mux.Handle("GET /items/liked-ids", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(likeController.HandleGetLikedItemIDs)))
@@ -174,8 +292,12 @@ func main() {
 	// Chat Endpoints
 	mux.Handle("POST /items/{item_id}/chat", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(chatController.HandleGetOrCreateRoom)))
 	mux.Handle("GET /items/{item_id}/chat_rooms", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(chatController.HandleGetChatRoomList)))
+	mux.Handle("GET /chats", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(chatController.HandleGetInbox)))
+	mux.Handle("GET /chats/unread", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(chatController.HandleGetUnreadCounts)))
+	mux.Handle("PUT /chats/{room_id}/read", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(chatController.HandleMarkRead)))
 	mux.Handle("GET /chats/{room_id}/messages", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(chatController.HandleGetMessages)))
 	mux.Handle("POST /chats/{room_id}/messages", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(chatController.HandleSendMessage)))
+	mux.Handle("GET /chats/{room_id}/stream", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(chatController.HandleStreamMessages)))
 
 	// Notification Endpoints
 	mux.Handle("GET /notifications", middleware.FirebaseAuthMiddleware(authClient, http.HandlerFunc(notificationController.HandleGetNotifications)))
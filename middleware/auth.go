@@ -32,11 +32,40 @@ func FirebaseAuthMiddleware(client *auth.Client, next http.Handler) http.Handler
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), userIDKey, token.UID)
+		ctx := WithUserID(r.Context(), token.UID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// OptionalFirebaseAuthMiddleware : 公開エンドポイント用。トークンがあれば検証してユーザーIDをcontextに詰める
+// トークンがない・検証に失敗した場合も未ログインとして処理を続ける (閲覧を妨げないため)
+func OptionalFirebaseAuthMiddleware(client *auth.Client, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+
+		idToken := strings.TrimPrefix(authHeader, "Bearer ")
+		if idToken == "" || idToken == authHeader {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, err := client.VerifyIDToken(r.Context(), idToken)
+		if err != nil {
+			log.Printf("auth: verification failed, continuing as guest: %v", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := WithUserID(r.Context(), token.UID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WithUserID : 認証済みユーザーIDをcontextに詰める(テストなど認証を経由しない場合に使用)
+func WithUserID(ctx context.Context, uid string) context.Context {
+	return context.WithValue(ctx, userIDKey, uid)
+}
+
 func GetUserIDFromContext(ctx context.Context) (string, error) {
 	uid, ok := ctx.Value(userIDKey).(string)
 	if !ok || uid == "" {
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// BlockedUser : ブロック中のユーザー一覧の1件
+type BlockedUser struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	IconURL   string    `json:"icon_url,omitempty"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+// BlockState : ブロック・解除後の状態
+type BlockState struct {
+	UserId  string `json:"user_id"`
+	Blocked bool   `json:"blocked"`
+}
@@ -0,0 +1,76 @@
+package model
+
+// Category : 商品カテゴリ (例: ファッション > メンズ > 靴)
+type Category struct {
+	Id        int         `json:"id"`
+	ParentId  int         `json:"parent_id,omitempty"` // 0ならトップレベル
+	Name      string      `json:"name"`
+	ItemCount int         `json:"item_count"` // 子孫カテゴリの商品を含む
+	Children  []*Category `json:"children"`
+}
+
+// BuildCategoryTree : フラットなカテゴリ一覧から木を組み立てる
+// directCountsはカテゴリ直下の商品数で、各ノードのItemCountには子孫の分を合算する
+func BuildCategoryTree(categories []Category, directCounts map[int]int) []*Category {
+	nodes := make(map[int]*Category, len(categories))
+	for _, c := range categories {
+		node := c
+		node.ItemCount = directCounts[c.Id]
+		node.Children = make([]*Category, 0)
+		nodes[c.Id] = &node
+	}
+
+	roots := make([]*Category, 0)
+	for _, c := range categories {
+		node := nodes[c.Id]
+		if parent, ok := nodes[c.ParentId]; ok && c.ParentId != c.Id {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	for _, root := range roots {
+		sumItemCount(root)
+	}
+	return roots
+}
+
+// sumItemCount : 子孫の商品数を合算してItemCountに反映する
+func sumItemCount(node *Category) int {
+	for _, child := range node.Children {
+		node.ItemCount += sumItemCount(child)
+	}
+	return node.ItemCount
+}
+
+// DescendantCategoryIDs : 指定カテゴリ自身と子孫カテゴリのIDを返す (存在しなければnil)
+func DescendantCategoryIDs(categories []Category, rootID int) []int {
+	children := make(map[int][]int)
+	found := false
+	for _, c := range categories {
+		if c.Id == rootID {
+			found = true
+		}
+		if c.Id != c.ParentId {
+			children[c.ParentId] = append(children[c.ParentId], c.Id)
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	// 親子関係が循環していても止まるよう、一度たどったカテゴリは飛ばす
+	ids := []int{rootID}
+	visited := map[int]bool{rootID: true}
+	for i := 0; i < len(ids); i++ {
+		for _, childID := range children[ids[i]] {
+			if visited[childID] {
+				continue
+			}
+			visited[childID] = true
+			ids = append(ids, childID)
+		}
+	}
+	return ids
+}
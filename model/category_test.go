@@ -0,0 +1,76 @@
+package model
+
+import (
+	"slices"
+	"testing"
+)
+
+// テスト用のカテゴリ: ファッション > メンズ > 靴 / ファッション > レディース, 家電
+var testCategories = []Category{
+	{Id: 1, Name: "ファッション"},
+	{Id: 2, ParentId: 1, Name: "メンズ"},
+	{Id: 3, ParentId: 2, Name: "靴"},
+	{Id: 4, ParentId: 1, Name: "レディース"},
+	{Id: 5, Name: "家電"},
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	roots := BuildCategoryTree(testCategories, map[int]int{2: 1, 3: 2, 4: 3, 5: 4})
+
+	if len(roots) != 2 {
+		t.Fatalf("BuildCategoryTree() roots = %d, want 2", len(roots))
+	}
+	fashion := roots[0]
+	if fashion.Id != 1 || len(fashion.Children) != 2 {
+		t.Fatalf("fashion = %+v", fashion)
+	}
+	// 子孫の商品数を合算する
+	if fashion.ItemCount != 6 {
+		t.Errorf("fashion.ItemCount = %d, want 6", fashion.ItemCount)
+	}
+	men := fashion.Children[0]
+	if men.ItemCount != 3 || len(men.Children) != 1 || men.Children[0].ItemCount != 2 {
+		t.Errorf("men = %+v", men)
+	}
+	if roots[1].ItemCount != 4 || len(roots[1].Children) != 0 {
+		t.Errorf("appliances = %+v", roots[1])
+	}
+}
+
+func TestDescendantCategoryIDs(t *testing.T) {
+	testCases := []struct {
+		name   string
+		rootID int
+		want   []int
+	}{
+		{name: "成功: トップレベルは子孫すべて", rootID: 1, want: []int{1, 2, 3, 4}},
+		{name: "成功: 中間カテゴリ", rootID: 2, want: []int{2, 3}},
+		{name: "成功: 末端カテゴリ", rootID: 3, want: []int{3}},
+		{name: "失敗: 存在しないカテゴリ", rootID: 99, want: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DescendantCategoryIDs(testCategories, tc.rootID)
+			slices.Sort(got)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("DescendantCategoryIDs(%d) = %v, want %v", tc.rootID, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDescendantCategoryIDs_Cycle(t *testing.T) {
+	// 6→7→6 と親子関係が循環している不正なデータ
+	categories := append(slices.Clone(testCategories),
+		Category{Id: 6, ParentId: 7, Name: "循環A"},
+		Category{Id: 7, ParentId: 6, Name: "循環B"},
+		Category{Id: 8, ParentId: 7, Name: "循環Bの子"},
+	)
+
+	got := DescendantCategoryIDs(categories, 6)
+	slices.Sort(got)
+	if want := []int{6, 7, 8}; !slices.Equal(got, want) {
+		t.Errorf("DescendantCategoryIDs(6) = %v, want %v", got, want)
+	}
+}
@@ -11,25 +11,105 @@ type ChatRoom struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// IsMember : 指定ユーザーがルームの当事者(購入希望者 or 出品者)かどうか
+func (r *ChatRoom) IsMember(userID string) bool {
+	return userID != "" && (userID == r.BuyerId || userID == r.SellerId)
+}
+
+// Message type
+const (
+	MessageTypeText  = "text"  // ユーザーが送信したメッセージ
+	MessageTypeOffer = "offer" // 価格交渉のシステムメッセージ (OfferIdつき)
+)
+
 // Message : メッセージ構造体
 type Message struct {
 	Id         string    `json:"id"`
 	ChatRoomId string    `json:"chat_room_id"`
 	SenderId   string    `json:"sender_id"`
+	Type       string    `json:"type"`
+	OfferId    string    `json:"offer_id,omitempty"`
 	Content    string    `json:"content"`
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+// メッセージ取得のページサイズ
+const (
+	DefaultMessagePageSize = 50
+	MaxMessagePageSize     = 100
+)
+
+// MessagePageQuery : メッセージ履歴のカーソル指定 (Before/Afterはメッセージの ULID)
+// どちらも空なら最新のメッセージから取得する
+type MessagePageQuery struct {
+	Before string
+	After  string
+	Limit  int
+}
+
+// IsValid バリデーション (BeforeとAfterの同時指定は不可)
+func (q *MessagePageQuery) IsValid() bool {
+	if q.Before != "" && q.After != "" {
+		return false
+	}
+	return q.Limit >= 0 && q.Limit <= MaxMessagePageSize
+}
+
+// MessagePage : メッセージ履歴のレスポンス (Messagesは常に古い順)
+// NextCursorは同じ方向(before/after)で次のページを取得するためのカーソル
+// CounterpartLastReadMessageIDは相手がどこまで読んだか(既読表示用)
+type MessagePage struct {
+	Messages                     []Message `json:"messages"`
+	NextCursor                   string    `json:"next_cursor,omitempty"`
+	HasMore                      bool      `json:"has_more"`
+	CounterpartLastReadMessageID string    `json:"counterpart_last_read_message_id,omitempty"`
+}
+
+// ChatReadState : ルーム参加者ごとの既読位置 (最後に読んだメッセージのULID)
+type ChatReadState struct {
+	ChatRoomId        string    `json:"chat_room_id"`
+	UserId            string    `json:"user_id"`
+	LastReadMessageId string    `json:"last_read_message_id"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
 // チャットルーム一覧表示用
 type ChatRoomInfo struct {
 	RoomID        string    `json:"room_id"`
 	BuyerID       string    `json:"buyer_id"`
 	BuyerName     string    `json:"buyer_name"`
-	BuyerImageURL string    `json:"buyer_image_url"` 
+	BuyerImageURL string    `json:"buyer_image_url"`
 	CreatedAt     time.Time `json:"created_at"`
 }
 
+// ChatInboxEntry : 受信箱(参加中の全チャットルーム)の1件
+type ChatInboxEntry struct {
+	RoomID             string     `json:"room_id"`
+	Role               string     `json:"role"` // "buyer" or "seller" (自分の立場)
+	ItemID             string     `json:"item_id"`
+	ItemName           string     `json:"item_name"`
+	ItemImageURL       string     `json:"item_image_url"`
+	CounterpartID      string     `json:"counterpart_id"`
+	CounterpartName    string     `json:"counterpart_name"`
+	CounterpartIconURL string     `json:"counterpart_icon_url"`
+	LastMessage        string     `json:"last_message"`
+	LastMessageAt      *time.Time `json:"last_message_at,omitempty"`
+	UnreadCount        int        `json:"unread_count"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// チャット内での立場
+const (
+	ChatRoleBuyer  = "buyer"
+	ChatRoleSeller = "seller"
+)
+
 // リクエスト用
 type MessageSendRequest struct {
 	Content string `json:"content"`
 }
+
+// MarkReadRequest : 既読リクエスト (MessageIDが空ならルームの最新メッセージまで既読にする)
+type MarkReadRequest struct {
+	MessageID string `json:"message_id"`
+}
@@ -7,6 +7,56 @@ var (
 	ErrNotAuthorized        = errors.New("not authorized to update this item")
 	ErrCannotUpdateSoldItem = errors.New("cannot update sold item")
 	ErrItemNotFound         = errors.New("item not found")
+	ErrItemInTransaction    = errors.New("item has an active transaction")
+	ErrCategoryNotFound     = errors.New("category not found")
+)
+
+// Chat errors
+var (
+	ErrChatRoomNotFound  = errors.New("chat room not found")
+	ErrNotChatMember     = errors.New("not a member of this chat room")
+	ErrCannotChatOwnItem = errors.New("cannot open chat room on own item")
+	ErrMessageNotFound   = errors.New("message not found")
+	ErrItemNotOnSale     = errors.New("item is not on sale")
+)
+
+// Order errors
+var (
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrNotOrderParticipant    = errors.New("not a participant of this order")
+	ErrInvalidOrderTransition = errors.New("invalid order status transition")
+)
+
+// Offer errors
+var (
+	ErrOfferNotFound          = errors.New("offer not found")
+	ErrNotOfferParticipant    = errors.New("not a participant of this offer")
+	ErrInvalidOfferTransition = errors.New("invalid offer status transition")
+	ErrOfferAlreadyPending    = errors.New("an offer for this item is already pending")
+	ErrCannotOfferOwnItem     = errors.New("cannot make an offer on own item")
+	ErrItemReserved           = errors.New("item is reserved for another buyer")
+)
+
+// Review errors
+var (
+	ErrNotEligibleForReview = errors.New("not eligible to review this item")
+	ErrAlreadyReviewed      = errors.New("already reviewed this item")
+)
+
+// User errors
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrCannotFollowSelf = errors.New("cannot follow yourself")
+	ErrCannotBlockSelf  = errors.New("cannot block yourself")
+	ErrUserBlocked      = errors.New("user is blocked")
+	ErrUserHasOpenOrder = errors.New("user has an open order")
+	ErrHandleTaken      = errors.New("handle is already taken")
+)
+
+// Saved search errors
+var (
+	ErrSavedSearchNotFound      = errors.New("saved search not found")
+	ErrSavedSearchLimitExceeded = errors.New("too many saved searches")
 )
 
 // Validation errors
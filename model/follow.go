@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// フォロー一覧・フィードのページサイズ
+const (
+	DefaultFeedPageSize = 20
+	MaxFeedPageSize     = 100
+)
+
+// FollowUser : フォロー・フォロワー一覧の1件
+type FollowUser struct {
+	Id         string    `json:"id"`
+	Name       string    `json:"name"`
+	IconURL    string    `json:"icon_url,omitempty"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+// FollowCounts : フォロワー数とフォロー数
+type FollowCounts struct {
+	Followers int
+	Following int
+}
+
+// FollowState : フォロー・解除後の状態
+type FollowState struct {
+	UserId        string `json:"user_id"`
+	Following     bool   `json:"following"`
+	FollowerCount int    `json:"follower_count"`
+}
+
+// FeedItem : フォロー中の出品者の新着商品
+type FeedItem struct {
+	ItemSimple
+	SellerId      string    `json:"seller_id"`
+	SellerName    string    `json:"seller_name"`
+	SellerIconURL string    `json:"seller_icon_url"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// FeedPage : フィードのレスポンス (新しい順)
+// NextCursorを次のリクエストのbeforeに渡すと続きを取得できる
+type FeedPage struct {
+	Items      []FeedItem `json:"items"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+	HasMore    bool       `json:"has_more"`
+}
@@ -1,26 +1,89 @@
 package model
 
-import "time"
+import (
+	"slices"
+	"time"
+	"unicode/utf8"
+)
 
 // Status constants
 const (
-	StatusOnSale = "ON_SALE"
-	StatusSold   = "SOLD"
+	StatusOnSale    = "ON_SALE"
+	StatusSold      = "SOLD"
+	StatusWithdrawn = "WITHDRAWN" // 出品者が取り下げた (一覧・検索・おすすめに出さない)
+)
+
+// Condition constants : 商品の状態
+const (
+	ConditionNew     = "NEW"      // 新品、未使用
+	ConditionLikeNew = "LIKE_NEW" // 未使用に近い
+	ConditionGood    = "GOOD"     // 目立った傷や汚れなし
+	ConditionFair    = "FAIR"     // やや傷や汚れあり
+	ConditionPoor    = "POOR"     // 傷や汚れあり
+	ConditionBad     = "BAD"      // 全体的に状態が悪い
+)
+
+// ShippingPayer constants : 送料の負担者
+const (
+	ShippingPayerSeller = "SELLER" // 送料込み(出品者負担)
+	ShippingPayerBuyer  = "BUYER"  // 着払い(購入者負担)
+)
+
+// ShippingMethod constants : 配送方法
+const (
+	ShippingMethodUndecided = "UNDECIDED" // 未定
+	ShippingMethodAnonymous = "ANONYMOUS" // 匿名配送
+	ShippingMethodMail      = "MAIL"      // 郵便
+	ShippingMethodCourier   = "COURIER"   // 宅配便
+	ShippingMethodHand      = "HAND"      // 手渡し
+)
+
+// Sort constants : 商品一覧・検索の並び順
+const (
+	SortNewest    = "newest"     // 新着順
+	SortPriceAsc  = "price_asc"  // 価格の安い順
+	SortPriceDesc = "price_desc" // 価格の高い順
+	SortPopular   = "popular"    // いいねの多い順
+)
+
+// SearchMode constants : キーワード検索の方式
+const (
+	SearchModeKeyword  = "keyword"  // 商品名・説明文の部分一致 (既定)
+	SearchModeSemantic = "semantic" // 検索語と商品のベクトルの類似度
+	SearchModeHybrid   = "hybrid"   // 類似度とキーワードの一致度を組み合わせる
+)
+
+const MaxBrandLen = 50
+
+var (
+	itemSorts       = []string{SortNewest, SortPriceAsc, SortPriceDesc, SortPopular}
+	itemConditions  = []string{ConditionNew, ConditionLikeNew, ConditionGood, ConditionFair, ConditionPoor, ConditionBad}
+	shippingPayers  = []string{ShippingPayerSeller, ShippingPayerBuyer}
+	shippingMethods = []string{ShippingMethodUndecided, ShippingMethodAnonymous, ShippingMethodMail, ShippingMethodCourier, ShippingMethodHand}
 )
 
 type Item struct {
-	ItemId        string    `json:"id"`
-	UserId        string    `json:"user_id"`
-	Name          string    `json:"name"`
-	Price         int       `json:"price"`
-	Description   string    `json:"description,omitempty"`
-	ImageURLs     []string  `json:"image_urls"`
-	Status        string    `json:"status"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-	SellerName    string    `json:"seller_name"`
-	SellerIconURL string    `json:"seller_icon_url"`
-	Embedding     []float32 `json:"-"`
+	ItemId         string    `json:"id"`
+	UserId         string    `json:"user_id"`
+	Name           string    `json:"name"`
+	Price          int       `json:"price"`
+	PreviousPrice  int       `json:"previous_price,omitempty"` // 値下げ前の価格 (直近の価格変更が値下げの場合のみ)
+	Description    string    `json:"description,omitempty"`
+	ImageURLs      []string  `json:"image_urls"`
+	Status         string    `json:"status"`
+	CategoryId     int       `json:"category_id,omitempty"`
+	Condition      string    `json:"condition,omitempty"`
+	Brand          string    `json:"brand,omitempty"`
+	ShippingPayer  string    `json:"shipping_payer,omitempty"`
+	ShippingMethod string    `json:"shipping_method,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	SellerName     string    `json:"seller_name"`
+	SellerIconURL  string    `json:"seller_icon_url"`
+	LikeCount      int       `json:"like_count"`
+	LikedByMe      *bool     `json:"liked_by_me,omitempty"` // ログイン中のみ
+	BuyerId        string    `json:"-"`
+	Embedding      []float32 `json:"-"`
 }
 
 type ItemCreateRequest struct {
@@ -28,6 +91,8 @@ type ItemCreateRequest struct {
 	Price       int      `json:"price"`
 	Description string   `json:"description,omitempty"`
 	ImageURLs   []string `json:"image_urls"`
+	CategoryId  int      `json:"category_id,omitempty"`
+	ItemAttributes
 }
 
 type ItemUpdateRequest struct {
@@ -37,19 +102,118 @@ type ItemUpdateRequest struct {
 	Price       int      `json:"price"`
 	Description string   `json:"description,omitempty"`
 	ImageURLs   []string `json:"image_urls"`
+	CategoryId  int      `json:"category_id,omitempty"`
+	ItemAttributes
+}
+
+// ItemAttributes : 出品時に選ぶ商品の属性 (いずれも任意。指定する場合は定義済みの値に限る)
+type ItemAttributes struct {
+	Condition      string `json:"condition,omitempty"`
+	Brand          string `json:"brand,omitempty"` // 自由入力 (MaxBrandLen文字まで)
+	ShippingPayer  string `json:"shipping_payer,omitempty"`
+	ShippingMethod string `json:"shipping_method,omitempty"`
+}
+
+// ItemFilter : 商品一覧・検索の絞り込み条件
+type ItemFilter struct {
+	CategoryId       int      // 指定カテゴリ(子孫を含む)で絞り込む。0なら絞り込まない
+	CategoryIds      []int    // CategoryIdを子孫まで展開したもの (usecaseで設定し、DAOはこちらを使う)
+	ItemAttributes            // 空でない属性は完全一致で絞り込む
+	MinPrice         int      // 0なら下限なし
+	MaxPrice         int      // 0なら上限なし
+	Status           string   // ON_SALE / SOLD。空なら取り下げ済み以外すべて
+	SellerId         string   // 出品者で絞り込む
+	ItemIds          []string // 検索インデックスで選んだ候補に限定する (usecaseで設定)
+	Sort             string   // 並び順。空ならキーワード検索は一致度順、一覧は新着順
+	ViewerId         string   // 閲覧中のユーザー。空ならゲスト
+	ExcludeSellerIds []string // ViewerIdとブロック関係にある出品者を除外する (usecaseで設定)
+}
+
+// ItemSearchDocument : キーワード検索インデックスに登録する商品のテキスト
+type ItemSearchDocument struct {
+	ItemId      string
+	Name        string
+	Description string
+}
+
+// ItemListResult : 商品一覧・検索の結果 (ページングのための総数を含む)
+type ItemListResult struct {
+	Items []ItemSimple `json:"items"`
+	Total int          `json:"total"`
 }
 
 type ItemSimple struct {
-	ItemId   string `json:"id"`
-	Name     string `json:"name"`
-	Price    int    `json:"price"`
-	ImageURL string `json:"image_url"` // 配列ではなく、サムネイル1枚の文字列
-	Status   string `json:"status"`
+	ItemId    string `json:"id"`
+	Name      string `json:"name"`
+	Price     int    `json:"price"`
+	ImageURL  string `json:"image_url"` // 配列ではなく、サムネイル1枚の文字列
+	Status    string `json:"status"`
+	LikeCount int    `json:"like_count"`
+	LikedByMe *bool  `json:"liked_by_me,omitempty"` // ログイン中のみ
+}
+
+// PurchasedItem : 購入履歴の1件 (支払った金額と出品者の概要を含む)
+type PurchasedItem struct {
+	ItemId        string    `json:"id"`
+	Name          string    `json:"name"`
+	ImageURL      string    `json:"image_url"`
+	Price         int       `json:"price"` // 支払った金額
+	PurchasedAt   time.Time `json:"purchased_at"`
+	OrderId       string    `json:"order_id,omitempty"`
+	OrderStatus   string    `json:"order_status,omitempty"`
+	SellerId      string    `json:"seller_id"`
+	SellerName    string    `json:"seller_name"`
+	SellerIconURL string    `json:"seller_icon_url"`
+}
+
+// SoldItem : 販売履歴の1件 (購入者の概要を含む)
+type SoldItem struct {
+	ItemId       string    `json:"id"`
+	Name         string    `json:"name"`
+	ImageURL     string    `json:"image_url"`
+	Price        int       `json:"price"` // 売れた金額
+	SoldAt       time.Time `json:"sold_at"`
+	OrderId      string    `json:"order_id,omitempty"`
+	OrderStatus  string    `json:"order_status,omitempty"`
+	BuyerId      string    `json:"buyer_id"`
+	BuyerName    string    `json:"buyer_name"`
+	BuyerIconURL string    `json:"buyer_icon_url"`
+}
+
+// IsValid : 指定された属性が定義済みの値かを検証 (未指定は許可)
+func (a *ItemAttributes) IsValid() bool {
+	if a.Condition != "" && !slices.Contains(itemConditions, a.Condition) {
+		return false
+	}
+	if a.ShippingPayer != "" && !slices.Contains(shippingPayers, a.ShippingPayer) {
+		return false
+	}
+	if a.ShippingMethod != "" && !slices.Contains(shippingMethods, a.ShippingMethod) {
+		return false
+	}
+	return utf8.RuneCountInString(a.Brand) <= MaxBrandLen
+}
+
+// IsValid : 絞り込み条件のバリデーション
+func (f *ItemFilter) IsValid() bool {
+	if !f.ItemAttributes.IsValid() {
+		return false
+	}
+	if f.MinPrice < 0 || f.MaxPrice < 0 {
+		return false
+	}
+	if f.MinPrice > 0 && f.MaxPrice > 0 && f.MinPrice > f.MaxPrice {
+		return false
+	}
+	if f.Status != "" && f.Status != StatusOnSale && f.Status != StatusSold {
+		return false
+	}
+	return f.Sort == "" || slices.Contains(itemSorts, f.Sort)
 }
 
 // IsValid バリデーション
 func (req *ItemCreateRequest) IsValid() bool {
-	return req.Name != "" && req.Price >= 0 && 10 > len(req.ImageURLs) && len(req.ImageURLs) > 0
+	return req.Name != "" && req.Price >= 0 && 10 > len(req.ImageURLs) && len(req.ImageURLs) > 0 && req.ItemAttributes.IsValid()
 }
 
 // IsValid バリデーション
@@ -66,5 +230,5 @@ func (req *ItemUpdateRequest) IsValid() bool {
 	if req.Price <= 0 {
 		return false
 	}
-	return true
+	return req.ItemAttributes.IsValid()
 }
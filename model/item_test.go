@@ -0,0 +1,47 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestItemCreateRequest_IsValid : 出品リクエストのバリデーションtest
+func TestItemCreateRequest_IsValid(t *testing.T) {
+	base := func() ItemCreateRequest {
+		return ItemCreateRequest{Name: "Tシャツ", Price: 1000, ImageURLs: []string{"http://example.com/1.jpg"}}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(req *ItemCreateRequest)
+		want   bool
+	}{
+		{name: "成功: 属性なし", modify: func(req *ItemCreateRequest) {}, want: true},
+		{
+			name: "成功: 全属性を指定",
+			modify: func(req *ItemCreateRequest) {
+				req.Condition = ConditionLikeNew
+				req.Brand = "ブランド"
+				req.ShippingPayer = ShippingPayerSeller
+				req.ShippingMethod = ShippingMethodAnonymous
+			},
+			want: true,
+		},
+		{name: "失敗: 未定義の状態", modify: func(req *ItemCreateRequest) { req.Condition = "BRAND_NEW" }, want: false},
+		{name: "失敗: 未定義の送料負担者", modify: func(req *ItemCreateRequest) { req.ShippingPayer = "FREE" }, want: false},
+		{name: "失敗: 未定義の配送方法", modify: func(req *ItemCreateRequest) { req.ShippingMethod = "DRONE" }, want: false},
+		{name: "成功: ブランド名が上限ちょうど", modify: func(req *ItemCreateRequest) { req.Brand = strings.Repeat("あ", MaxBrandLen) }, want: true},
+		{name: "失敗: ブランド名が長すぎる", modify: func(req *ItemCreateRequest) { req.Brand = strings.Repeat("あ", MaxBrandLen+1) }, want: false},
+		{name: "失敗: 画像なし", modify: func(req *ItemCreateRequest) { req.ImageURLs = nil }, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := base()
+			tc.modify(&req)
+			if got := req.IsValid(); got != tc.want {
+				t.Errorf("IsValid() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
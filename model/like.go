@@ -8,3 +8,10 @@ type Like struct {
 	ItemID    string    `json:"item_id"`
 	CreatedAt time.Time `json:"created_at"`
 }
+
+// LikeState : いいねの登録・解除後の状態
+type LikeState struct {
+	ItemID    string `json:"item_id"`
+	Liked     bool   `json:"liked"`
+	LikeCount int    `json:"like_count"`
+}
@@ -1,15 +1,40 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+// 通知の種類
+const (
+	NotificationTypePurchase    = "purchase"
+	NotificationTypeComment     = "comment"
+	NotificationTypeOrder       = "order"
+	NotificationTypeReview      = "review"
+	NotificationTypeOffer       = "offer"
+	NotificationTypeWithdraw    = "withdraw"
+	NotificationTypeSavedSearch = "saved_search"
+	NotificationTypePriceDrop   = "price_drop"
+	NotificationTypeNewListing  = "new_listing"
+	NotificationTypeMention     = "mention"
+)
 
 // Notification : 通知
 type Notification struct {
-	Id        string    `json:"id"`
-	UserId    string    `json:"user_id"`
-	Type      string    `json:"type"` // "purchase" or "comment"
-	ItemId    string    `json:"item_id"`
-	ItemName  string    `json:"item_name"`
-	Message   string    `json:"message"`
-	IsRead    bool      `json:"is_read"`
-	CreatedAt time.Time `json:"created_at"`
+	Id         string          `json:"id"`
+	UserId     string          `json:"user_id"`
+	Type       string          `json:"type"` // "purchase", "comment", "order", "review", "offer", "withdraw", "saved_search", "price_drop", "new_listing", "mention"
+	ItemId     string          `json:"item_id"`
+	ItemName   string          `json:"item_name"`
+	ChatRoomId string          `json:"chat_room_id,omitempty"` // commentのみ。ルームを開いたときにそのルームの通知だけ既読にする
+	Message    string          `json:"message"`
+	Payload    json.RawMessage `json:"payload,omitempty"` // 種類ごとの追加情報 (price_dropはPriceDropPayload)
+	IsRead     bool            `json:"is_read"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// PriceDropPayload : 値下げ通知の追加情報
+type PriceDropPayload struct {
+	OldPrice int `json:"old_price"`
+	NewPrice int `json:"new_price"`
 }
@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// Offer status
+const (
+	OfferStatusPending   = "PENDING"   // 相手の回答待ち (ProposedByが最後に金額を提示した側)
+	OfferStatusAccepted  = "ACCEPTED"  // 合意済み (ExpiresAtまで購入者のために取り置き)
+	OfferStatusRejected  = "REJECTED"  // 拒否
+	OfferStatusPurchased = "PURCHASED" // 合意した金額で購入済み
+)
+
+// Offer response action
+const (
+	OfferActionAccept  = "accept"
+	OfferActionReject  = "reject"
+	OfferActionCounter = "counter"
+)
+
+// OfferReservationWindow : 合意後に購入者のために商品を取り置く期間
+const OfferReservationWindow = 24 * time.Hour
+
+// Offer : 価格交渉 (購入希望者が提示し、相手が承諾・拒否・再提示する)
+type Offer struct {
+	Id         string     `json:"id"`
+	ItemId     string     `json:"item_id"`
+	ChatRoomId string     `json:"chat_room_id"`
+	BuyerId    string     `json:"buyer_id"`
+	SellerId   string     `json:"seller_id"`
+	Price      int        `json:"price"`
+	ProposedBy string     `json:"proposed_by"`
+	Status     string     `json:"status"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// IsParticipant : 指定ユーザーが交渉の当事者かどうか
+func (o *Offer) IsParticipant(userID string) bool {
+	return userID != "" && (userID == o.BuyerId || userID == o.SellerId)
+}
+
+// IsReserving : 合意済みで取り置き期間内かどうか
+func (o *Offer) IsReserving(now time.Time) bool {
+	return o.Status == OfferStatusAccepted && o.ExpiresAt != nil && now.Before(*o.ExpiresAt)
+}
+
+type OfferCreateRequest struct {
+	Price int `json:"price"`
+}
+
+// IsValid バリデーション
+func (req *OfferCreateRequest) IsValid() bool {
+	return req.Price > 0
+}
+
+type OfferRespondRequest struct {
+	Price int `json:"price"` // counterの場合のみ使用
+}
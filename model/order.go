@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Order status constants (取引の状態)
+const (
+	OrderStatusAwaitingPayment = "AWAITING_PAYMENT"
+	OrderStatusPaid            = "PAID"
+	OrderStatusShipped         = "SHIPPED"
+	OrderStatusReceived        = "RECEIVED"
+	OrderStatusCompleted       = "COMPLETED"
+	OrderStatusCancelled       = "CANCELLED"
+)
+
+// Order actions (状態遷移のトリガー)
+const (
+	OrderActionPay      = "pay"
+	OrderActionShip     = "ship"
+	OrderActionReceive  = "receive"
+	OrderActionComplete = "complete"
+	OrderActionCancel   = "cancel"
+)
+
+// Order : 購入から取引完了までを管理する取引
+type Order struct {
+	Id        string    `json:"id"`
+	ItemId    string    `json:"item_id"`
+	ItemName  string    `json:"item_name"`
+	BuyerId   string    `json:"buyer_id"`
+	SellerId  string    `json:"seller_id"`
+	Price     int       `json:"price"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IsParticipant : 指定ユーザーが取引の当事者かどうか
+func (o *Order) IsParticipant(userID string) bool {
+	return userID != "" && (userID == o.BuyerId || userID == o.SellerId)
+}
+
+// IsActive : 完了・キャンセル以外の進行中の取引かどうか
+func (o *Order) IsActive() bool {
+	return o.Status != OrderStatusCompleted && o.Status != OrderStatusCancelled
+}
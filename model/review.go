@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// Review rule
+const (
+	MinRating           = 1
+	MaxRating           = 5
+	MaxReviewCommentLen = 500
+)
+
+// Reviewer roles (評価者の立場)
+const (
+	ReviewerRoleBuyer  = "buyer"
+	ReviewerRoleSeller = "seller"
+)
+
+// Review : 完了した取引の評価 (購入者・出品者がそれぞれ1回ずつ相手を評価する)
+type Review struct {
+	Id              string    `json:"id"`
+	OrderId         string    `json:"order_id"`
+	ItemId          string    `json:"item_id"`
+	ReviewerId      string    `json:"reviewer_id"`
+	RevieweeId      string    `json:"reviewee_id"`
+	ReviewerRole    string    `json:"reviewer_role"` // ReviewerRoleBuyer or ReviewerRoleSeller
+	Rating          int       `json:"rating"`
+	Comment         string    `json:"comment,omitempty"`
+	ReviewerName    string    `json:"reviewer_name,omitempty"`
+	ReviewerIconURL string    `json:"reviewer_icon_url,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// RatingSummary : ユーザーが受けた評価の集計
+type RatingSummary struct {
+	Average float64 `json:"rating_average"`
+	Count   int     `json:"review_count"`
+}
+
+type ReviewCreateRequest struct {
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment"`
+}
+
+// IsValid バリデーション
+func (req *ReviewCreateRequest) IsValid() bool {
+	if req.Rating < MinRating || req.Rating > MaxRating {
+		return false
+	}
+	return len([]rune(req.Comment)) <= MaxReviewCommentLen
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MaxSavedSearchesPerUser  = 20
+	MaxSavedSearchKeywordLen = 100
+)
+
+// SavedSearch : 保存した検索条件 (条件に一致する商品が出品されると通知する)
+type SavedSearch struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	Keyword   string    `json:"keyword"`
+	MinPrice  int       `json:"min_price,omitempty"` // 0なら下限なし
+	MaxPrice  int       `json:"max_price,omitempty"` // 0なら上限なし
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SavedSearchRequest : 検索条件の保存・更新リクエスト
+type SavedSearchRequest struct {
+	Keyword  string `json:"keyword"`
+	MinPrice int    `json:"min_price,omitempty"`
+	MaxPrice int    `json:"max_price,omitempty"`
+}
+
+// IsValid バリデーション (キーワードは必須)
+func (req *SavedSearchRequest) IsValid() bool {
+	keyword := strings.TrimSpace(req.Keyword)
+	if keyword == "" || utf8.RuneCountInString(keyword) > MaxSavedSearchKeywordLen {
+		return false
+	}
+	if req.MinPrice < 0 || req.MaxPrice < 0 {
+		return false
+	}
+	if req.MinPrice > 0 && req.MaxPrice > 0 && req.MinPrice > req.MaxPrice {
+		return false
+	}
+	return true
+}
+
+// MatchesPrice : 価格が保存した価格帯に収まるか
+func (s *SavedSearch) MatchesPrice(price int) bool {
+	if s.MinPrice > 0 && price < s.MinPrice {
+		return false
+	}
+	if s.MaxPrice > 0 && price > s.MaxPrice {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,10 @@
+package model
+
+// MaxSearchQueryLen : 検索履歴に記録する検索語の最大文字数 (これより長いものは記録しない)
+const MaxSearchQueryLen = 100
+
+// PopularQuery : 集計期間内によく検索された検索語
+type PopularQuery struct {
+	Query string // 最も多く使われた表記
+	Count int
+}
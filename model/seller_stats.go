@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// SellerStatsInquiryLimit : 返信率・返信時間の集計に使う直近の問い合わせ(チャットルーム)の件数
+const SellerStatsInquiryLimit = 100
+
+// SellerStats : 出品者としての実績 (GET /users/{id} でプロフィールと一緒に返す)
+// 集計対象のデータがない項目はnull
+type SellerStats struct {
+	ListingCount          int       `json:"listing_count"`            // 出品数 (取り下げ済みを除く)
+	SoldCount             int       `json:"sold_count"`               // 売れた数
+	AvgTimeToSellSeconds  *int64    `json:"avg_time_to_sell_seconds"` // 出品から購入までの平均時間
+	ResponseRate          *float64  `json:"response_rate"`            // 問い合わせに返信した割合 (0〜1)
+	MedianResponseSeconds *int64    `json:"median_response_seconds"`  // 問い合わせから最初の返信までの時間の中央値
+	MemberSince           time.Time `json:"member_since"`
+}
+
+// SellerItemStats : 出品者の商品の集計
+type SellerItemStats struct {
+	ListingCount         int
+	SoldCount            int
+	AvgTimeToSellSeconds *float64 // 売れた商品がなければnil
+}
+
+// SellerInquiry : 出品者が受けた問い合わせ (チャットルームごとの購入希望者の最初のメッセージとそれへの最初の返信)
+type SellerInquiry struct {
+	ChatRoomId   string
+	InquiredAt   time.Time
+	FirstReplyAt *time.Time // 未返信ならnil
+}
@@ -1,45 +1,125 @@
 package model
 
-import "time"
+import (
+	"regexp"
+	"strings"
+	"time"
+)
 
 // MaxNameLen definition of user rule
 const MaxNameLen = 50
 const MaxBioLen = 500
 
+// ハンドル (@tanaka_shop) は英小文字・数字・アンダースコアのみ。大文字は小文字にそろえる
+const MinHandleLen = 3
+const MaxHandleLen = 20
+
+// MaxMentions : 1つの商品説明で通知するメンションの上限
+const MaxMentions = 10
+
+var handlePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// mentionPattern : 本文中の@ハンドル (メールアドレスの@は直前が英数字・ドットなので除く)
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_.@])@([A-Za-z0-9_]+)`)
+
+// reservedHandles : 運営と紛らわしいもの・/users/ 以下のパスと衝突するものは使えない
+var reservedHandles = map[string]bool{
+	"admin": true, "administrator": true, "root": true, "system": true, "support": true,
+	"help": true, "official": true, "staff": true, "moderator": true, "api": true,
+	"me": true, "user": true, "users": true, "items": true, "reviews": true,
+	"follow": true, "followers": true, "following": true, "block": true, "blocks": true,
+	"export": true, "register": true, "null": true, "undefined": true,
+}
+
+// NormalizeHandle : 先頭の@と前後の空白を除き、小文字にそろえる
+func NormalizeHandle(handle string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+}
+
+// IsValidHandle : 正規化済みのハンドルが使えるか (長さ・文字種・予約語)
+func IsValidHandle(handle string) bool {
+	if len(handle) < MinHandleLen || len(handle) > MaxHandleLen {
+		return false
+	}
+	return handlePattern.MatchString(handle) && !reservedHandles[handle]
+}
+
+// ExtractMentions : 本文中の@ハンドルを出現順に重複なく返す (正規化済み、使えないハンドルは除く。最大MaxMentions件)
+func ExtractMentions(text string) []string {
+	handles := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		handle := NormalizeHandle(m[1])
+		if seen[handle] || !IsValidHandle(handle) {
+			continue
+		}
+		seen[handle] = true
+		handles = append(handles, handle)
+		if len(handles) == MaxMentions {
+			break
+		}
+	}
+	return handles
+}
+
+// DeletedUserName : 退会したユーザーの表示名 (取引やチャットの相手として表示される)
+const DeletedUserName = "退会したユーザー"
+
 // User struct and method used for operation related to user
 type User struct {
 	Id        string    `json:"id"`
 	Name      string    `json:"name"`
+	Handle    string    `json:"handle,omitempty"` // 未設定なら空
 	Age       int       `json:"age"`
 	Email     string    `json:"email,omitempty"`
 	Bio       string    `json:"bio,omitempty"`
 	IconURL   string    `json:"icon_url,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// 受けた評価の集計 (GET /users/{id} で返す)
+	RatingAverage float64 `json:"rating_average"`
+	ReviewCount   int     `json:"review_count"`
+
+	// フォローの集計 (GET /users/{id} で返す)
+	FollowerCount  int `json:"follower_count"`
+	FollowingCount int `json:"following_count"`
+
+	// 出品者としての実績 (GET /users/{id} で返す)
+	SellerStats *SellerStats `json:"seller_stats,omitempty"`
 }
 
 type UserCreateRequest struct {
 	Name    string `json:"name"`
+	Handle  string `json:"handle"` // 任意
 	Age     int    `json:"age"`
 	Email   string `json:"email"`
 	IconURL string `json:"icon_url"`
 }
 
 func (req *UserCreateRequest) IsValid() bool {
+	if req.Handle != "" && !IsValidHandle(req.Handle) {
+		return false
+	}
 	return req.Name != "" && len(req.Name) <= MaxNameLen
 }
 
 type UserUpdateRequest struct {
-	Name    string `json:"name"`
-	Age     int    `json:"age"`
-	Bio     string `json:"bio"`
-	IconURL string `json:"icon_url"`
+	Name        string `json:"name"`
+	Handle      string `json:"handle"`       // 空なら変更しない
+	ClearHandle bool   `json:"clear_handle"` // trueならハンドルを削除する (handleと同時には指定できない)
+	Age         int    `json:"age"`
+	Bio         string `json:"bio"`
+	IconURL     string `json:"icon_url"`
 }
 
 func (req *UserUpdateRequest) IsValid() bool {
 	if req.Name == "" || len(req.Name) > MaxNameLen {
 		return false
 	}
+	if req.Handle != "" && (req.ClearHandle || !IsValidHandle(req.Handle)) {
+		return false
+	}
 	// 年齢は-1（未設定）or 1-150の範囲
 	if req.Age < -1 || req.Age > 150 {
 		return false
@@ -49,3 +129,13 @@ func (req *UserUpdateRequest) IsValid() bool {
 	}
 	return true
 }
+
+// UserExport : 個人データのエクスポート (GET /users/me/export)
+type UserExport struct {
+	ExportedAt    time.Time      `json:"exported_at"`
+	Profile       *User          `json:"profile"`
+	Items         []ItemSimple   `json:"items"`
+	Likes         []ItemSimple   `json:"likes"`
+	Messages      []Message      `json:"messages"`
+	Notifications []Notification `json:"notifications"`
+}
@@ -1,6 +1,9 @@
 package model
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 // TestUserCreateRequest_IsValid : ユーザー作成リクエストのバリデーションtest
 func TestUserCreateRequest_IsValid(t *testing.T) {
@@ -39,6 +42,16 @@ func TestUserCreateRequest_IsValid(t *testing.T) {
 			req:  UserCreateRequest{Name: "yuu", Age: 25, Email: ""},
 			want: true,
 		},
+		{
+			name: "成功: ハンドルあり",
+			req:  UserCreateRequest{Name: "Taro", Handle: "tanaka_shop"},
+			want: true,
+		},
+		{
+			name: "失敗: ハンドルが予約語",
+			req:  UserCreateRequest{Name: "Taro", Handle: "admin"},
+			want: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -50,3 +63,69 @@ func TestUserCreateRequest_IsValid(t *testing.T) {
 		})
 	}
 }
+
+// TestIsValidHandle : ハンドルの正規化とバリデーションtest
+func TestIsValidHandle(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "成功: 英小文字・数字・アンダースコア", input: "tanaka_shop2", want: true},
+		{name: "成功: 先頭の@と大文字は正規化する", input: "@Tanaka_Shop", want: true},
+		{name: "成功: 最短 (MinHandleLen=3)", input: "abc", want: true},
+		{name: "失敗: 短すぎる", input: "ab", want: false},
+		{name: "失敗: 長すぎる (MaxHandleLen=20)", input: "abcdefghijklmnopqrstu", want: false},
+		{name: "失敗: 使えない文字 (ハイフン)", input: "tanaka-shop", want: false},
+		{name: "失敗: 使えない文字 (日本語)", input: "たなか", want: false},
+		{name: "失敗: 予約語", input: "me", want: false},
+		{name: "失敗: 予約語 (パスと衝突)", input: "Followers", want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsValidHandle(NormalizeHandle(tc.input)); got != tc.want {
+				t.Errorf("IsValidHandle(%q) = %v, want %v", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestExtractMentions : 本文からのメンション抽出test
+func TestExtractMentions(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "成功: 文頭と文中", text: "@tanaka_shop さんと@Sato_Taroさんの出品です", want: []string{"tanaka_shop", "sato_taro"}},
+		{name: "成功: 重複は1件にまとめる", text: "@tanaka_shop @TANAKA_SHOP", want: []string{"tanaka_shop"}},
+		{name: "成功: メールアドレスは除く", text: "連絡先 taro@example.com", want: []string{}},
+		{name: "成功: 使えないハンドルは除く", text: "@ab @admin @abcdefghijklmnopqrstu", want: []string{}},
+		{name: "成功: メンションなし", text: "美品です", want: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ExtractMentions(tc.text)
+			if len(got) != len(tc.want) {
+				t.Fatalf("ExtractMentions() = %v, want %v", got, tc.want)
+			}
+			for i := range tc.want {
+				if got[i] != tc.want[i] {
+					t.Errorf("ExtractMentions() = %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+
+	t.Run("成功: 上限 (MaxMentions=10) を超えた分は除く", func(t *testing.T) {
+		text := ""
+		for i := 0; i < MaxMentions+5; i++ {
+			text += fmt.Sprintf("@user_%02d ", i)
+		}
+		if got := ExtractMentions(text); len(got) != MaxMentions {
+			t.Errorf("len(ExtractMentions()) = %d, want %d", len(got), MaxMentions)
+		}
+	})
+}
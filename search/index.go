@@ -0,0 +1,248 @@
+package search
+
+import (
+	"context"
+	"db/model"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// BM25のパラメータ
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// nameBoost : 商品名に含まれる語を説明文の何倍として数えるか
+	nameBoost = 3.0
+)
+
+// Hit : 検索結果の1件
+type Hit struct {
+	ItemId string
+	Score  float64
+}
+
+// Index : 商品のキーワード検索インデックス
+// 単一プロセス構成ではインメモリの転置インデックス(NewInvertedIndex)を使い、
+// 複数インスタンス構成にする場合は外部の検索エンジンを使った実装に差し替える
+type Index interface {
+	// Upsert : 商品を登録する (登録済みなら置き換える)
+	Upsert(doc model.ItemSearchDocument)
+	// Remove : 商品をインデックスから外す
+	Remove(itemID string)
+	// Search : 検索語のトークンをすべて含む商品を関連度の高い順に最大limit件返す
+	Search(ctx context.Context, query string, limit int) ([]Hit, error)
+}
+
+// DocumentLoader : インデックスの構築に使う商品テキストの読み込み元 (dao.ItemDAOが満たす)
+type DocumentLoader interface {
+	GetSearchDocuments(ctx context.Context) ([]model.ItemSearchDocument, error)
+}
+
+// posting : ある語の、ある商品での出現回数
+type posting struct {
+	nameTF int
+	descTF int
+}
+
+// docStats : 商品ごとの長さ(トークン数)と登録した語の一覧 (削除時に使う)
+type docStats struct {
+	nameLen int
+	descLen int
+	terms   []string
+}
+
+// invertedIndex : 文字bigramの転置インデックスとBM25によるIndexの実装
+type invertedIndex struct {
+	mu           sync.RWMutex
+	postings     map[string]map[string]posting // 語 -> 商品ID -> 出現回数
+	docs         map[string]docStats
+	totalNameLen int
+	totalDescLen int
+	loader       DocumentLoader
+}
+
+// NewInvertedIndex : インデックスの初期化と自動ロード
+func NewInvertedIndex(loader DocumentLoader) Index {
+	idx := &invertedIndex{
+		postings: make(map[string]map[string]posting),
+		docs:     make(map[string]docStats),
+		loader:   loader,
+	}
+
+	// 起動時に一度ロード
+	if err := idx.Reload(context.Background()); err != nil {
+		log.Printf("Warning: failed to load search index on startup: %v", err)
+	}
+
+	return idx
+}
+
+// Reload : DBから全商品を読み込んでインデックスを作り直す
+func (idx *invertedIndex) Reload(ctx context.Context) error {
+	start := time.Now()
+
+	docs, err := idx.loader.GetSearchDocuments(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load search documents: %w", err)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.postings = make(map[string]map[string]posting)
+	idx.docs = make(map[string]docStats)
+	idx.totalNameLen = 0
+	idx.totalDescLen = 0
+	for _, doc := range docs {
+		idx.add(doc)
+	}
+
+	log.Printf("Search index reloaded: %d items in %v", len(docs), time.Since(start))
+	return nil
+}
+
+// Upsert : 商品を登録する (登録済みなら置き換える)
+func (idx *invertedIndex) Upsert(doc model.ItemSearchDocument) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(doc.ItemId)
+	idx.add(doc)
+}
+
+// Remove : 商品をインデックスから外す
+func (idx *invertedIndex) Remove(itemID string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(itemID)
+}
+
+// add : 商品の語を登録する (呼び出し側でロックを取る)
+func (idx *invertedIndex) add(doc model.ItemSearchDocument) {
+	nameTokens := tokenize(doc.Name, true)
+	descTokens := tokenize(doc.Description, true)
+
+	tfs := make(map[string]posting)
+	for _, t := range nameTokens {
+		p := tfs[t]
+		p.nameTF++
+		tfs[t] = p
+	}
+	for _, t := range descTokens {
+		p := tfs[t]
+		p.descTF++
+		tfs[t] = p
+	}
+
+	terms := make([]string, 0, len(tfs))
+	for t, p := range tfs {
+		if idx.postings[t] == nil {
+			idx.postings[t] = make(map[string]posting)
+		}
+		idx.postings[t][doc.ItemId] = p
+		terms = append(terms, t)
+	}
+
+	idx.docs[doc.ItemId] = docStats{nameLen: len(nameTokens), descLen: len(descTokens), terms: terms}
+	idx.totalNameLen += len(nameTokens)
+	idx.totalDescLen += len(descTokens)
+}
+
+// remove : 商品の語を削除する (呼び出し側でロックを取る)
+func (idx *invertedIndex) remove(itemID string) {
+	stats, ok := idx.docs[itemID]
+	if !ok {
+		return
+	}
+
+	for _, t := range stats.terms {
+		delete(idx.postings[t], itemID)
+		if len(idx.postings[t]) == 0 {
+			delete(idx.postings, t)
+		}
+	}
+	idx.totalNameLen -= stats.nameLen
+	idx.totalDescLen -= stats.descLen
+	delete(idx.docs, itemID)
+}
+
+// Search : 検索語のトークンをすべて含む商品をBM25のスコア順に返す
+// 商品名と説明文は、商品名の出現回数と長さをnameBoost倍して1つの文書として扱う
+func (idx *invertedIndex) Search(ctx context.Context, query string, limit int) ([]Hit, error) {
+	terms := uniqueTokens(tokenize(query, false))
+	if len(terms) == 0 {
+		return []Hit{}, nil
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	// 出現する商品が少ない語から順に絞り込む (すべての語を含む商品のみ対象)
+	sort.Slice(terms, func(i, j int) bool {
+		return len(idx.postings[terms[i]]) < len(idx.postings[terms[j]])
+	})
+	candidates := make(map[string]bool)
+	for id := range idx.postings[terms[0]] {
+		candidates[id] = true
+	}
+	for _, t := range terms[1:] {
+		for id := range candidates {
+			if _, ok := idx.postings[t][id]; !ok {
+				delete(candidates, id)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return []Hit{}, nil
+	}
+
+	n := float64(len(idx.docs))
+	avgLen := (nameBoost*float64(idx.totalNameLen) + float64(idx.totalDescLen)) / n
+
+	hits := make([]Hit, 0, len(candidates))
+	for id := range candidates {
+		stats := idx.docs[id]
+		docLen := nameBoost*float64(stats.nameLen) + float64(stats.descLen)
+
+		var score float64
+		for _, t := range terms {
+			df := float64(len(idx.postings[t]))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+			p := idx.postings[t][id]
+			tf := nameBoost*float64(p.nameTF) + float64(p.descTF)
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*docLen/avgLen))
+		}
+		hits = append(hits, Hit{ItemId: id, Score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ItemId > hits[j].ItemId // 同点はIDの降順 (ULIDなので新しい順)
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+// uniqueTokens : 重複を除いたトークン列を返す
+func uniqueTokens(tokens []string) []string {
+	seen := make(map[string]bool, len(tokens))
+	result := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if !seen[t] {
+			seen[t] = true
+			result = append(result, t)
+		}
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+package search
+
+import (
+	"context"
+	"db/model"
+	"slices"
+	"testing"
+)
+
+// stubLoader : DocumentLoader のスタブ
+type stubLoader struct {
+	docs []model.ItemSearchDocument
+}
+
+func (l *stubLoader) GetSearchDocuments(ctx context.Context) ([]model.ItemSearchDocument, error) {
+	return l.docs, nil
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "成功: カタカナはひらがなに", in: "ジャケット", want: "じゃけっと"},
+		{name: "成功: 半角カナは全角にしてからひらがなに", in: "ｼﾞｬｹｯﾄ", want: "じゃけっと"},
+		{name: "成功: 全角英数は半角小文字に", in: "ＮＩＫＥ　２０２４", want: "nike 2024"},
+		{name: "成功: 長音符は残す", in: "コート", want: "こーと"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.in); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name         string
+		in           string
+		withUnigrams bool
+		want         []string
+	}{
+		{name: "成功: 文字bigram", in: "冬コート", want: []string{"冬こ", "こー", "ーと"}},
+		{name: "成功: 記号と空白で区切る", in: "靴・ab", want: []string{"靴", "ab"}},
+		{name: "成功: 登録時は1文字も含める", in: "靴下", withUnigrams: true, want: []string{"靴", "下", "靴下"}},
+		{name: "成功: 空文字", in: "", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenize(tt.in, tt.withUnigrams); !slices.Equal(got, tt.want) {
+				t.Errorf("tokenize(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvertedIndex_Search(t *testing.T) {
+	idx := NewInvertedIndex(&stubLoader{docs: []model.ItemSearchDocument{
+		{ItemId: "01", Name: "ダウンジャケット", Description: "冬用の暖かい上着です"},
+		{ItemId: "02", Name: "レザーコート", Description: "ジャケットにも合わせやすい"},
+		{ItemId: "03", Name: "スニーカー", Description: "ほぼ未使用の靴です"},
+		{ItemId: "04", Name: "靴下セット", Description: ""},
+	}})
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "成功: 商品名の一致を説明文より上位に", query: "ジャケット", want: []string{"01", "02"}},
+		{name: "成功: ひらがなでカタカナに一致", query: "じゃけっと", want: []string{"01", "02"}},
+		{name: "成功: 半角カナで一致", query: "ｽﾆｰｶｰ", want: []string{"03"}},
+		{name: "成功: 1文字の検索語", query: "靴", want: []string{"04", "03"}},
+		{name: "成功: すべての語を含む商品のみ", query: "冬 ジャケット", want: []string{"01"}},
+		{name: "成功: 一致なし", query: "テレビ", want: []string{}},
+		{name: "成功: 記号のみ", query: "!!", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := idx.Search(context.Background(), tt.query, 10)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			got := make([]string, len(hits))
+			for i, h := range hits {
+				got[i] = h.ItemId
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvertedIndex_UpsertRemove(t *testing.T) {
+	idx := NewInvertedIndex(&stubLoader{})
+
+	idx.Upsert(model.ItemSearchDocument{ItemId: "01", Name: "トートバッグ"})
+	if hits, _ := idx.Search(context.Background(), "バッグ", 10); len(hits) != 1 {
+		t.Fatalf("Search() after Upsert = %v, want 1 hit", hits)
+	}
+
+	// 更新すると古い語では見つからない
+	idx.Upsert(model.ItemSearchDocument{ItemId: "01", Name: "リュック"})
+	if hits, _ := idx.Search(context.Background(), "バッグ", 10); len(hits) != 0 {
+		t.Errorf("Search() old name after update = %v, want none", hits)
+	}
+	if hits, _ := idx.Search(context.Background(), "リュック", 10); len(hits) != 1 {
+		t.Errorf("Search() new name after update = %v, want 1 hit", hits)
+	}
+
+	idx.Remove("01")
+	if hits, _ := idx.Search(context.Background(), "リュック", 10); len(hits) != 0 {
+		t.Errorf("Search() after Remove = %v, want none", hits)
+	}
+}
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// maxSuggestions : 1回の補完で返す候補の上限 (各ノードに保持する上位件数)
+const maxSuggestions = 10
+
+// Suggestion : 補完候補の元になるテキストと重み (出品数や検索回数)
+type Suggestion struct {
+	Text   string
+	Weight float64
+}
+
+// Suggester : 検索語の前方一致補完
+type Suggester interface {
+	// Rebuild : 候補を入れ替える (検索中の呼び出しとは並行に実行できる)
+	Rebuild(suggestions []Suggestion)
+	// Suggest : 正規化した上でprefixから始まる候補を重みの大きい順に最大limit件返す
+	Suggest(prefix string, limit int) []string
+}
+
+// trieNode : 正規化した文字ごとの木。各ノードに部分木内の上位候補を持たせて検索を定数時間にする
+type trieNode struct {
+	children map[rune]*trieNode
+	entry    *suggestionEntry
+	top      []*suggestionEntry
+}
+
+// suggestionEntry : 正規化すると同じになる候補をまとめたもの
+type suggestionEntry struct {
+	text       string  // 表示する文字列 (最も重みの大きかった表記)
+	textWeight float64 // textの表記の重み
+	weight     float64 // 同じ候補の重みの合計
+}
+
+// trieSuggester : トライ木によるSuggesterの実装。再構築した木を丸ごと差し替える
+type trieSuggester struct {
+	root atomic.Pointer[trieNode]
+}
+
+// NewTrieSuggester : 空のSuggesterを生成 (候補はRebuildで登録する)
+func NewTrieSuggester() Suggester {
+	s := &trieSuggester{}
+	s.root.Store(newTrieNode())
+	return s
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: make(map[rune]*trieNode)}
+}
+
+// Rebuild : 候補から木を作り直して差し替える
+func (s *trieSuggester) Rebuild(suggestions []Suggestion) {
+	root := newTrieNode()
+
+	for _, sg := range suggestions {
+		text := strings.TrimSpace(sg.Text)
+		key := Normalize(text)
+		if key == "" || sg.Weight <= 0 {
+			continue
+		}
+
+		node := root
+		for _, r := range key {
+			child, ok := node.children[r]
+			if !ok {
+				child = newTrieNode()
+				node.children[r] = child
+			}
+			node = child
+		}
+
+		if node.entry == nil {
+			node.entry = &suggestionEntry{}
+		}
+		node.entry.weight += sg.Weight
+		if sg.Weight > node.entry.textWeight {
+			node.entry.text = text
+			node.entry.textWeight = sg.Weight
+		}
+	}
+
+	collectTop(root)
+	s.root.Store(root)
+}
+
+// collectTop : 子の上位候補と自身の候補から、部分木の上位候補を求める (帰りがけ順)
+func collectTop(node *trieNode) {
+	candidates := make([]*suggestionEntry, 0)
+	if node.entry != nil {
+		candidates = append(candidates, node.entry)
+	}
+	for _, child := range node.children {
+		collectTop(child)
+		candidates = append(candidates, child.top...)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].weight != candidates[j].weight {
+			return candidates[i].weight > candidates[j].weight
+		}
+		return candidates[i].text < candidates[j].text
+	})
+	if len(candidates) > maxSuggestions {
+		candidates = candidates[:maxSuggestions]
+	}
+	node.top = candidates
+}
+
+// Suggest : prefixから始まる候補を重みの大きい順に返す
+func (s *trieSuggester) Suggest(prefix string, limit int) []string {
+	key := Normalize(strings.TrimSpace(prefix))
+	if key == "" {
+		return []string{}
+	}
+
+	node := s.root.Load()
+	for _, r := range key {
+		child, ok := node.children[r]
+		if !ok {
+			return []string{}
+		}
+		node = child
+	}
+
+	if limit <= 0 || limit > len(node.top) {
+		limit = len(node.top)
+	}
+	result := make([]string, limit)
+	for i := 0; i < limit; i++ {
+		result[i] = node.top[i].text
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package search
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTrieSuggester_Suggest(t *testing.T) {
+	s := NewTrieSuggester()
+	s.Rebuild([]Suggestion{
+		{Text: "ジャケット", Weight: 5},
+		{Text: "じゃけっと", Weight: 1}, // 正規化すると同じ → 重みを合算し、重い方の表記を使う
+		{Text: "ジャケット メンズ", Weight: 3},
+		{Text: "ジャンパー", Weight: 4},
+		{Text: "Nike エアマックス", Weight: 2},
+		{Text: "  ", Weight: 10}, // 空は無視
+	})
+
+	tests := []struct {
+		name   string
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{name: "成功: 重みの大きい順", prefix: "ジャ", limit: 10, want: []string{"ジャケット", "ジャンパー", "ジャケット メンズ"}},
+		{name: "成功: ひらがなで入力", prefix: "じゃけ", limit: 10, want: []string{"ジャケット", "ジャケット メンズ"}},
+		{name: "成功: 半角カナで入力", prefix: "ｼﾞｬﾝ", limit: 10, want: []string{"ジャンパー"}},
+		{name: "成功: 全角・大文字の英字", prefix: "ＮＩ", limit: 10, want: []string{"Nike エアマックス"}},
+		{name: "成功: 件数の上限", prefix: "ジャ", limit: 1, want: []string{"ジャケット"}},
+		{name: "成功: 一致なし", prefix: "テレビ", limit: 10, want: []string{}},
+		{name: "成功: 空の入力", prefix: " ", limit: 10, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Suggest(tt.prefix, tt.limit); !slices.Equal(got, tt.want) {
+				t.Errorf("Suggest(%q) = %v, want %v", tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrieSuggester_Rebuild(t *testing.T) {
+	s := NewTrieSuggester()
+	s.Rebuild([]Suggestion{{Text: "スニーカー", Weight: 1}})
+	s.Rebuild([]Suggestion{{Text: "スカート", Weight: 1}})
+
+	// 再構築すると古い候補は残らない
+	if got := s.Suggest("ス", 10); !slices.Equal(got, []string{"スカート"}) {
+		t.Errorf("Suggest() after Rebuild = %v, want [スカート]", got)
+	}
+}
@@ -0,0 +1,57 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize : 検索用にテキストを正規化する
+// NFKCで全角英数・半角カナを統一し、カタカナをひらがなに、英字を小文字にそろえる
+func Normalize(s string) string {
+	s = norm.NFKC.String(s)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		// カタカナ(ァ〜ヶ)はひらがなと同じ並びなので、オフセットで変換できる
+		if r >= 'ァ' && r <= 'ヶ' {
+			r -= 'ァ' - 'ぁ'
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// tokenize : テキストを正規化して文字bigramに分割する
+// 空白・記号で区切った区間ごとに2文字ずつずらして切り出し、1文字だけの区間はそのまま1トークンにする
+// withUnigrams をtrueにすると1文字のトークンも含める (1文字の検索語に一致させるため、登録側でのみ使う)
+func tokenize(s string, withUnigrams bool) []string {
+	tokens := make([]string, 0)
+	run := make([]rune, 0)
+
+	flush := func() {
+		if len(run) == 1 || (withUnigrams && len(run) > 1) {
+			for _, r := range run {
+				tokens = append(tokens, string(r))
+			}
+		}
+		for i := 0; i+1 < len(run); i++ {
+			tokens = append(tokens, string(run[i:i+2]))
+		}
+		run = run[:0]
+	}
+
+	for _, r := range Normalize(s) {
+		// 長音符は「ー」を含む語(コート、ケーキ)を分断しないよう文字として扱う
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || r == 'ー' {
+			run = append(run, r)
+			continue
+		}
+		flush()
+	}
+	flush()
+
+	return tokens
+}
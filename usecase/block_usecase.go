@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"db/dao"
+	"db/model"
+	"fmt"
+)
+
+type BlockUsecase interface {
+	Block(ctx context.Context, blockerID, blockedID string) (*model.BlockState, error)
+	Unblock(ctx context.Context, blockerID, blockedID string) (*model.BlockState, error)
+	GetBlockedUsers(ctx context.Context, userID string, limit int, offset int) ([]model.BlockedUser, error)
+}
+
+type blockUsecase struct {
+	blockDAO dao.BlockDAO
+	userDAO  dao.UserDAO
+}
+
+func NewBlockUsecase(blockDAO dao.BlockDAO, userDAO dao.UserDAO) BlockUsecase {
+	return &blockUsecase{blockDAO: blockDAO, userDAO: userDAO}
+}
+
+// Block : ユーザーをブロックする (ブロック済みでも成功する)
+// お互いのフォローは解除される
+func (u *blockUsecase) Block(ctx context.Context, blockerID, blockedID string) (*model.BlockState, error) {
+	if blockerID == blockedID {
+		return nil, model.ErrCannotBlockSelf
+	}
+	if _, err := u.userDAO.GetUser(ctx, blockedID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := u.blockDAO.Block(ctx, blockerID, blockedID); err != nil {
+		return nil, fmt.Errorf("failed to block: %w", err)
+	}
+	return &model.BlockState{UserId: blockedID, Blocked: true}, nil
+}
+
+// Unblock : ブロックを解除する (ブロックしていなくても成功する)
+func (u *blockUsecase) Unblock(ctx context.Context, blockerID, blockedID string) (*model.BlockState, error) {
+	if err := u.blockDAO.Unblock(ctx, blockerID, blockedID); err != nil {
+		return nil, fmt.Errorf("failed to unblock: %w", err)
+	}
+	return &model.BlockState{UserId: blockedID, Blocked: false}, nil
+}
+
+// GetBlockedUsers : ブロック中のユーザー一覧
+func (u *blockUsecase) GetBlockedUsers(ctx context.Context, userID string, limit int, offset int) ([]model.BlockedUser, error) {
+	users, err := u.blockDAO.GetBlockedUsers(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+	return users, nil
+}
+
+// checkNotBlocked : 2人の間にブロック関係があればErrUserBlockedを返す (どちらがブロックしていても同じ)
+func checkNotBlocked(ctx context.Context, blockDAO dao.BlockDAO, userID, otherID string) error {
+	blocked, err := blockDAO.IsBlocked(ctx, userID, otherID)
+	if err != nil {
+		return fmt.Errorf("failed to check block: %w", err)
+	}
+	if blocked {
+		return model.ErrUserBlocked
+	}
+	return nil
+}
+
+// resolveBlockFilter : 閲覧中のユーザーとブロック関係にある出品者を一覧・検索から除外する
+func resolveBlockFilter(ctx context.Context, blockDAO dao.BlockDAO, filter *model.ItemFilter) error {
+	if filter.ViewerId == "" {
+		return nil
+	}
+
+	blockedIDs, err := blockDAO.GetBlockedUserIDs(ctx, filter.ViewerId)
+	if err != nil {
+		return fmt.Errorf("fail:blockDAO.GetBlockedUserIDs: %w", err)
+	}
+	filter.ExcludeSellerIds = blockedIDs
+	return nil
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// MockBlockDAO : dao.BlockDAO のモック
+type MockBlockDAO struct {
+	BlockFunc             func(ctx context.Context, blockerID, blockedID string) error
+	UnblockFunc           func(ctx context.Context, blockerID, blockedID string) error
+	IsBlockedFunc         func(ctx context.Context, userID, otherID string) (bool, error)
+	GetBlockedUserIDsFunc func(ctx context.Context, userID string) ([]string, error)
+	GetBlockedUsersFunc   func(ctx context.Context, userID string, limit int, offset int) ([]model.BlockedUser, error)
+}
+
+func (m *MockBlockDAO) Block(ctx context.Context, blockerID, blockedID string) error {
+	if m.BlockFunc != nil {
+		return m.BlockFunc(ctx, blockerID, blockedID)
+	}
+	return nil
+}
+
+func (m *MockBlockDAO) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	if m.UnblockFunc != nil {
+		return m.UnblockFunc(ctx, blockerID, blockedID)
+	}
+	return nil
+}
+
+func (m *MockBlockDAO) IsBlocked(ctx context.Context, userID, otherID string) (bool, error) {
+	if m.IsBlockedFunc != nil {
+		return m.IsBlockedFunc(ctx, userID, otherID)
+	}
+	return false, nil
+}
+
+func (m *MockBlockDAO) GetBlockedUserIDs(ctx context.Context, userID string) ([]string, error) {
+	if m.GetBlockedUserIDsFunc != nil {
+		return m.GetBlockedUserIDsFunc(ctx, userID)
+	}
+	return []string{}, nil
+}
+
+func (m *MockBlockDAO) GetBlockedUsers(ctx context.Context, userID string, limit int, offset int) ([]model.BlockedUser, error) {
+	if m.GetBlockedUsersFunc != nil {
+		return m.GetBlockedUsersFunc(ctx, userID, limit, offset)
+	}
+	return []model.BlockedUser{}, nil
+}
+
+// newBlockingDAO : user1とseller1がブロック関係にあるBlockDAO (どちらがブロックしたかは区別しない)
+func newBlockingDAO() *MockBlockDAO {
+	pair := []string{"user1", "seller1"}
+	return &MockBlockDAO{
+		IsBlockedFunc: func(ctx context.Context, userID, otherID string) (bool, error) {
+			return slices.Contains(pair, userID) && slices.Contains(pair, otherID) && userID != otherID, nil
+		},
+		GetBlockedUserIDsFunc: func(ctx context.Context, userID string) ([]string, error) {
+			switch userID {
+			case "user1":
+				return []string{"seller1"}, nil
+			case "seller1":
+				return []string{"user1"}, nil
+			}
+			return []string{}, nil
+		},
+	}
+}
+
+func TestBlockUsecase_Block(t *testing.T) {
+	tests := []struct {
+		name        string
+		blockedID   string
+		userErr     error
+		wantErr     error
+		wantBlocked bool
+	}{
+		{name: "成功: ブロックする", blockedID: "seller1", wantBlocked: true},
+		{name: "失敗: 自分自身", blockedID: "user1", wantErr: model.ErrCannotBlockSelf},
+		{name: "失敗: 存在しないユーザー", blockedID: "unknown", userErr: model.ErrUserNotFound, wantErr: model.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocked := false
+			mockBlockDAO := &MockBlockDAO{
+				BlockFunc: func(ctx context.Context, blockerID, blockedID string) error {
+					blocked = true
+					return nil
+				},
+			}
+			mockUserDAO := &MockUserDAO{
+				GetUserFunc: func(ctx context.Context, id string) (*model.User, error) {
+					if tt.userErr != nil {
+						return nil, tt.userErr
+					}
+					return &model.User{Id: id}, nil
+				},
+			}
+
+			u := NewBlockUsecase(mockBlockDAO, mockUserDAO)
+			state, err := u.Block(context.Background(), "user1", tt.blockedID)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Block() error = %v, want %v", err, tt.wantErr)
+			}
+			if blocked != tt.wantBlocked {
+				t.Errorf("Block() blocked = %v, want %v", blocked, tt.wantBlocked)
+			}
+			if tt.wantErr == nil && (state.UserId != tt.blockedID || !state.Blocked) {
+				t.Errorf("Block() state = %+v", state)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"db/dao"
+	"db/model"
+	"fmt"
+)
+
+type CategoryUsecase interface {
+	GetCategoryTree(ctx context.Context) ([]*model.Category, error)
+}
+
+type categoryUsecase struct {
+	categoryDAO dao.CategoryDAO
+}
+
+func NewCategoryUsecase(categoryDAO dao.CategoryDAO) CategoryUsecase {
+	return &categoryUsecase{categoryDAO: categoryDAO}
+}
+
+// GetCategoryTree : カテゴリの木を商品数(子孫カテゴリの商品を含む)つきで取得
+func (u *categoryUsecase) GetCategoryTree(ctx context.Context) ([]*model.Category, error) {
+	categories, err := u.categoryDAO.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	counts, err := u.categoryDAO.GetCategoryItemCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category item counts: %w", err)
+	}
+
+	return model.BuildCategoryTree(categories, counts), nil
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// MockCategoryDAO : dao.CategoryDAO のモック
+type MockCategoryDAO struct {
+	GetCategoriesFunc         func(ctx context.Context) ([]model.Category, error)
+	GetCategoryItemCountsFunc func(ctx context.Context) (map[int]int, error)
+	CategoryExistsFunc        func(ctx context.Context, categoryID int) (bool, error)
+}
+
+func (m *MockCategoryDAO) GetCategories(ctx context.Context) ([]model.Category, error) {
+	if m.GetCategoriesFunc != nil {
+		return m.GetCategoriesFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockCategoryDAO) GetCategoryItemCounts(ctx context.Context) (map[int]int, error) {
+	if m.GetCategoryItemCountsFunc != nil {
+		return m.GetCategoryItemCountsFunc(ctx)
+	}
+	return map[int]int{}, nil
+}
+
+func (m *MockCategoryDAO) CategoryExists(ctx context.Context, categoryID int) (bool, error) {
+	if m.CategoryExistsFunc != nil {
+		return m.CategoryExistsFunc(ctx, categoryID)
+	}
+	return true, nil
+}
+
+// テスト用のカテゴリ: ファッション > メンズ > 靴, 家電
+func newTestCategoryDAO() *MockCategoryDAO {
+	return &MockCategoryDAO{
+		GetCategoriesFunc: func(ctx context.Context) ([]model.Category, error) {
+			return []model.Category{
+				{Id: 1, Name: "ファッション"},
+				{Id: 2, ParentId: 1, Name: "メンズ"},
+				{Id: 3, ParentId: 2, Name: "靴"},
+				{Id: 4, Name: "家電"},
+			}, nil
+		},
+		GetCategoryItemCountsFunc: func(ctx context.Context) (map[int]int, error) {
+			return map[int]int{1: 1, 3: 2, 4: 5}, nil
+		},
+	}
+}
+
+func TestCategoryUsecase_GetCategoryTree(t *testing.T) {
+	u := NewCategoryUsecase(newTestCategoryDAO())
+	tree, err := u.GetCategoryTree(context.Background())
+	if err != nil {
+		t.Fatalf("GetCategoryTree() error = %v", err)
+	}
+	if len(tree) != 2 {
+		t.Fatalf("GetCategoryTree() roots = %d, want 2", len(tree))
+	}
+	if tree[0].ItemCount != 3 {
+		t.Errorf("fashion.ItemCount = %d, want 3", tree[0].ItemCount)
+	}
+	if tree[1].ItemCount != 5 {
+		t.Errorf("appliances.ItemCount = %d, want 5", tree[1].ItemCount)
+	}
+}
+
+func TestItemList_GetItems_CategoryFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		categoryID int
+		wantIDs    []int
+		wantErr    error
+	}{
+		{name: "成功: 絞り込みなし", categoryID: 0, wantIDs: nil},
+		{name: "成功: 子孫カテゴリを含む", categoryID: 1, wantIDs: []int{1, 2, 3}},
+		{name: "成功: 末端カテゴリ", categoryID: 3, wantIDs: []int{3}},
+		{name: "失敗: 存在しないカテゴリ", categoryID: 99, wantErr: model.ErrCategoryNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotIDs []int
+			called := false
+			mockItemDAO := &MockItemDAO{
+				GetItemListFunc: func(ctx context.Context, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
+					called = true
+					gotIDs = filter.CategoryIds
+					return []model.ItemSimple{}, nil
+				},
+			}
+
+			u := NewItemList(mockItemDAO, newTestCategoryDAO(), &MockBlockDAO{}, nil)
+			_, err := u.GetItems(context.Background(), model.ItemFilter{CategoryId: tt.categoryID}, 10, 0)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetItems() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if called {
+					t.Errorf("GetItemList() should not be called on error")
+				}
+				return
+			}
+			slices.Sort(gotIDs)
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("filter.CategoryIds = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
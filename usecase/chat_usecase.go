@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 	"crypto/rand"
+	"db/cache"
 	"db/dao"
+	"db/hub"
 	"db/model"
 	"fmt"
 	"log"
@@ -14,32 +16,66 @@ import (
 
 type ChatUsecase interface {
 	// ルーム関連
-	GetOrCreateChatRoom(ctx context.Context, itemID, buyerID, sellerID string) (*model.ChatRoom, error)
-	GetChatRoomList(ctx context.Context, itemID string) ([]model.ChatRoomInfo, error)
+	GetOrCreateChatRoom(ctx context.Context, itemID, buyerID string) (*model.ChatRoom, error)
+	GetChatRoomList(ctx context.Context, itemID, userID string) ([]model.ChatRoomInfo, error)
+	GetInbox(ctx context.Context, userID string, limit int, offset int) ([]model.ChatInboxEntry, error)
 
 	// メッセージ関連
 	SendMessage(ctx context.Context, roomID, senderID, content string) error
-	GetMessages(ctx context.Context, roomID string) ([]model.Message, error)
+	GetMessages(ctx context.Context, roomID, userID string, query model.MessagePageQuery) (*model.MessagePage, error)
+	SubscribeMessages(ctx context.Context, roomID, userID string) (<-chan model.Message, func(), error)
+
+	// 既読関連
+	MarkRoomRead(ctx context.Context, roomID, userID, messageID string) error
+	GetUnreadCounts(ctx context.Context, userID string) (map[string]int, error)
 }
 
 type chatUsecase struct {
 	chatDAO         dao.ChatDAO
 	itemDAO         dao.ItemDAO
 	notificationDAO dao.NotificationDAO
+	blockDAO        dao.BlockDAO
+	chatHub         hub.ChatHub
+	statsCache      *cache.SellerStatsCache
 }
 
-func NewChatUsecase(chatDAO dao.ChatDAO, itemDAO dao.ItemDAO, notificationDAO dao.NotificationDAO) ChatUsecase {
+func NewChatUsecase(chatDAO dao.ChatDAO, itemDAO dao.ItemDAO, notificationDAO dao.NotificationDAO, blockDAO dao.BlockDAO, chatHub hub.ChatHub, statsCache *cache.SellerStatsCache) ChatUsecase {
 	return &chatUsecase{
 		chatDAO:         chatDAO,
 		itemDAO:         itemDAO,
 		notificationDAO: notificationDAO,
+		blockDAO:        blockDAO,
+		chatHub:         chatHub,
+		statsCache:      statsCache,
 	}
 }
 
 // GetOrCreateChatRoom :チャットルームがあれば取得、なければ作成して返す
-func (u *chatUsecase) GetOrCreateChatRoom(ctx context.Context, itemID, buyerID, sellerID string) (*model.ChatRoom, error) {
+// 出品者はリクエストではなく商品情報から決定する
+func (u *chatUsecase) GetOrCreateChatRoom(ctx context.Context, itemID, buyerID string) (*model.ChatRoom, error) {
+	item, err := u.itemDAO.GetItem(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item: %w", err)
+	}
+	sellerID := item.UserId
+
+	// 自分の商品に対してルームは作れない
+	if buyerID == sellerID {
+		return nil, model.ErrCannotChatOwnItem
+	}
+	// ブロック関係にある相手とはルームを作れない (既存のルームも開けない)
+	if err := checkNotBlocked(ctx, u.blockDAO, buyerID, sellerID); err != nil {
+		return nil, err
+	}
+
+	return getOrCreateChatRoom(ctx, u.chatDAO, item, buyerID)
+}
+
+// getOrCreateChatRoom : 商品と購入希望者のルームを取得し、なければ作成する (価格交渉からも使う)
+// 売り切れ・取り下げ後も既存のルームは開けるが、新しいルームは販売中の商品にしか作れない
+func getOrCreateChatRoom(ctx context.Context, chatDAO dao.ChatDAO, item *model.Item, buyerID string) (*model.ChatRoom, error) {
 	// 1. 既存のルームがあるか探す
-	existingRoom, err := u.chatDAO.GetChatRoom(ctx, itemID, buyerID)
+	existingRoom, err := chatDAO.GetChatRoom(ctx, item.ItemId, buyerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing room: %w", err)
 	}
@@ -48,36 +84,75 @@ func (u *chatUsecase) GetOrCreateChatRoom(ctx context.Context, itemID, buyerID,
 	}
 
 	// 2. なければ新規作成
+	if item.Status != model.StatusOnSale {
+		return nil, model.ErrItemNotOnSale
+	}
 	t := time.Now()
 	entropy := ulid.Monotonic(rand.Reader, 0)
 	newID := ulid.MustNew(ulid.Timestamp(t), entropy).String()
 
 	newRoom := &model.ChatRoom{
 		Id:        newID,
-		ItemId:    itemID,
+		ItemId:    item.ItemId,
 		BuyerId:   buyerID,
-		SellerId:  sellerID,
+		SellerId:  item.UserId,
 		CreatedAt: t,
 	}
 
-	if err := u.chatDAO.CreateChatRoom(ctx, newRoom); err != nil {
+	if err := chatDAO.CreateChatRoom(ctx, newRoom); err != nil {
 		return nil, fmt.Errorf("failed to create chat room: %w", err)
 	}
 
 	return newRoom, nil
 }
 
-// GetChatRoomList :商品IDからチャットルーム一覧を取得
-func (u *chatUsecase) GetChatRoomList(ctx context.Context, itemID string) ([]model.ChatRoomInfo, error) {
+// GetChatRoomList :商品IDからチャットルーム一覧を取得 (出品者のみ閲覧可能)
+func (u *chatUsecase) GetChatRoomList(ctx context.Context, itemID, userID string) ([]model.ChatRoomInfo, error) {
+	item, err := u.itemDAO.GetItem(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item: %w", err)
+	}
+	if item.UserId != userID {
+		return nil, model.ErrNotAuthorized
+	}
+
 	return u.chatDAO.GetChatRoomsByItemID(ctx, itemID)
 }
 
+// GetInbox :ログインユーザーが購入希望者・出品者として参加している全ルームを取得
+func (u *chatUsecase) GetInbox(ctx context.Context, userID string, limit int, offset int) ([]model.ChatInboxEntry, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	entries, err := u.chatDAO.GetChatInbox(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat inbox: %w", err)
+	}
+	return entries, nil
+}
+
 // SendMessage :メッセージを送信
 func (u *chatUsecase) SendMessage(ctx context.Context, roomID, senderID, content string) error {
 	if content == "" {
 		return fmt.Errorf("message content is empty")
 	}
 
+	// チャットルーム情報を取得し、送信者が当事者か確認
+	room, err := u.getRoomForMember(ctx, roomID, senderID)
+	if err != nil {
+		return err
+	}
+
+	// ブロック関係にある相手には送れない
+	recipientID := room.SellerId
+	if senderID == room.SellerId {
+		recipientID = room.BuyerId
+	}
+	if err := checkNotBlocked(ctx, u.blockDAO, senderID, recipientID); err != nil {
+		return err
+	}
+
 	t := time.Now()
 	entropy := ulid.Monotonic(rand.Reader, 0)
 	newID := ulid.MustNew(ulid.Timestamp(t), entropy).String()
@@ -86,6 +161,7 @@ func (u *chatUsecase) SendMessage(ctx context.Context, roomID, senderID, content
 		Id:         newID,
 		ChatRoomId: roomID,
 		SenderId:   senderID,
+		Type:       model.MessageTypeText,
 		Content:    content,
 		CreatedAt:  t,
 	}
@@ -93,12 +169,12 @@ func (u *chatUsecase) SendMessage(ctx context.Context, roomID, senderID, content
 	if err := u.chatDAO.SaveMessage(ctx, msg); err != nil {
 		return err
 	}
+	// 出品者の返信率・返信時間を計算し直させる
+	u.statsCache.Invalidate(room.SellerId)
 
-	// チャットルーム情報を取得
-	room, err := u.chatDAO.GetChatRoomByID(ctx, roomID)
-	if err != nil {
-		log.Printf("Warning: failed to get chat room: %v\n", err)
-		return nil
+	// ストリーム購読中のクライアントへ配信(失敗しても送信自体は成功とする)
+	if err := u.chatHub.Publish(ctx, roomID, *msg); err != nil {
+		log.Printf("Warning: failed to publish message: %v\n", err)
 	}
 
 	// 商品情報を取得
@@ -109,26 +185,17 @@ func (u *chatUsecase) SendMessage(ctx context.Context, roomID, senderID, content
 	}
 
 	// コメント通知を作成（相手に通知）
-	var recipientID string
-	switch senderID {
-	case room.BuyerId:
-		recipientID = room.SellerId
-	case room.SellerId:
-		recipientID = room.BuyerId
-	default:
-		return nil
-	}
-
 	notificationID := ulid.MustNew(ulid.Timestamp(t), entropy).String()
 	notification := &model.Notification{
-		Id:        notificationID,
-		UserId:    recipientID,
-		Type:      "comment",
-		ItemId:    room.ItemId,
-		ItemName:  item.Name,
-		Message:   fmt.Sprintf("%sにコメントがつきました", item.Name),
-		IsRead:    false,
-		CreatedAt: t,
+		Id:         notificationID,
+		UserId:     recipientID,
+		Type:       model.NotificationTypeComment,
+		ItemId:     room.ItemId,
+		ItemName:   item.Name,
+		ChatRoomId: roomID,
+		Message:    fmt.Sprintf("%sにコメントがつきました", item.Name),
+		IsRead:     false,
+		CreatedAt:  t,
 	}
 
 	if err := u.notificationDAO.CreateNotification(ctx, notification); err != nil {
@@ -138,7 +205,155 @@ func (u *chatUsecase) SendMessage(ctx context.Context, roomID, senderID, content
 	return nil
 }
 
-// GetMessages :メッセージ履歴を取得
-func (u *chatUsecase) GetMessages(ctx context.Context, roomID string) ([]model.Message, error) {
-	return u.chatDAO.GetMessages(ctx, roomID)
+// GetMessages :メッセージ履歴をカーソル単位で取得 (ルームの当事者のみ)
+func (u *chatUsecase) GetMessages(ctx context.Context, roomID, userID string, query model.MessagePageQuery) (*model.MessagePage, error) {
+	if !query.IsValid() {
+		return nil, model.ErrInvalidRequest
+	}
+	// カーソルはメッセージIDのULIDのみ受け付ける
+	for _, cursor := range []string{query.Before, query.After} {
+		if cursor == "" {
+			continue
+		}
+		if _, err := ulid.Parse(cursor); err != nil {
+			return nil, fmt.Errorf("%w: invalid cursor", model.ErrInvalidRequest)
+		}
+	}
+	if query.Limit == 0 {
+		query.Limit = model.DefaultMessagePageSize
+	}
+
+	room, err := u.getRoomForMember(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 次ページの有無を判定するために1件多く取得
+	limit := query.Limit
+	query.Limit = limit + 1
+	msgs, err := u.chatDAO.GetMessages(ctx, roomID, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	page := &model.MessagePage{Messages: msgs}
+	if len(msgs) > limit {
+		page.HasMore = true
+		if query.After != "" {
+			// 新しい方向へ進む場合は末尾(最新)が余分
+			page.Messages = msgs[:limit]
+			page.NextCursor = page.Messages[limit-1].Id
+		} else {
+			// 古い方向へ遡る場合は先頭(最古)が余分
+			page.Messages = msgs[len(msgs)-limit:]
+			page.NextCursor = page.Messages[0].Id
+		}
+	}
+
+	// 相手の既読位置 (取得に失敗しても履歴は返す)
+	states, err := u.chatDAO.GetReadStates(ctx, roomID)
+	if err != nil {
+		log.Printf("Warning: failed to get read states: %v\n", err)
+		return page, nil
+	}
+	counterpartID := room.SellerId
+	if userID == room.SellerId {
+		counterpartID = room.BuyerId
+	}
+	for _, s := range states {
+		if s.UserId == counterpartID {
+			page.CounterpartLastReadMessageID = s.LastReadMessageId
+		}
+	}
+
+	return page, nil
+}
+
+// SubscribeMessages :ルームの新着メッセージを購読 (ルームの当事者のみ)
+func (u *chatUsecase) SubscribeMessages(ctx context.Context, roomID, userID string) (<-chan model.Message, func(), error) {
+	if _, err := u.getRoomForMember(ctx, roomID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	ch, unsubscribe, err := u.chatHub.Subscribe(ctx, roomID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to subscribe chat room: %w", err)
+	}
+	return ch, unsubscribe, nil
+}
+
+// MarkRoomRead :ルームをmessageIDまで既読にする (空なら最新メッセージまで)
+// そのメッセージまでに届いたコメント通知もあわせて既読にする
+func (u *chatUsecase) MarkRoomRead(ctx context.Context, roomID, userID, messageID string) error {
+	if _, err := u.getRoomForMember(ctx, roomID, userID); err != nil {
+		return err
+	}
+
+	if messageID == "" {
+		latestID, err := u.chatDAO.GetLatestMessageID(ctx, roomID)
+		if err != nil {
+			return fmt.Errorf("failed to get latest message: %w", err)
+		}
+		messageID = latestID
+		if messageID == "" {
+			// メッセージがまだないルーム
+			return nil
+		}
+	} else {
+		if _, err := ulid.Parse(messageID); err != nil {
+			return fmt.Errorf("%w: invalid message id", model.ErrInvalidRequest)
+		}
+		exists, err := u.chatDAO.MessageExists(ctx, roomID, messageID)
+		if err != nil {
+			return fmt.Errorf("failed to check message: %w", err)
+		}
+		if !exists {
+			return model.ErrMessageNotFound
+		}
+	}
+
+	state := &model.ChatReadState{
+		ChatRoomId:        roomID,
+		UserId:            userID,
+		LastReadMessageId: messageID,
+		UpdatedAt:         time.Now(),
+	}
+	if err := u.chatDAO.UpsertReadState(ctx, state); err != nil {
+		return fmt.Errorf("failed to mark room as read: %w", err)
+	}
+
+	// 既読にしたメッセージまでのコメント通知をまとめて既読にする(失敗しても既読処理は成功とする)
+	if err := u.notificationDAO.MarkChatRoomNotificationsAsRead(ctx, userID, roomID, messageID); err != nil {
+		log.Printf("Warning: failed to mark comment notifications as read: %v\n", err)
+	}
+
+	return nil
+}
+
+// GetUnreadCounts :参加中のルームごとの未読数を取得 (未読のないルームは含まない)
+func (u *chatUsecase) GetUnreadCounts(ctx context.Context, userID string) (map[string]int, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	counts, err := u.chatDAO.GetUnreadCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unread counts: %w", err)
+	}
+	return counts, nil
+}
+
+// getRoomForMember :ルームを取得し、userIDが当事者でなければエラーを返す
+func (u *chatUsecase) getRoomForMember(ctx context.Context, roomID, userID string) (*model.ChatRoom, error) {
+	room, err := u.chatDAO.GetChatRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat room: %w", err)
+	}
+	if room == nil {
+		return nil, model.ErrChatRoomNotFound
+	}
+	if !room.IsMember(userID) {
+		return nil, model.ErrNotChatMember
+	}
+	return room, nil
 }
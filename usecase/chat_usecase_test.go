@@ -0,0 +1,860 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/hub"
+	"db/model"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// MockChatDAO : dao.ChatDAO のモック
+type MockChatDAO struct {
+	CreateChatRoomFunc       func(ctx context.Context, room *model.ChatRoom) error
+	GetChatRoomFunc          func(ctx context.Context, itemID, buyerID string) (*model.ChatRoom, error)
+	GetChatRoomByIDFunc      func(ctx context.Context, roomID string) (*model.ChatRoom, error)
+	GetChatRoomsByItemIDFunc func(ctx context.Context, itemID string) ([]model.ChatRoomInfo, error)
+	GetChatInboxFunc         func(ctx context.Context, userID string, limit int, offset int) ([]model.ChatInboxEntry, error)
+	SaveMessageFunc          func(ctx context.Context, msg *model.Message) error
+	GetMessagesFunc          func(ctx context.Context, roomID string, query model.MessagePageQuery) ([]model.Message, error)
+	GetUserMessagesFunc      func(ctx context.Context, userID string) ([]model.Message, error)
+	GetSellerInquiriesFunc   func(ctx context.Context, sellerID string, limit int) ([]model.SellerInquiry, error)
+	MessageExistsFunc        func(ctx context.Context, roomID, messageID string) (bool, error)
+	GetLatestMessageIDFunc   func(ctx context.Context, roomID string) (string, error)
+	UpsertReadStateFunc      func(ctx context.Context, state *model.ChatReadState) error
+	GetReadStatesFunc        func(ctx context.Context, roomID string) ([]model.ChatReadState, error)
+	GetUnreadCountsFunc      func(ctx context.Context, userID string) (map[string]int, error)
+}
+
+func (m *MockChatDAO) CreateChatRoom(ctx context.Context, room *model.ChatRoom) error {
+	if m.CreateChatRoomFunc != nil {
+		return m.CreateChatRoomFunc(ctx, room)
+	}
+	return nil
+}
+
+func (m *MockChatDAO) GetChatRoom(ctx context.Context, itemID, buyerID string) (*model.ChatRoom, error) {
+	if m.GetChatRoomFunc != nil {
+		return m.GetChatRoomFunc(ctx, itemID, buyerID)
+	}
+	return nil, nil
+}
+
+func (m *MockChatDAO) GetChatRoomByID(ctx context.Context, roomID string) (*model.ChatRoom, error) {
+	if m.GetChatRoomByIDFunc != nil {
+		return m.GetChatRoomByIDFunc(ctx, roomID)
+	}
+	return nil, nil
+}
+
+func (m *MockChatDAO) GetChatRoomsByItemID(ctx context.Context, itemID string) ([]model.ChatRoomInfo, error) {
+	if m.GetChatRoomsByItemIDFunc != nil {
+		return m.GetChatRoomsByItemIDFunc(ctx, itemID)
+	}
+	return nil, nil
+}
+
+func (m *MockChatDAO) GetChatInbox(ctx context.Context, userID string, limit int, offset int) ([]model.ChatInboxEntry, error) {
+	if m.GetChatInboxFunc != nil {
+		return m.GetChatInboxFunc(ctx, userID, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockChatDAO) SaveMessage(ctx context.Context, msg *model.Message) error {
+	if m.SaveMessageFunc != nil {
+		return m.SaveMessageFunc(ctx, msg)
+	}
+	return nil
+}
+
+func (m *MockChatDAO) GetMessages(ctx context.Context, roomID string, query model.MessagePageQuery) ([]model.Message, error) {
+	if m.GetMessagesFunc != nil {
+		return m.GetMessagesFunc(ctx, roomID, query)
+	}
+	return nil, nil
+}
+
+func (m *MockChatDAO) GetUserMessages(ctx context.Context, userID string) ([]model.Message, error) {
+	if m.GetUserMessagesFunc != nil {
+		return m.GetUserMessagesFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockChatDAO) GetSellerInquiries(ctx context.Context, sellerID string, limit int) ([]model.SellerInquiry, error) {
+	if m.GetSellerInquiriesFunc != nil {
+		return m.GetSellerInquiriesFunc(ctx, sellerID, limit)
+	}
+	return []model.SellerInquiry{}, nil
+}
+
+func (m *MockChatDAO) MessageExists(ctx context.Context, roomID, messageID string) (bool, error) {
+	if m.MessageExistsFunc != nil {
+		return m.MessageExistsFunc(ctx, roomID, messageID)
+	}
+	return false, nil
+}
+
+func (m *MockChatDAO) GetLatestMessageID(ctx context.Context, roomID string) (string, error) {
+	if m.GetLatestMessageIDFunc != nil {
+		return m.GetLatestMessageIDFunc(ctx, roomID)
+	}
+	return "", nil
+}
+
+func (m *MockChatDAO) UpsertReadState(ctx context.Context, state *model.ChatReadState) error {
+	if m.UpsertReadStateFunc != nil {
+		return m.UpsertReadStateFunc(ctx, state)
+	}
+	return nil
+}
+
+func (m *MockChatDAO) GetReadStates(ctx context.Context, roomID string) ([]model.ChatReadState, error) {
+	if m.GetReadStatesFunc != nil {
+		return m.GetReadStatesFunc(ctx, roomID)
+	}
+	return nil, nil
+}
+
+func (m *MockChatDAO) GetUnreadCounts(ctx context.Context, userID string) (map[string]int, error) {
+	if m.GetUnreadCountsFunc != nil {
+		return m.GetUnreadCountsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+// テスト用の共通データ
+var (
+	chatTestItem = &model.Item{ItemId: "item1", UserId: "seller1", Name: "Test Item", Status: model.StatusOnSale}
+	chatTestRoom = &model.ChatRoom{Id: "room1", ItemId: "item1", BuyerId: "buyer1", SellerId: "seller1"}
+)
+
+func TestChatUsecase_GetOrCreateChatRoom(t *testing.T) {
+	tests := []struct {
+		name        string
+		buyerID     string
+		mockItemDAO *MockItemDAO
+		mockChatDAO *MockChatDAO
+		wantSeller  string
+		wantErr     error
+		wantAnyErr  bool
+	}{
+		{
+			name:    "成功: 新規作成 (出品者は商品情報から決定)",
+			buyerID: "buyer1",
+			mockItemDAO: &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return chatTestItem, nil
+				},
+			},
+			mockChatDAO: &MockChatDAO{},
+			wantSeller:  "seller1",
+		},
+		{
+			name:    "成功: 既存ルームを返す",
+			buyerID: "buyer1",
+			mockItemDAO: &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return chatTestItem, nil
+				},
+			},
+			mockChatDAO: &MockChatDAO{
+				GetChatRoomFunc: func(ctx context.Context, itemID, buyerID string) (*model.ChatRoom, error) {
+					return chatTestRoom, nil
+				},
+				CreateChatRoomFunc: func(ctx context.Context, room *model.ChatRoom) error {
+					return errors.New("should not be called")
+				},
+			},
+			wantSeller: "seller1",
+		},
+		{
+			name:    "成功: 売り切れ後も既存ルームは開ける",
+			buyerID: "buyer1",
+			mockItemDAO: &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return &model.Item{ItemId: itemID, UserId: "seller1", Status: model.StatusSold}, nil
+				},
+			},
+			mockChatDAO: &MockChatDAO{
+				GetChatRoomFunc: func(ctx context.Context, itemID, buyerID string) (*model.ChatRoom, error) {
+					return chatTestRoom, nil
+				},
+			},
+			wantSeller: "seller1",
+		},
+		{
+			name:    "失敗: 取り下げた商品には新しいルームを作れない",
+			buyerID: "buyer1",
+			mockItemDAO: &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return &model.Item{ItemId: itemID, UserId: "seller1", Status: model.StatusWithdrawn}, nil
+				},
+			},
+			mockChatDAO: &MockChatDAO{
+				CreateChatRoomFunc: func(ctx context.Context, room *model.ChatRoom) error {
+					return errors.New("should not be called")
+				},
+			},
+			wantErr: model.ErrItemNotOnSale,
+		},
+		{
+			name:    "失敗: 売り切れの商品には新しいルームを作れない",
+			buyerID: "buyer1",
+			mockItemDAO: &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return &model.Item{ItemId: itemID, UserId: "seller1", Status: model.StatusSold}, nil
+				},
+			},
+			mockChatDAO: &MockChatDAO{
+				CreateChatRoomFunc: func(ctx context.Context, room *model.ChatRoom) error {
+					return errors.New("should not be called")
+				},
+			},
+			wantErr: model.ErrItemNotOnSale,
+		},
+		{
+			name:    "失敗: 自分の商品",
+			buyerID: "seller1",
+			mockItemDAO: &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return chatTestItem, nil
+				},
+			},
+			mockChatDAO: &MockChatDAO{},
+			wantErr:     model.ErrCannotChatOwnItem,
+		},
+		{
+			name:    "失敗: 商品が存在しない",
+			buyerID: "buyer1",
+			mockItemDAO: &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return nil, model.ErrItemNotFound
+				},
+			},
+			mockChatDAO: &MockChatDAO{},
+			wantErr:     model.ErrItemNotFound,
+		},
+		{
+			name:    "失敗: ルーム作成エラー",
+			buyerID: "buyer1",
+			mockItemDAO: &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return chatTestItem, nil
+				},
+			},
+			mockChatDAO: &MockChatDAO{
+				CreateChatRoomFunc: func(ctx context.Context, room *model.ChatRoom) error {
+					return errors.New("db error")
+				},
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewChatUsecase(tt.mockChatDAO, tt.mockItemDAO, &MockNotificationDAO{}, &MockBlockDAO{}, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+			room, err := u.GetOrCreateChatRoom(context.Background(), "item1", tt.buyerID)
+
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil {
+					t.Fatalf("GetOrCreateChatRoom() expected error, got nil")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetOrCreateChatRoom() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetOrCreateChatRoom() unexpected error: %v", err)
+			}
+			if room.SellerId != tt.wantSeller {
+				t.Errorf("GetOrCreateChatRoom() seller = %v, want %v", room.SellerId, tt.wantSeller)
+			}
+		})
+	}
+}
+
+func TestChatUsecase_GetChatRoomList(t *testing.T) {
+	mockItemDAO := &MockItemDAO{
+		GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+			return chatTestItem, nil
+		},
+	}
+	mockChatDAO := &MockChatDAO{
+		GetChatRoomsByItemIDFunc: func(ctx context.Context, itemID string) ([]model.ChatRoomInfo, error) {
+			return []model.ChatRoomInfo{{RoomID: "room1", BuyerID: "buyer1"}}, nil
+		},
+	}
+
+	tests := []struct {
+		name    string
+		userID  string
+		wantErr error
+	}{
+		{name: "成功: 出品者は閲覧可能", userID: "seller1"},
+		{name: "失敗: 購入希望者は閲覧不可", userID: "buyer1", wantErr: model.ErrNotAuthorized},
+		{name: "失敗: 第三者は閲覧不可", userID: "other", wantErr: model.ErrNotAuthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewChatUsecase(mockChatDAO, mockItemDAO, &MockNotificationDAO{}, &MockBlockDAO{}, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+			rooms, err := u.GetChatRoomList(context.Background(), "item1", tt.userID)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetChatRoomList() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && len(rooms) != 1 {
+				t.Errorf("GetChatRoomList() got length = %v, want 1", len(rooms))
+			}
+		})
+	}
+}
+
+func TestChatUsecase_GetInbox(t *testing.T) {
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name        string
+		userID      string
+		inboxErr    error
+		wantErr     error
+		wantRoomIDs []string
+	}{
+		{name: "成功: 参加中のルームを取得", userID: "buyer1", wantRoomIDs: []string{"room2", "room1"}},
+		{name: "失敗: ユーザーIDが空", userID: ""},
+		{name: "失敗: DBエラー", userID: "buyer1", inboxErr: dbErr, wantErr: dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUserID string
+			var gotLimit, gotOffset int
+			mockChatDAO := &MockChatDAO{
+				GetChatInboxFunc: func(ctx context.Context, userID string, limit int, offset int) ([]model.ChatInboxEntry, error) {
+					gotUserID, gotLimit, gotOffset = userID, limit, offset
+					if tt.inboxErr != nil {
+						return nil, tt.inboxErr
+					}
+					return []model.ChatInboxEntry{
+						{RoomID: "room2", Role: model.ChatRoleBuyer, UnreadCount: 2},
+						{RoomID: "room1", Role: model.ChatRoleSeller},
+					}, nil
+				},
+			}
+
+			u := NewChatUsecase(mockChatDAO, &MockItemDAO{}, &MockNotificationDAO{}, &MockBlockDAO{}, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+			entries, err := u.GetInbox(context.Background(), tt.userID, 20, 40)
+
+			if tt.userID == "" {
+				if err == nil || gotUserID != "" {
+					t.Fatalf("GetInbox() should fail without calling DAO, error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetInbox() error = %v, want %v", err, tt.wantErr)
+			}
+			if gotUserID != tt.userID || gotLimit != 20 || gotOffset != 40 {
+				t.Errorf("GetChatInbox() called with (%q, %d, %d), want (%q, 20, 40)", gotUserID, gotLimit, gotOffset, tt.userID)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			var roomIDs []string
+			for _, e := range entries {
+				roomIDs = append(roomIDs, e.RoomID)
+			}
+			if !slices.Equal(roomIDs, tt.wantRoomIDs) {
+				t.Errorf("GetInbox() rooms = %v, want %v", roomIDs, tt.wantRoomIDs)
+			}
+		})
+	}
+}
+
+func TestChatUsecase_GetMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		room    *model.ChatRoom
+		wantErr error
+	}{
+		{name: "成功: 購入希望者", userID: "buyer1", room: chatTestRoom},
+		{name: "成功: 出品者", userID: "seller1", room: chatTestRoom},
+		{name: "失敗: 第三者", userID: "other", room: chatTestRoom, wantErr: model.ErrNotChatMember},
+		{name: "失敗: ルームが存在しない", userID: "buyer1", room: nil, wantErr: model.ErrChatRoomNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getMessagesCalled := false
+			mockChatDAO := &MockChatDAO{
+				GetChatRoomByIDFunc: func(ctx context.Context, roomID string) (*model.ChatRoom, error) {
+					return tt.room, nil
+				},
+				GetMessagesFunc: func(ctx context.Context, roomID string, query model.MessagePageQuery) ([]model.Message, error) {
+					getMessagesCalled = true
+					return []model.Message{{Id: "msg1"}}, nil
+				},
+			}
+
+			u := NewChatUsecase(mockChatDAO, &MockItemDAO{}, &MockNotificationDAO{}, &MockBlockDAO{}, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+			_, err := u.GetMessages(context.Background(), "room1", tt.userID, model.MessagePageQuery{})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetMessages() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && getMessagesCalled {
+				t.Errorf("GetMessages() should not read messages when access is denied")
+			}
+		})
+	}
+}
+
+func TestChatUsecase_GetMessages_Pagination(t *testing.T) {
+	// ULIDは辞書順 = 時系列順
+	ids := []string{
+		"01HZZZZZZZ0000000000000001",
+		"01HZZZZZZZ0000000000000002",
+		"01HZZZZZZZ0000000000000003",
+	}
+	msgs := make([]model.Message, len(ids))
+	for i, id := range ids {
+		msgs[i] = model.Message{Id: id, ChatRoomId: "room1"}
+	}
+
+	tests := []struct {
+		name           string
+		query          model.MessagePageQuery
+		daoResult      []model.Message
+		wantDAOLimit   int
+		wantIDs        []string
+		wantHasMore    bool
+		wantNextCursor string
+		wantErr        error
+	}{
+		{
+			name:         "成功: 最新ページ (続きなし)",
+			query:        model.MessagePageQuery{},
+			daoResult:    msgs,
+			wantDAOLimit: model.DefaultMessagePageSize + 1,
+			wantIDs:      ids,
+		},
+		{
+			name:           "成功: beforeで遡る (続きあり)",
+			query:          model.MessagePageQuery{Before: "01HZZZZZZZ0000000000000009", Limit: 2},
+			daoResult:      msgs,
+			wantDAOLimit:   3,
+			wantIDs:        ids[1:],
+			wantHasMore:    true,
+			wantNextCursor: ids[1],
+		},
+		{
+			name:           "成功: afterで新着を取得 (続きあり)",
+			query:          model.MessagePageQuery{After: "01HZZZZZZZ0000000000000000", Limit: 2},
+			daoResult:      msgs,
+			wantDAOLimit:   3,
+			wantIDs:        ids[:2],
+			wantHasMore:    true,
+			wantNextCursor: ids[1],
+		},
+		{
+			name:    "失敗: beforeとafterの同時指定",
+			query:   model.MessagePageQuery{Before: ids[0], After: ids[1]},
+			wantErr: model.ErrInvalidRequest,
+		},
+		{
+			name:    "失敗: ULIDでないカーソル",
+			query:   model.MessagePageQuery{Before: "not-a-ulid"},
+			wantErr: model.ErrInvalidRequest,
+		},
+		{
+			name:    "失敗: limitが上限超過",
+			query:   model.MessagePageQuery{Limit: model.MaxMessagePageSize + 1},
+			wantErr: model.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotDAOLimit int
+			mockChatDAO := &MockChatDAO{
+				GetChatRoomByIDFunc: func(ctx context.Context, roomID string) (*model.ChatRoom, error) {
+					return chatTestRoom, nil
+				},
+				GetMessagesFunc: func(ctx context.Context, roomID string, query model.MessagePageQuery) ([]model.Message, error) {
+					gotDAOLimit = query.Limit
+					return tt.daoResult, nil
+				},
+			}
+
+			u := NewChatUsecase(mockChatDAO, &MockItemDAO{}, &MockNotificationDAO{}, &MockBlockDAO{}, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+			page, err := u.GetMessages(context.Background(), "room1", "buyer1", tt.query)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetMessages() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if gotDAOLimit != tt.wantDAOLimit {
+				t.Errorf("DAO limit = %v, want %v", gotDAOLimit, tt.wantDAOLimit)
+			}
+			if len(page.Messages) != len(tt.wantIDs) {
+				t.Fatalf("GetMessages() got length = %v, want %v", len(page.Messages), len(tt.wantIDs))
+			}
+			for i, id := range tt.wantIDs {
+				if page.Messages[i].Id != id {
+					t.Errorf("Messages[%d] = %v, want %v", i, page.Messages[i].Id, id)
+				}
+			}
+			if page.HasMore != tt.wantHasMore || page.NextCursor != tt.wantNextCursor {
+				t.Errorf("HasMore/NextCursor = %v/%v, want %v/%v", page.HasMore, page.NextCursor, tt.wantHasMore, tt.wantNextCursor)
+			}
+		})
+	}
+}
+
+func TestChatUsecase_SendMessage(t *testing.T) {
+	tests := []struct {
+		name          string
+		senderID      string
+		wantErr       error
+		wantRecipient string
+	}{
+		{name: "成功: 購入希望者から出品者へ", senderID: "buyer1", wantRecipient: "seller1"},
+		{name: "成功: 出品者から購入希望者へ", senderID: "seller1", wantRecipient: "buyer1"},
+		{name: "失敗: 第三者は送信不可", senderID: "other", wantErr: model.ErrNotChatMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := false
+			var recipient string
+			mockChatDAO := &MockChatDAO{
+				GetChatRoomByIDFunc: func(ctx context.Context, roomID string) (*model.ChatRoom, error) {
+					return chatTestRoom, nil
+				},
+				SaveMessageFunc: func(ctx context.Context, msg *model.Message) error {
+					saved = true
+					return nil
+				},
+			}
+			mockItemDAO := &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return chatTestItem, nil
+				},
+			}
+			mockNotificationDAO := &MockNotificationDAO{
+				CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+					recipient = notification.UserId
+					return nil
+				},
+			}
+
+			u := NewChatUsecase(mockChatDAO, mockItemDAO, mockNotificationDAO, &MockBlockDAO{}, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+			err := u.SendMessage(context.Background(), "room1", tt.senderID, "hello")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SendMessage() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if saved {
+					t.Errorf("SendMessage() should not save message when access is denied")
+				}
+				return
+			}
+			if recipient != tt.wantRecipient {
+				t.Errorf("SendMessage() notified %v, want %v", recipient, tt.wantRecipient)
+			}
+		})
+	}
+}
+
+func TestChatUsecase_SubscribeMessages(t *testing.T) {
+	mockChatDAO := &MockChatDAO{
+		GetChatRoomByIDFunc: func(ctx context.Context, roomID string) (*model.ChatRoom, error) {
+			return chatTestRoom, nil
+		},
+	}
+	mockItemDAO := &MockItemDAO{
+		GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+			return chatTestItem, nil
+		},
+	}
+	u := NewChatUsecase(mockChatDAO, mockItemDAO, &MockNotificationDAO{}, &MockBlockDAO{}, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+	ctx := context.Background()
+
+	t.Run("失敗: 第三者は購読不可", func(t *testing.T) {
+		if _, _, err := u.SubscribeMessages(ctx, "room1", "other"); !errors.Is(err, model.ErrNotChatMember) {
+			t.Errorf("SubscribeMessages() error = %v, want %v", err, model.ErrNotChatMember)
+		}
+	})
+
+	t.Run("成功: 送信したメッセージが購読者に届く", func(t *testing.T) {
+		ch, unsubscribe, err := u.SubscribeMessages(ctx, "room1", "seller1")
+		if err != nil {
+			t.Fatalf("SubscribeMessages() error = %v", err)
+		}
+		defer unsubscribe()
+
+		if err := u.SendMessage(ctx, "room1", "buyer1", "hello"); err != nil {
+			t.Fatalf("SendMessage() error = %v", err)
+		}
+
+		select {
+		case msg := <-ch:
+			if msg.Content != "hello" || msg.SenderId != "buyer1" {
+				t.Errorf("received message = %+v", msg)
+			}
+		default:
+			t.Errorf("subscriber did not receive message")
+		}
+	})
+}
+
+func TestChatUsecase_MarkRoomRead(t *testing.T) {
+	const latestID = "01HZZZZZZZ0000000000000003"
+
+	tests := []struct {
+		name          string
+		userID        string
+		messageID     string
+		exists        bool
+		latestID      string
+		wantErr       error
+		wantReadID    string
+		wantNotifRead bool
+	}{
+		{
+			name:          "成功: メッセージ指定",
+			userID:        "buyer1",
+			messageID:     "01HZZZZZZZ0000000000000002",
+			exists:        true,
+			wantReadID:    "01HZZZZZZZ0000000000000002",
+			wantNotifRead: true,
+		},
+		{
+			name:          "成功: 省略時は最新メッセージまで",
+			userID:        "seller1",
+			latestID:      latestID,
+			wantReadID:    latestID,
+			wantNotifRead: true,
+		},
+		{
+			name:   "成功: メッセージがないルームは何もしない",
+			userID: "buyer1",
+		},
+		{
+			name:      "失敗: 別ルームのメッセージ",
+			userID:    "buyer1",
+			messageID: "01HZZZZZZZ0000000000000002",
+			exists:    false,
+			wantErr:   model.ErrMessageNotFound,
+		},
+		{
+			name:      "失敗: 不正なメッセージID",
+			userID:    "buyer1",
+			messageID: "invalid",
+			wantErr:   model.ErrInvalidRequest,
+		},
+		{
+			name:    "失敗: 第三者",
+			userID:  "other",
+			wantErr: model.ErrNotChatMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotReadID string
+			notifRead := false
+			mockChatDAO := &MockChatDAO{
+				GetChatRoomByIDFunc: func(ctx context.Context, roomID string) (*model.ChatRoom, error) {
+					return chatTestRoom, nil
+				},
+				MessageExistsFunc: func(ctx context.Context, roomID, messageID string) (bool, error) {
+					return tt.exists, nil
+				},
+				GetLatestMessageIDFunc: func(ctx context.Context, roomID string) (string, error) {
+					return tt.latestID, nil
+				},
+				UpsertReadStateFunc: func(ctx context.Context, state *model.ChatReadState) error {
+					gotReadID = state.LastReadMessageId
+					return nil
+				},
+			}
+			mockNotificationDAO := &MockNotificationDAO{
+				MarkChatRoomNotificationsAsReadFunc: func(ctx context.Context, userID string, chatRoomID string, messageID string) error {
+					// 既読にしたメッセージまでの通知だけを対象にする
+					if userID == tt.userID && chatRoomID == "room1" && messageID == tt.wantReadID {
+						notifRead = true
+					}
+					return nil
+				},
+			}
+
+			u := NewChatUsecase(mockChatDAO, &MockItemDAO{}, mockNotificationDAO, &MockBlockDAO{}, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+			err := u.MarkRoomRead(context.Background(), "room1", tt.userID, tt.messageID)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MarkRoomRead() error = %v, want %v", err, tt.wantErr)
+			}
+			if gotReadID != tt.wantReadID {
+				t.Errorf("read state = %v, want %v", gotReadID, tt.wantReadID)
+			}
+			if notifRead != tt.wantNotifRead {
+				t.Errorf("comment notifications cleared = %v, want %v", notifRead, tt.wantNotifRead)
+			}
+		})
+	}
+}
+
+func TestChatUsecase_MarkRoomRead_OnlyThatRoomNotifications(t *testing.T) {
+	// 同じ商品に2人の購入希望者がいて、出品者は片方のルームだけ読む
+	rooms := map[string]*model.ChatRoom{
+		"room1": {Id: "room1", ItemId: "item1", BuyerId: "buyer1", SellerId: "seller1"},
+		"room2": {Id: "room2", ItemId: "item1", BuyerId: "buyer2", SellerId: "seller1"},
+	}
+	var notifications []*model.Notification
+	mockChatDAO := &MockChatDAO{
+		GetChatRoomByIDFunc: func(ctx context.Context, roomID string) (*model.ChatRoom, error) {
+			return rooms[roomID], nil
+		},
+		GetLatestMessageIDFunc: func(ctx context.Context, roomID string) (string, error) {
+			return "01HZZZZZZZZZZZZZZZZZZZZZZZ", nil
+		},
+	}
+	mockItemDAO := &MockItemDAO{
+		GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+			return chatTestItem, nil
+		},
+	}
+	mockNotificationDAO := &MockNotificationDAO{
+		CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+			notifications = append(notifications, notification)
+			return nil
+		},
+		MarkChatRoomNotificationsAsReadFunc: func(ctx context.Context, userID string, chatRoomID string, messageID string) error {
+			for _, n := range notifications {
+				if n.UserId == userID && n.ChatRoomId == chatRoomID {
+					n.IsRead = true
+				}
+			}
+			return nil
+		},
+	}
+
+	u := NewChatUsecase(mockChatDAO, mockItemDAO, mockNotificationDAO, &MockBlockDAO{}, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+	ctx := context.Background()
+	if err := u.SendMessage(ctx, "room1", "buyer1", "購入希望です"); err != nil {
+		t.Fatalf("SendMessage(room1) error = %v", err)
+	}
+	if err := u.SendMessage(ctx, "room2", "buyer2", "値下げできますか"); err != nil {
+		t.Fatalf("SendMessage(room2) error = %v", err)
+	}
+	if err := u.MarkRoomRead(ctx, "room1", "seller1", ""); err != nil {
+		t.Fatalf("MarkRoomRead() error = %v", err)
+	}
+
+	if len(notifications) != 2 {
+		t.Fatalf("notifications = %d, want 2", len(notifications))
+	}
+	for _, n := range notifications {
+		wantRead := n.ChatRoomId == "room1"
+		if n.IsRead != wantRead {
+			t.Errorf("notification for %s read = %v, want %v", n.ChatRoomId, n.IsRead, wantRead)
+		}
+	}
+}
+
+func TestChatUsecase_GetMessages_CounterpartReadState(t *testing.T) {
+	mockChatDAO := &MockChatDAO{
+		GetChatRoomByIDFunc: func(ctx context.Context, roomID string) (*model.ChatRoom, error) {
+			return chatTestRoom, nil
+		},
+		GetReadStatesFunc: func(ctx context.Context, roomID string) ([]model.ChatReadState, error) {
+			return []model.ChatReadState{
+				{ChatRoomId: "room1", UserId: "buyer1", LastReadMessageId: "buyer-read"},
+				{ChatRoomId: "room1", UserId: "seller1", LastReadMessageId: "seller-read"},
+			}, nil
+		},
+	}
+	u := NewChatUsecase(mockChatDAO, &MockItemDAO{}, &MockNotificationDAO{}, &MockBlockDAO{}, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+
+	page, err := u.GetMessages(context.Background(), "room1", "buyer1", model.MessagePageQuery{})
+	if err != nil {
+		t.Fatalf("GetMessages() error = %v", err)
+	}
+	if page.CounterpartLastReadMessageID != "seller-read" {
+		t.Errorf("CounterpartLastReadMessageID = %v, want seller-read", page.CounterpartLastReadMessageID)
+	}
+}
+
+func TestChatUsecase_BlockedUsers(t *testing.T) {
+	item := &model.Item{ItemId: "item1", Name: "Test Item", UserId: "seller1", Status: model.StatusOnSale}
+	room := &model.ChatRoom{Id: "room1", ItemId: "item1", BuyerId: "user1", SellerId: "seller1"}
+
+	tests := []struct {
+		name   string
+		action func(u ChatUsecase) error
+	}{
+		{
+			name: "失敗: ブロック関係の出品者とルームを開けない",
+			action: func(u ChatUsecase) error {
+				_, err := u.GetOrCreateChatRoom(context.Background(), "item1", "user1")
+				return err
+			},
+		},
+		{
+			name: "失敗: 購入希望者から送信できない",
+			action: func(u ChatUsecase) error {
+				return u.SendMessage(context.Background(), "room1", "user1", "hello")
+			},
+		},
+		{
+			name: "失敗: 出品者から送信できない",
+			action: func(u ChatUsecase) error {
+				return u.SendMessage(context.Background(), "room1", "seller1", "hello")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, saved := false, false
+			mockChatDAO := &MockChatDAO{
+				GetChatRoomByIDFunc: func(ctx context.Context, roomID string) (*model.ChatRoom, error) {
+					return room, nil
+				},
+				CreateChatRoomFunc: func(ctx context.Context, room *model.ChatRoom) error {
+					created = true
+					return nil
+				},
+				SaveMessageFunc: func(ctx context.Context, msg *model.Message) error {
+					saved = true
+					return nil
+				},
+			}
+			mockItemDAO := &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return item, nil
+				},
+			}
+
+			u := NewChatUsecase(mockChatDAO, mockItemDAO, &MockNotificationDAO{}, newBlockingDAO(), hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+			if err := tt.action(u); !errors.Is(err, model.ErrUserBlocked) {
+				t.Fatalf("error = %v, want %v", err, model.ErrUserBlocked)
+			}
+			if created || saved {
+				t.Errorf("should not create room or save message when blocked")
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"db/dao"
+	"db/model"
+	"fmt"
+	"log"
+
+	"github.com/oklog/ulid"
+)
+
+type FollowUsecase interface {
+	Follow(ctx context.Context, followerID, followeeID string) (*model.FollowState, error)
+	Unfollow(ctx context.Context, followerID, followeeID string) (*model.FollowState, error)
+	GetFollowers(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error)
+	GetFollowing(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error)
+	GetFeed(ctx context.Context, userID string, before string, limit int) (*model.FeedPage, error)
+}
+
+type followUsecase struct {
+	followDAO dao.FollowDAO
+	userDAO   dao.UserDAO
+	itemDAO   dao.ItemDAO
+	blockDAO  dao.BlockDAO
+}
+
+func NewFollowUsecase(followDAO dao.FollowDAO, userDAO dao.UserDAO, itemDAO dao.ItemDAO, blockDAO dao.BlockDAO) FollowUsecase {
+	return &followUsecase{followDAO: followDAO, userDAO: userDAO, itemDAO: itemDAO, blockDAO: blockDAO}
+}
+
+// Follow : ユーザーをフォローする (フォロー済みでも成功し、フォロー後の状態を返す)
+func (u *followUsecase) Follow(ctx context.Context, followerID, followeeID string) (*model.FollowState, error) {
+	if followerID == followeeID {
+		return nil, model.ErrCannotFollowSelf
+	}
+	if _, err := u.userDAO.GetUser(ctx, followeeID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	// ブロック関係にある相手はフォローできない
+	if err := checkNotBlocked(ctx, u.blockDAO, followerID, followeeID); err != nil {
+		return nil, err
+	}
+
+	if err := u.followDAO.Follow(ctx, followerID, followeeID); err != nil {
+		return nil, fmt.Errorf("failed to follow: %w", err)
+	}
+
+	return u.followState(ctx, followeeID, true)
+}
+
+// Unfollow : フォローを解除する (フォローしていなくても成功し、解除後の状態を返す)
+func (u *followUsecase) Unfollow(ctx context.Context, followerID, followeeID string) (*model.FollowState, error) {
+	if err := u.followDAO.Unfollow(ctx, followerID, followeeID); err != nil {
+		return nil, fmt.Errorf("failed to unfollow: %w", err)
+	}
+
+	return u.followState(ctx, followeeID, false)
+}
+
+// followState : フォロー・解除後の状態とフォロワー数を返す
+func (u *followUsecase) followState(ctx context.Context, followeeID string, following bool) (*model.FollowState, error) {
+	counts, err := u.followDAO.GetFollowCounts(ctx, followeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count followers: %w", err)
+	}
+	return &model.FollowState{UserId: followeeID, Following: following, FollowerCount: counts.Followers}, nil
+}
+
+// GetFollowers : フォロワー一覧
+func (u *followUsecase) GetFollowers(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error) {
+	users, err := u.followDAO.GetFollowers(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followers: %w", err)
+	}
+	return users, nil
+}
+
+// GetFollowing : フォロー中のユーザー一覧
+func (u *followUsecase) GetFollowing(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error) {
+	users, err := u.followDAO.GetFollowing(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get following: %w", err)
+	}
+	return users, nil
+}
+
+// GetFeed : フォロー中の出品者の販売中の商品を新しい順にカーソル単位で取得
+func (u *followUsecase) GetFeed(ctx context.Context, userID string, before string, limit int) (*model.FeedPage, error) {
+	if limit < 0 || limit > model.MaxFeedPageSize {
+		return nil, model.ErrInvalidRequest
+	}
+	if limit == 0 {
+		limit = model.DefaultFeedPageSize
+	}
+	// カーソルは商品IDのULIDのみ受け付ける
+	if before != "" {
+		if _, err := ulid.Parse(before); err != nil {
+			return nil, fmt.Errorf("%w: invalid cursor", model.ErrInvalidRequest)
+		}
+	}
+
+	// 次ページの有無を判定するために1件多く取得
+	items, err := u.itemDAO.GetFollowingFeed(ctx, userID, before, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed: %w", err)
+	}
+
+	page := &model.FeedPage{Items: items}
+	if len(items) > limit {
+		page.HasMore = true
+		page.Items = items[:limit]
+		page.NextCursor = page.Items[limit-1].ItemId
+	}
+	return page, nil
+}
+
+// notifyFollowers : 出品者のフォロワーに新着出品を通知する
+// 出品処理とは非同期に呼ばれるため、失敗はログに残すだけにする
+func notifyFollowers(ctx context.Context, followDAO dao.FollowDAO, notificationDAO dao.NotificationDAO, item *model.Item) {
+	followerIDs, err := followDAO.GetFollowerIDs(ctx, item.UserId)
+	if err != nil {
+		log.Printf("Warning: failed to get followers: %v", err)
+		return
+	}
+
+	for _, followerID := range followerIDs {
+		notification := &model.Notification{
+			UserId:   followerID,
+			Type:     model.NotificationTypeNewListing,
+			ItemId:   item.ItemId,
+			ItemName: item.Name,
+			Message:  fmt.Sprintf("フォロー中の出品者が%sを出品しました", item.Name),
+		}
+		if err := notificationDAO.CreateNotification(ctx, notification); err != nil {
+			log.Printf("Warning: failed to create notification: %v", err)
+		}
+	}
+}
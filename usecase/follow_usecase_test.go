@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// MockFollowDAO : dao.FollowDAO のモック
+type MockFollowDAO struct {
+	FollowFunc          func(ctx context.Context, followerID, followeeID string) error
+	UnfollowFunc        func(ctx context.Context, followerID, followeeID string) error
+	IsFollowingFunc     func(ctx context.Context, followerID, followeeID string) (bool, error)
+	GetFollowersFunc    func(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error)
+	GetFollowingFunc    func(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error)
+	GetFollowerIDsFunc  func(ctx context.Context, userID string) ([]string, error)
+	GetFollowCountsFunc func(ctx context.Context, userID string) (*model.FollowCounts, error)
+}
+
+func (m *MockFollowDAO) Follow(ctx context.Context, followerID, followeeID string) error {
+	if m.FollowFunc != nil {
+		return m.FollowFunc(ctx, followerID, followeeID)
+	}
+	return nil
+}
+
+func (m *MockFollowDAO) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	if m.UnfollowFunc != nil {
+		return m.UnfollowFunc(ctx, followerID, followeeID)
+	}
+	return nil
+}
+
+func (m *MockFollowDAO) IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error) {
+	if m.IsFollowingFunc != nil {
+		return m.IsFollowingFunc(ctx, followerID, followeeID)
+	}
+	return false, nil
+}
+
+func (m *MockFollowDAO) GetFollowers(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error) {
+	if m.GetFollowersFunc != nil {
+		return m.GetFollowersFunc(ctx, userID, limit, offset)
+	}
+	return []model.FollowUser{}, nil
+}
+
+func (m *MockFollowDAO) GetFollowing(ctx context.Context, userID string, limit int, offset int) ([]model.FollowUser, error) {
+	if m.GetFollowingFunc != nil {
+		return m.GetFollowingFunc(ctx, userID, limit, offset)
+	}
+	return []model.FollowUser{}, nil
+}
+
+func (m *MockFollowDAO) GetFollowerIDs(ctx context.Context, userID string) ([]string, error) {
+	if m.GetFollowerIDsFunc != nil {
+		return m.GetFollowerIDsFunc(ctx, userID)
+	}
+	return []string{}, nil
+}
+
+func (m *MockFollowDAO) GetFollowCounts(ctx context.Context, userID string) (*model.FollowCounts, error) {
+	if m.GetFollowCountsFunc != nil {
+		return m.GetFollowCountsFunc(ctx, userID)
+	}
+	return &model.FollowCounts{}, nil
+}
+
+// MockUserDAO : dao.UserDAO のモック
+type MockUserDAO struct {
+	ListFunc       func(ctx context.Context) ([]model.User, error)
+	DBInsertFunc   func(ctx context.Context, user *model.User) error
+	GetUserFunc    func(ctx context.Context, id string) (*model.User, error)
+	UpdateUserFunc func(ctx context.Context, user *model.User, clearHandle bool) error
+	DeleteUserFunc func(ctx context.Context, id string) ([]string, error)
+
+	GetUserByHandleFunc     func(ctx context.Context, handle string) (*model.User, error)
+	GetMentionedUserIDsFunc func(ctx context.Context, authorID string, handles []string) ([]string, error)
+}
+
+func (m *MockUserDAO) List(ctx context.Context) ([]model.User, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return []model.User{}, nil
+}
+
+func (m *MockUserDAO) DBInsert(ctx context.Context, user *model.User) error {
+	if m.DBInsertFunc != nil {
+		return m.DBInsertFunc(ctx, user)
+	}
+	return nil
+}
+
+func (m *MockUserDAO) GetUser(ctx context.Context, id string) (*model.User, error) {
+	if m.GetUserFunc != nil {
+		return m.GetUserFunc(ctx, id)
+	}
+	return &model.User{Id: id}, nil
+}
+
+func (m *MockUserDAO) GetUserByHandle(ctx context.Context, handle string) (*model.User, error) {
+	if m.GetUserByHandleFunc != nil {
+		return m.GetUserByHandleFunc(ctx, handle)
+	}
+	return nil, model.ErrUserNotFound
+}
+
+func (m *MockUserDAO) GetMentionedUserIDs(ctx context.Context, authorID string, handles []string) ([]string, error) {
+	if m.GetMentionedUserIDsFunc != nil {
+		return m.GetMentionedUserIDsFunc(ctx, authorID, handles)
+	}
+	return []string{}, nil
+}
+
+func (m *MockUserDAO) UpdateUser(ctx context.Context, user *model.User, clearHandle bool) error {
+	if m.UpdateUserFunc != nil {
+		return m.UpdateUserFunc(ctx, user, clearHandle)
+	}
+	return nil
+}
+
+func (m *MockUserDAO) DeleteUser(ctx context.Context, id string) ([]string, error) {
+	if m.DeleteUserFunc != nil {
+		return m.DeleteUserFunc(ctx, id)
+	}
+	return []string{}, nil
+}
+
+func TestFollowUsecase_Follow(t *testing.T) {
+	tests := []struct {
+		name         string
+		followeeID   string
+		userErr      error
+		wantErr      error
+		wantFollowed bool
+	}{
+		{name: "成功: フォローしてフォロワー数を返す", followeeID: "seller1", wantFollowed: true},
+		{name: "失敗: 自分自身", followeeID: "user1", wantErr: model.ErrCannotFollowSelf},
+		{name: "失敗: 存在しないユーザー", followeeID: "unknown", userErr: model.ErrUserNotFound, wantErr: model.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			followed := false
+			mockFollowDAO := &MockFollowDAO{
+				FollowFunc: func(ctx context.Context, followerID, followeeID string) error {
+					followed = true
+					return nil
+				},
+				GetFollowCountsFunc: func(ctx context.Context, userID string) (*model.FollowCounts, error) {
+					return &model.FollowCounts{Followers: 5, Following: 1}, nil
+				},
+			}
+			mockUserDAO := &MockUserDAO{
+				GetUserFunc: func(ctx context.Context, id string) (*model.User, error) {
+					if tt.userErr != nil {
+						return nil, tt.userErr
+					}
+					return &model.User{Id: id}, nil
+				},
+			}
+
+			u := NewFollowUsecase(mockFollowDAO, mockUserDAO, &MockItemDAO{}, &MockBlockDAO{})
+			state, err := u.Follow(context.Background(), "user1", tt.followeeID)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Follow() error = %v, want %v", err, tt.wantErr)
+			}
+			if followed != tt.wantFollowed {
+				t.Errorf("FollowDAO.Follow called = %v, want %v", followed, tt.wantFollowed)
+			}
+			if tt.wantErr == nil && (!state.Following || state.FollowerCount != 5) {
+				t.Errorf("Follow() = %+v, want following with 5 followers", state)
+			}
+		})
+	}
+}
+
+func TestFollowUsecase_GetFeed(t *testing.T) {
+	// ULIDの新しい順
+	feed := []model.FeedItem{
+		{ItemSimple: model.ItemSimple{ItemId: "01HZZZZZZZZZZZZZZZZZZZZZZ3"}},
+		{ItemSimple: model.ItemSimple{ItemId: "01HZZZZZZZZZZZZZZZZZZZZZZ2"}},
+		{ItemSimple: model.ItemSimple{ItemId: "01HZZZZZZZZZZZZZZZZZZZZZZ1"}},
+	}
+
+	tests := []struct {
+		name           string
+		before         string
+		limit          int
+		wantErr        error
+		wantIDs        []string
+		wantNextCursor string
+	}{
+		{name: "成功: 続きがある", limit: 2, wantIDs: []string{feed[0].ItemId, feed[1].ItemId}, wantNextCursor: feed[1].ItemId},
+		{name: "成功: 最後のページ", limit: 3, wantIDs: []string{feed[0].ItemId, feed[1].ItemId, feed[2].ItemId}},
+		{name: "失敗: 不正なカーソル", before: "not-a-ulid", limit: 2, wantErr: model.ErrInvalidRequest},
+		{name: "失敗: 件数の上限超え", limit: model.MaxFeedPageSize + 1, wantErr: model.ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockItemDAO := &MockItemDAO{
+				GetFollowingFeedFunc: func(ctx context.Context, userID string, before string, limit int) ([]model.FeedItem, error) {
+					if limit > len(feed) {
+						limit = len(feed)
+					}
+					return feed[:limit], nil
+				},
+			}
+
+			u := NewFollowUsecase(&MockFollowDAO{}, &MockUserDAO{}, mockItemDAO, &MockBlockDAO{})
+			page, err := u.GetFeed(context.Background(), "user1", tt.before, tt.limit)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetFeed() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			gotIDs := make([]string, len(page.Items))
+			for i, item := range page.Items {
+				gotIDs[i] = item.ItemId
+			}
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("GetFeed() items = %v, want %v", gotIDs, tt.wantIDs)
+			}
+			if page.NextCursor != tt.wantNextCursor || page.HasMore != (tt.wantNextCursor != "") {
+				t.Errorf("GetFeed() next = %q has_more = %v, want %q", page.NextCursor, page.HasMore, tt.wantNextCursor)
+			}
+		})
+	}
+}
+
+func TestNotifyFollowers(t *testing.T) {
+	item := &model.Item{ItemId: "item1", UserId: "seller1", Name: "Test Item"}
+	mockFollowDAO := &MockFollowDAO{
+		GetFollowerIDsFunc: func(ctx context.Context, userID string) ([]string, error) {
+			if userID != "seller1" {
+				t.Errorf("GetFollowerIDs(%q), want seller1", userID)
+			}
+			return []string{"user1", "user2"}, nil
+		},
+	}
+	var recipients []string
+	mockNotificationDAO := &MockNotificationDAO{
+		CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+			if notification.Type != model.NotificationTypeNewListing || notification.ItemId != "item1" {
+				t.Errorf("unexpected notification %+v", notification)
+			}
+			recipients = append(recipients, notification.UserId)
+			return nil
+		},
+	}
+
+	notifyFollowers(context.Background(), mockFollowDAO, mockNotificationDAO, item)
+
+	if want := []string{"user1", "user2"}; !slices.Equal(recipients, want) {
+		t.Errorf("notification recipients = %v, want %v", recipients, want)
+	}
+}
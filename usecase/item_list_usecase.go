@@ -4,34 +4,54 @@ import (
 	"context"
 	"db/dao"
 	"db/model"
+	"db/search"
 	"fmt"
 	"strings"
 )
 
+// keywordCandidateLimit : 検索インデックスから取り出す候補の上限
+// これを超えてヒットした場合、関連度の低い商品は検索結果に出ず、Totalも絞り込み後の候補数で頭打ちになる
+const keywordCandidateLimit = 1000
+
 type ItemList interface {
-	GetItems(ctx context.Context, limit int, offset int) ([]model.ItemSimple, error)
-	SearchItems(ctx context.Context, keyword string, limit int, offset int) ([]model.ItemSimple, error)
+	GetItems(ctx context.Context, filter model.ItemFilter, limit int, offset int) (*model.ItemListResult, error)
+	SearchItems(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) (*model.ItemListResult, error)
 }
 
 type itemList struct {
-	itemDAO dao.ItemDAO
+	itemDAO     dao.ItemDAO
+	categoryDAO dao.CategoryDAO
+	blockDAO    dao.BlockDAO
+	searchIndex search.Index
 }
 
-func NewItemList(dao dao.ItemDAO) ItemList {
-	return &itemList{itemDAO: dao}
+// NewItemList : searchIndexがnilの場合、キーワード検索はDBのLIKE検索で行う
+func NewItemList(dao dao.ItemDAO, categoryDAO dao.CategoryDAO, blockDAO dao.BlockDAO, searchIndex search.Index) ItemList {
+	return &itemList{itemDAO: dao, categoryDAO: categoryDAO, blockDAO: blockDAO, searchIndex: searchIndex}
 }
 
-func (us *itemList) GetItems(ctx context.Context, limit int, offset int) ([]model.ItemSimple, error) {
+func (us *itemList) GetItems(ctx context.Context, filter model.ItemFilter, limit int, offset int) (*model.ItemListResult, error) {
+	if err := resolveCategoryFilter(ctx, us.categoryDAO, &filter); err != nil {
+		return nil, err
+	}
+	if err := resolveBlockFilter(ctx, us.blockDAO, &filter); err != nil {
+		return nil, err
+	}
 
-	items, err := us.itemDAO.GetItemList(ctx, limit, offset)
+	items, err := us.itemDAO.GetItemList(ctx, filter, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("fail:itemDAO.GetItemList: %w", err)
 	}
 
-	return items, nil
+	total, err := us.itemDAO.CountItems(ctx, "", filter)
+	if err != nil {
+		return nil, fmt.Errorf("fail:itemDAO.CountItems: %w", err)
+	}
+
+	return &model.ItemListResult{Items: items, Total: total}, nil
 }
 
-func (us *itemList) SearchItems(ctx context.Context, keyword string, limit int, offset int) ([]model.ItemSimple, error) {
+func (us *itemList) SearchItems(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) (*model.ItemListResult, error) {
 	// キーワードの前後の空白を削除
 	keyword = strings.TrimSpace(keyword)
 
@@ -39,10 +59,130 @@ func (us *itemList) SearchItems(ctx context.Context, keyword string, limit int,
 		return nil, fmt.Errorf("keyword is required")
 	}
 
-	items, err := us.itemDAO.SearchItems(ctx, keyword, limit, offset)
+	if err := resolveCategoryFilter(ctx, us.categoryDAO, &filter); err != nil {
+		return nil, err
+	}
+	if err := resolveBlockFilter(ctx, us.blockDAO, &filter); err != nil {
+		return nil, err
+	}
+
+	if us.searchIndex != nil {
+		return us.searchByIndex(ctx, keyword, filter, limit, offset)
+	}
+
+	items, err := us.itemDAO.SearchItems(ctx, keyword, filter, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("fail:itemDAO.SearchItems: %w", err)
 	}
 
-	return items, nil
+	total, err := us.itemDAO.CountItems(ctx, keyword, filter)
+	if err != nil {
+		return nil, fmt.Errorf("fail:itemDAO.CountItems: %w", err)
+	}
+
+	return &model.ItemListResult{Items: items, Total: total}, nil
+}
+
+// searchByIndex : 検索インデックスで候補を選び、DBで絞り込み条件を適用する
+// 並び順の指定がなければ関連度順、指定があればその順に並べる
+func (us *itemList) searchByIndex(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) (*model.ItemListResult, error) {
+	rankedIDs, err := searchIndexIDs(ctx, us.searchIndex, keyword)
+	if err != nil {
+		return nil, err
+	}
+	if len(rankedIDs) == 0 {
+		return &model.ItemListResult{Items: []model.ItemSimple{}, Total: 0}, nil
+	}
+
+	if filter.Sort == "" {
+		return pageRankedItems(ctx, us.itemDAO, rankedIDs, filter, limit, offset)
+	}
+
+	filter.ItemIds = rankedIDs
+	items, err := us.itemDAO.GetItemList(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("fail:itemDAO.GetItemList: %w", err)
+	}
+	total, err := us.itemDAO.CountItems(ctx, "", filter)
+	if err != nil {
+		return nil, fmt.Errorf("fail:itemDAO.CountItems: %w", err)
+	}
+
+	return &model.ItemListResult{Items: items, Total: total}, nil
+}
+
+// searchIndexIDs : 検索インデックスで関連度の高い順に最大keywordCandidateLimit件の商品IDを返す
+func searchIndexIDs(ctx context.Context, searchIndex search.Index, keyword string) ([]string, error) {
+	hits, err := searchIndex.Search(ctx, keyword, keywordCandidateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("fail:searchIndex.Search: %w", err)
+	}
+
+	ids := make([]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ItemId
+	}
+	return ids, nil
+}
+
+// filterRankedIDs : 順位付けした商品IDのうち一覧と同じ絞り込み条件に合うものを、順位を保ったまま返す
+func filterRankedIDs(ctx context.Context, itemDAO dao.ItemDAO, rankedIDs []string, filter model.ItemFilter) ([]string, error) {
+	matchedIDs, err := itemDAO.FilterItemIDs(ctx, rankedIDs, filter)
+	if err != nil {
+		return nil, fmt.Errorf("fail:itemDAO.FilterItemIDs: %w", err)
+	}
+	matched := make(map[string]bool, len(matchedIDs))
+	for _, id := range matchedIDs {
+		matched[id] = true
+	}
+	filteredIDs := make([]string, 0, len(matchedIDs))
+	for _, id := range rankedIDs {
+		if matched[id] {
+			filteredIDs = append(filteredIDs, id)
+		}
+	}
+	return filteredIDs, nil
+}
+
+// pageRankedItems : 順位付けした商品IDに一覧と同じ絞り込み条件を適用し、順位を保ったままページングする
+func pageRankedItems(ctx context.Context, itemDAO dao.ItemDAO, rankedIDs []string, filter model.ItemFilter, limit int, offset int) (*model.ItemListResult, error) {
+	filteredIDs, err := filterRankedIDs(ctx, itemDAO, rankedIDs, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(filteredIDs)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	items, err := itemDAO.GetItemsByIDs(ctx, filteredIDs[offset:end])
+	if err != nil {
+		return nil, fmt.Errorf("fail:itemDAO.GetItemsByIDs: %w", err)
+	}
+
+	return &model.ItemListResult{Items: items, Total: total}, nil
+}
+
+// resolveCategoryFilter : カテゴリ指定を子孫カテゴリまで展開する
+func resolveCategoryFilter(ctx context.Context, categoryDAO dao.CategoryDAO, filter *model.ItemFilter) error {
+	if filter.CategoryId == 0 {
+		return nil
+	}
+
+	categories, err := categoryDAO.GetCategories(ctx)
+	if err != nil {
+		return fmt.Errorf("fail:categoryDAO.GetCategories: %w", err)
+	}
+
+	filter.CategoryIds = model.DescendantCategoryIDs(categories, filter.CategoryId)
+	if filter.CategoryIds == nil {
+		return model.ErrCategoryNotFound
+	}
+
+	return nil
 }
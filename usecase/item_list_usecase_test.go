@@ -3,15 +3,21 @@ package usecase
 import (
 	"context"
 	"db/model"
+	"db/search"
 	"errors"
+	"reflect"
+	"slices"
 	"testing"
 )
 
 // MockItemDAO : dao.ItemDAO のモック
 type MockItemDAO struct {
 	// 各メソッドの戻り値を制御するための関数フィールドなどを必要に応じて追加
-	GetItemListFunc func(ctx context.Context, limit int, offset int) ([]model.ItemSimple, error)
-	SearchItemsFunc func(ctx context.Context, keyword string, limit int, offset int) ([]model.ItemSimple, error)
+	GetItemListFunc        func(ctx context.Context, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error)
+	SearchItemsFunc        func(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error)
+	CountItemsFunc         func(ctx context.Context, keyword string, filter model.ItemFilter) (int, error)
+	FilterItemIDsFunc      func(ctx context.Context, itemIDs []string, filter model.ItemFilter) ([]string, error)
+	GetSearchDocumentsFunc func(ctx context.Context) ([]model.ItemSearchDocument, error)
 
 	// 未使用メソッドのスタブ (コンパイルエラー回避のため)
 	ItemInsertFunc           func(ctx context.Context, item *model.Item) error
@@ -19,8 +25,13 @@ type MockItemDAO struct {
 	GetUserItemsFunc         func(ctx context.Context, userID string) ([]model.ItemSimple, error)
 	GetItemFunc              func(ctx context.Context, itemID string) (*model.Item, error)
 	GetItemsByIDsFunc        func(ctx context.Context, itemIDs []string) ([]model.ItemSimple, error)
-	PurchaseItemFunc         func(ctx context.Context, itemID string, buyerID string) error
-	UpdateItemFunc           func(ctx context.Context, itemID string, userID string, name string, price int, description string, imageURLs []string, embedding []float32) error
+	GetFollowingFeedFunc     func(ctx context.Context, userID string, before string, limit int) ([]model.FeedItem, error)
+	GetSellerItemStatsFunc   func(ctx context.Context, sellerID string) (*model.SellerItemStats, error)
+	GetPurchasedItemsFunc    func(ctx context.Context, buyerID string, limit int, offset int) ([]model.PurchasedItem, error)
+	GetSoldItemsFunc         func(ctx context.Context, sellerID string, limit int, offset int) ([]model.SoldItem, error)
+	PurchaseItemFunc         func(ctx context.Context, itemID string, buyerID string, orderID string, price int, reservationID string) error
+	UpdateItemFunc           func(ctx context.Context, item *model.Item) (int, error)
+	WithdrawItemFunc         func(ctx context.Context, itemID string, userID string) error
 	GetAllItemEmbeddingsFunc func(ctx context.Context) (map[string][]float32, error)
 	GetItemEmbeddingFunc     func(ctx context.Context, itemID string) ([]float32, error)
 }
@@ -32,20 +43,41 @@ func (m *MockItemDAO) ItemInsert(ctx context.Context, item *model.Item) error {
 	return nil
 }
 
-func (m *MockItemDAO) GetItemList(ctx context.Context, limit int, offset int) ([]model.ItemSimple, error) {
+func (m *MockItemDAO) GetItemList(ctx context.Context, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
 	if m.GetItemListFunc != nil {
-		return m.GetItemListFunc(ctx, limit, offset)
+		return m.GetItemListFunc(ctx, filter, limit, offset)
 	}
 	return nil, nil
 }
 
-func (m *MockItemDAO) SearchItems(ctx context.Context, keyword string, limit int, offset int) ([]model.ItemSimple, error) {
+func (m *MockItemDAO) SearchItems(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
 	if m.SearchItemsFunc != nil {
-		return m.SearchItemsFunc(ctx, keyword, limit, offset)
+		return m.SearchItemsFunc(ctx, keyword, filter, limit, offset)
 	}
 	return nil, nil
 }
 
+func (m *MockItemDAO) CountItems(ctx context.Context, keyword string, filter model.ItemFilter) (int, error) {
+	if m.CountItemsFunc != nil {
+		return m.CountItemsFunc(ctx, keyword, filter)
+	}
+	return 0, nil
+}
+
+func (m *MockItemDAO) FilterItemIDs(ctx context.Context, itemIDs []string, filter model.ItemFilter) ([]string, error) {
+	if m.FilterItemIDsFunc != nil {
+		return m.FilterItemIDsFunc(ctx, itemIDs, filter)
+	}
+	return itemIDs, nil
+}
+
+func (m *MockItemDAO) GetSearchDocuments(ctx context.Context) ([]model.ItemSearchDocument, error) {
+	if m.GetSearchDocumentsFunc != nil {
+		return m.GetSearchDocumentsFunc(ctx)
+	}
+	return []model.ItemSearchDocument{}, nil
+}
+
 func (m *MockItemDAO) GetMyItems(ctx context.Context, sellerID string) ([]model.ItemSimple, error) {
 	if m.GetMyItemsFunc != nil {
 		return m.GetMyItemsFunc(ctx, sellerID)
@@ -67,6 +99,20 @@ func (m *MockItemDAO) GetItem(ctx context.Context, itemID string) (*model.Item,
 	return nil, nil
 }
 
+func (m *MockItemDAO) GetFollowingFeed(ctx context.Context, userID string, before string, limit int) ([]model.FeedItem, error) {
+	if m.GetFollowingFeedFunc != nil {
+		return m.GetFollowingFeedFunc(ctx, userID, before, limit)
+	}
+	return []model.FeedItem{}, nil
+}
+
+func (m *MockItemDAO) GetSellerItemStats(ctx context.Context, sellerID string) (*model.SellerItemStats, error) {
+	if m.GetSellerItemStatsFunc != nil {
+		return m.GetSellerItemStatsFunc(ctx, sellerID)
+	}
+	return &model.SellerItemStats{}, nil
+}
+
 func (m *MockItemDAO) GetItemsByIDs(ctx context.Context, itemIDs []string) ([]model.ItemSimple, error) {
 	if m.GetItemsByIDsFunc != nil {
 		return m.GetItemsByIDsFunc(ctx, itemIDs)
@@ -74,16 +120,37 @@ func (m *MockItemDAO) GetItemsByIDs(ctx context.Context, itemIDs []string) ([]mo
 	return nil, nil
 }
 
-func (m *MockItemDAO) PurchaseItem(ctx context.Context, itemID string, buyerID string) error {
+func (m *MockItemDAO) GetPurchasedItems(ctx context.Context, buyerID string, limit int, offset int) ([]model.PurchasedItem, error) {
+	if m.GetPurchasedItemsFunc != nil {
+		return m.GetPurchasedItemsFunc(ctx, buyerID, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockItemDAO) GetSoldItems(ctx context.Context, sellerID string, limit int, offset int) ([]model.SoldItem, error) {
+	if m.GetSoldItemsFunc != nil {
+		return m.GetSoldItemsFunc(ctx, sellerID, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockItemDAO) PurchaseItem(ctx context.Context, itemID string, buyerID string, orderID string, price int, reservationID string) error {
 	if m.PurchaseItemFunc != nil {
-		return m.PurchaseItemFunc(ctx, itemID, buyerID)
+		return m.PurchaseItemFunc(ctx, itemID, buyerID, orderID, price, reservationID)
 	}
 	return nil
 }
 
-func (m *MockItemDAO) UpdateItem(ctx context.Context, itemID string, userID string, name string, price int, description string, imageURLs []string, embedding []float32) error {
+func (m *MockItemDAO) UpdateItem(ctx context.Context, item *model.Item) (int, error) {
 	if m.UpdateItemFunc != nil {
-		return m.UpdateItemFunc(ctx, itemID, userID, name, price, description, imageURLs, embedding)
+		return m.UpdateItemFunc(ctx, item)
+	}
+	return item.Price, nil
+}
+
+func (m *MockItemDAO) WithdrawItem(ctx context.Context, itemID string, userID string) error {
+	if m.WithdrawItemFunc != nil {
+		return m.WithdrawItemFunc(ctx, itemID, userID)
 	}
 	return nil
 }
@@ -109,29 +176,49 @@ func TestItemList_GetItems(t *testing.T) {
 	}
 
 	tests := []struct {
-		name    string
-		mockDAO *MockItemDAO
-		limit   int
-		offset  int
-		want    []model.ItemSimple
-		wantErr bool
+		name      string
+		mockDAO   *MockItemDAO
+		limit     int
+		offset    int
+		want      []model.ItemSimple
+		wantTotal int
+		wantErr   bool
 	}{
 		{
 			name: "成功: 一覧取得",
 			mockDAO: &MockItemDAO{
-				GetItemListFunc: func(ctx context.Context, limit int, offset int) ([]model.ItemSimple, error) {
+				GetItemListFunc: func(ctx context.Context, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
+					return mockItems, nil
+				},
+				CountItemsFunc: func(ctx context.Context, keyword string, filter model.ItemFilter) (int, error) {
+					return 42, nil
+				},
+			},
+			limit:     10,
+			offset:    0,
+			want:      mockItems,
+			wantTotal: 42,
+			wantErr:   false,
+		},
+		{
+			name: "失敗: 件数取得エラー",
+			mockDAO: &MockItemDAO{
+				GetItemListFunc: func(ctx context.Context, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
 					return mockItems, nil
 				},
+				CountItemsFunc: func(ctx context.Context, keyword string, filter model.ItemFilter) (int, error) {
+					return 0, errors.New("count error")
+				},
 			},
 			limit:   10,
 			offset:  0,
-			want:    mockItems,
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "失敗: DAOエラー",
 			mockDAO: &MockItemDAO{
-				GetItemListFunc: func(ctx context.Context, limit int, offset int) ([]model.ItemSimple, error) {
+				GetItemListFunc: func(ctx context.Context, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
 					return nil, errors.New("db error")
 				},
 			},
@@ -144,15 +231,18 @@ func TestItemList_GetItems(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			u := NewItemList(tt.mockDAO)
-			got, err := u.GetItems(context.Background(), tt.limit, tt.offset)
+			u := NewItemList(tt.mockDAO, &MockCategoryDAO{}, &MockBlockDAO{}, nil)
+			got, err := u.GetItems(context.Background(), model.ItemFilter{}, tt.limit, tt.offset)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetItems() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && len(got) != len(tt.want) {
-				t.Errorf("GetItems() got length = %v, want length %v", len(got), len(tt.want))
+			if !tt.wantErr && len(got.Items) != len(tt.want) {
+				t.Errorf("GetItems() got length = %v, want length %v", len(got.Items), len(tt.want))
+			}
+			if !tt.wantErr && got.Total != tt.wantTotal {
+				t.Errorf("GetItems() total = %v, want %v", got.Total, tt.wantTotal)
 			}
 		})
 	}
@@ -175,7 +265,7 @@ func TestItemList_SearchItems(t *testing.T) {
 		{
 			name: "成功: 検索成功",
 			mockDAO: &MockItemDAO{
-				SearchItemsFunc: func(ctx context.Context, keyword string, limit int, offset int) ([]model.ItemSimple, error) {
+				SearchItemsFunc: func(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
 					if keyword == "test" {
 						return mockItems, nil
 					}
@@ -191,7 +281,7 @@ func TestItemList_SearchItems(t *testing.T) {
 		{
 			name: "失敗: キーワード空",
 			mockDAO: &MockItemDAO{
-				SearchItemsFunc: func(ctx context.Context, keyword string, limit int, offset int) ([]model.ItemSimple, error) {
+				SearchItemsFunc: func(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
 					return nil, nil // 呼ばれないはず
 				},
 			},
@@ -204,7 +294,7 @@ func TestItemList_SearchItems(t *testing.T) {
 		{
 			name: "失敗: DAOエラー",
 			mockDAO: &MockItemDAO{
-				SearchItemsFunc: func(ctx context.Context, keyword string, limit int, offset int) ([]model.ItemSimple, error) {
+				SearchItemsFunc: func(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
 					return nil, errors.New("search error")
 				},
 			},
@@ -218,15 +308,131 @@ func TestItemList_SearchItems(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			u := NewItemList(tt.mockDAO)
-			got, err := u.SearchItems(context.Background(), tt.keyword, tt.limit, tt.offset)
+			u := NewItemList(tt.mockDAO, &MockCategoryDAO{}, &MockBlockDAO{}, nil)
+			got, err := u.SearchItems(context.Background(), tt.keyword, model.ItemFilter{}, tt.limit, tt.offset)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SearchItems() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && len(got) != len(tt.want) {
-				t.Errorf("SearchItems() got length = %v, want length %v", len(got), len(tt.want))
+			if !tt.wantErr && len(got.Items) != len(tt.want) {
+				t.Errorf("SearchItems() got length = %v, want length %v", len(got.Items), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestItemList_SearchItems_Index(t *testing.T) {
+	docs := []model.ItemSearchDocument{
+		{ItemId: "01", Name: "ダウンジャケット", Description: "冬用"},
+		{ItemId: "02", Name: "レザーコート", Description: "ジャケットにも合う"},
+		{ItemId: "03", Name: "スニーカー"},
+	}
+
+	tests := []struct {
+		name        string
+		keyword     string
+		filter      model.ItemFilter
+		excludedIDs []string // 絞り込み条件で除外される商品
+		wantIDs     []string
+		wantTotal   int
+		wantSQLSort bool
+	}{
+		{name: "成功: 関連度順 (ひらがなでカタカナに一致)", keyword: "じゃけっと", wantIDs: []string{"01", "02"}, wantTotal: 2},
+		{name: "成功: 絞り込み条件を適用", keyword: "ジャケット", excludedIDs: []string{"01"}, wantIDs: []string{"02"}, wantTotal: 1},
+		{name: "成功: 並び順の指定はDBで並べる", keyword: "ジャケット", filter: model.ItemFilter{Sort: model.SortPriceAsc}, wantIDs: []string{"02", "01"}, wantTotal: 2, wantSQLSort: true},
+		{name: "成功: 一致なし", keyword: "テレビ", wantIDs: []string{}, wantTotal: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sqlFilterIDs []string
+			mockDAO := &MockItemDAO{
+				GetSearchDocumentsFunc: func(ctx context.Context) ([]model.ItemSearchDocument, error) {
+					return docs, nil
+				},
+				SearchItemsFunc: func(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
+					t.Errorf("SearchItems() (LIKE search) should not be called when the index is enabled")
+					return nil, nil
+				},
+				FilterItemIDsFunc: func(ctx context.Context, itemIDs []string, filter model.ItemFilter) ([]string, error) {
+					ids := make([]string, 0)
+					for _, id := range itemIDs {
+						if !slices.Contains(tt.excludedIDs, id) {
+							ids = append(ids, id)
+						}
+					}
+					return ids, nil
+				},
+				GetItemsByIDsFunc: func(ctx context.Context, itemIDs []string) ([]model.ItemSimple, error) {
+					items := make([]model.ItemSimple, len(itemIDs))
+					for i, id := range itemIDs {
+						items[i] = model.ItemSimple{ItemId: id}
+					}
+					return items, nil
+				},
+				GetItemListFunc: func(ctx context.Context, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
+					sqlFilterIDs = filter.ItemIds
+					return []model.ItemSimple{{ItemId: "02"}, {ItemId: "01"}}, nil
+				},
+				CountItemsFunc: func(ctx context.Context, keyword string, filter model.ItemFilter) (int, error) {
+					return 2, nil
+				},
+			}
+
+			u := NewItemList(mockDAO, &MockCategoryDAO{}, &MockBlockDAO{}, search.NewInvertedIndex(mockDAO))
+			got, err := u.SearchItems(context.Background(), tt.keyword, tt.filter, 10, 0)
+			if err != nil {
+				t.Fatalf("SearchItems() error = %v", err)
+			}
+
+			gotIDs := make([]string, len(got.Items))
+			for i, item := range got.Items {
+				gotIDs[i] = item.ItemId
+			}
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("SearchItems() ids = %v, want %v", gotIDs, tt.wantIDs)
+			}
+			if got.Total != tt.wantTotal {
+				t.Errorf("SearchItems() total = %v, want %v", got.Total, tt.wantTotal)
+			}
+			if tt.wantSQLSort && len(sqlFilterIDs) != 2 {
+				t.Errorf("filter.ItemIds = %v, want the index hits", sqlFilterIDs)
+			}
+		})
+	}
+}
+
+func TestItemList_ExcludesBlockedSellers(t *testing.T) {
+	tests := []struct {
+		name        string
+		viewerID    string
+		wantExclude []string
+	}{
+		{name: "成功: ブロック関係の出品者を除外", viewerID: "user1", wantExclude: []string{"seller1"}},
+		{name: "成功: ゲストは除外しない", viewerID: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var listFilter, countFilter model.ItemFilter
+			mockItemDAO := &MockItemDAO{
+				GetItemListFunc: func(ctx context.Context, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
+					listFilter = filter
+					return []model.ItemSimple{}, nil
+				},
+				CountItemsFunc: func(ctx context.Context, keyword string, filter model.ItemFilter) (int, error) {
+					countFilter = filter
+					return 0, nil
+				},
+			}
+
+			u := NewItemList(mockItemDAO, &MockCategoryDAO{}, newBlockingDAO(), nil)
+			if _, err := u.GetItems(context.Background(), model.ItemFilter{ViewerId: tt.viewerID}, 20, 0); err != nil {
+				t.Fatalf("GetItems() error = %v", err)
+			}
+			if !reflect.DeepEqual(listFilter.ExcludeSellerIds, tt.wantExclude) || !reflect.DeepEqual(countFilter.ExcludeSellerIds, tt.wantExclude) {
+				t.Errorf("ExcludeSellerIds = %v / %v, want %v", listFilter.ExcludeSellerIds, countFilter.ExcludeSellerIds, tt.wantExclude)
 			}
 		})
 	}
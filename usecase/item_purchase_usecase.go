@@ -14,48 +14,80 @@ import (
 )
 
 type ItemPurchase interface {
-	PurchaseItem(ctx context.Context, itemID string, buyerID string) error
+	PurchaseItem(ctx context.Context, itemID string, buyerID string) (string, error)
 }
 
 type itemPurchase struct {
 	itemDAO         dao.ItemDAO
+	offerDAO        dao.OfferDAO
 	notificationDAO dao.NotificationDAO
+	blockDAO        dao.BlockDAO
 	embeddingCache  *cache.EmbeddingCache
+	statsCache      *cache.SellerStatsCache
 }
 
-func NewItemPurchase(itemDAO dao.ItemDAO, notificationDAO dao.NotificationDAO, embeddingCache *cache.EmbeddingCache) ItemPurchase {
+func NewItemPurchase(itemDAO dao.ItemDAO, offerDAO dao.OfferDAO, notificationDAO dao.NotificationDAO, blockDAO dao.BlockDAO, embeddingCache *cache.EmbeddingCache, statsCache *cache.SellerStatsCache) ItemPurchase {
 	return &itemPurchase{
 		itemDAO:         itemDAO,
+		offerDAO:        offerDAO,
 		notificationDAO: notificationDAO,
+		blockDAO:        blockDAO,
 		embeddingCache:  embeddingCache,
+		statsCache:      statsCache,
 	}
 }
 
-func (u *itemPurchase) PurchaseItem(ctx context.Context, itemID string, buyerID string) error {
+// PurchaseItem : 商品を購入し、作成された取引のIDを返す
+// 価格交渉で合意済み(取り置き中)の場合は合意した購入者だけが、合意した金額で購入できる
+func (u *itemPurchase) PurchaseItem(ctx context.Context, itemID string, buyerID string) (string, error) {
 	// 商品が存在し、販売中かチェック
 	item, err := u.itemDAO.GetItem(ctx, itemID)
 	if err != nil {
-		return fmt.Errorf("item not found: %w", err)
+		return "", fmt.Errorf("item not found: %w", err)
 	}
 
 	if item.Status != model.StatusOnSale {
-		return fmt.Errorf("item is not available for purchase")
+		return "", fmt.Errorf("item is not available for purchase")
 	}
 
-	// 購入処理
-	if err := u.itemDAO.PurchaseItem(ctx, itemID, buyerID); err != nil {
-		return fmt.Errorf("failed to purchase item: %w", err)
+	// ブロック関係にある出品者の商品は購入できない
+	if err := checkNotBlocked(ctx, u.blockDAO, buyerID, item.UserId); err != nil {
+		return "", err
 	}
 
-	// 出品者に通知を作成
 	t := time.Now()
+
+	// 取り置き中なら合意した購入者と金額に限定する
+	price := item.Price
+	reservationID := ""
+	reservation, err := u.offerDAO.GetActiveReservation(ctx, itemID, t)
+	if err != nil {
+		return "", fmt.Errorf("failed to check reservation: %w", err)
+	}
+	if reservation != nil {
+		if reservation.BuyerId != buyerID {
+			return "", model.ErrItemReserved
+		}
+		price = reservation.Price
+		reservationID = reservation.Id
+	}
+
+	// 購入処理 (支払い待ちの取引を同時に作成し、取り置きはトランザクション内で確かめ直して購入済みにする)
 	entropy := ulid.Monotonic(rand.Reader, 0)
+	orderID := ulid.MustNew(ulid.Timestamp(t), entropy).String()
+
+	if err := u.itemDAO.PurchaseItem(ctx, itemID, buyerID, orderID, price, reservationID); err != nil {
+		return "", fmt.Errorf("failed to purchase item: %w", err)
+	}
+	// 検索インデックスは更新しない (売り切れの商品も検索でき、販売状態の絞り込みはDBで行うため)
+
+	// 出品者に通知を作成
 	notificationID := ulid.MustNew(ulid.Timestamp(t), entropy).String()
 
 	notification := &model.Notification{
 		Id:        notificationID,
 		UserId:    item.UserId, // 出品者
-		Type:      "purchase",
+		Type:      model.NotificationTypePurchase,
 		ItemId:    itemID,
 		ItemName:  item.Name,
 		Message:   fmt.Sprintf("%sが購入されました", item.Name),
@@ -70,6 +102,8 @@ func (u *itemPurchase) PurchaseItem(ctx context.Context, itemID string, buyerID
 
 	// キャッシュからベクトルを削除（おすすめから除外）
 	u.embeddingCache.Delete(itemID)
+	// 出品者の実績(売れた数など)を計算し直させる
+	u.statsCache.Invalidate(item.UserId)
 
-	return nil
+	return orderID, nil
 }
@@ -6,15 +6,17 @@ import (
 	"db/model"
 	"errors"
 	"testing"
+	"time"
 )
 
 // MockNotificationDAO : dao.NotificationDAO のモック
 type MockNotificationDAO struct {
-	CreateNotificationFunc   func(ctx context.Context, notification *model.Notification) error
-	GetUserNotificationsFunc func(ctx context.Context, userID string, limit int) ([]model.Notification, error)
-	GetUnreadCountFunc       func(ctx context.Context, userID string) (int, error)
-	MarkAsReadFunc           func(ctx context.Context, notificationID string, userID string) error
-	MarkAllAsReadFunc        func(ctx context.Context, userID string) error
+	CreateNotificationFunc              func(ctx context.Context, notification *model.Notification) error
+	GetUserNotificationsFunc            func(ctx context.Context, userID string, limit int) ([]model.Notification, error)
+	GetUnreadCountFunc                  func(ctx context.Context, userID string) (int, error)
+	MarkAsReadFunc                      func(ctx context.Context, notificationID string, userID string) error
+	MarkAllAsReadFunc                   func(ctx context.Context, userID string) error
+	MarkChatRoomNotificationsAsReadFunc func(ctx context.Context, userID string, chatRoomID string, messageID string) error
 }
 
 func (m *MockNotificationDAO) CreateNotification(ctx context.Context, notification *model.Notification) error {
@@ -52,6 +54,13 @@ func (m *MockNotificationDAO) MarkAllAsRead(ctx context.Context, userID string)
 	return nil
 }
 
+func (m *MockNotificationDAO) MarkChatRoomNotificationsAsRead(ctx context.Context, userID string, chatRoomID string, messageID string) error {
+	if m.MarkChatRoomNotificationsAsReadFunc != nil {
+		return m.MarkChatRoomNotificationsAsReadFunc(ctx, userID, chatRoomID, messageID)
+	}
+	return nil
+}
+
 func TestItemPurchase_PurchaseItem(t *testing.T) {
 	// Setup
 	validItem := &model.Item{
@@ -72,6 +81,7 @@ func TestItemPurchase_PurchaseItem(t *testing.T) {
 		itemID               string
 		buyerID              string
 		mockItemDAO          *MockItemDAO
+		mockOfferDAO         *MockOfferDAO
 		mockNotificationDAO  *MockNotificationDAO
 		wantErr              bool
 		wantEmbeddingDeleted bool
@@ -84,7 +94,10 @@ func TestItemPurchase_PurchaseItem(t *testing.T) {
 				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
 					return validItem, nil
 				},
-				PurchaseItemFunc: func(ctx context.Context, itemID string, buyerID string) error {
+				PurchaseItemFunc: func(ctx context.Context, itemID string, buyerID string, orderID string, price int, reservationID string) error {
+					if price != 1000 {
+						t.Errorf("PurchaseItem() price = %v, want listed price 1000", price)
+					}
 					return nil
 				},
 				GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
@@ -137,7 +150,7 @@ func TestItemPurchase_PurchaseItem(t *testing.T) {
 				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
 					return validItem, nil
 				},
-				PurchaseItemFunc: func(ctx context.Context, itemID string, buyerID string) error {
+				PurchaseItemFunc: func(ctx context.Context, itemID string, buyerID string, orderID string, price int, reservationID string) error {
 					return errors.New("db error")
 				},
 				GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
@@ -160,12 +173,20 @@ func TestItemPurchase_PurchaseItem(t *testing.T) {
 				embeddingCache.Set(tt.itemID, []float32{0.1, 0.2})
 			}
 
-			u := NewItemPurchase(tt.mockItemDAO, tt.mockNotificationDAO, embeddingCache)
-			err := u.PurchaseItem(context.Background(), tt.itemID, tt.buyerID)
+			mockOfferDAO := tt.mockOfferDAO
+			if mockOfferDAO == nil {
+				mockOfferDAO = &MockOfferDAO{}
+			}
+
+			u := NewItemPurchase(tt.mockItemDAO, mockOfferDAO, tt.mockNotificationDAO, &MockBlockDAO{}, embeddingCache, cache.NewSellerStatsCache(time.Minute))
+			orderID, err := u.PurchaseItem(context.Background(), tt.itemID, tt.buyerID)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("PurchaseItem() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if !tt.wantErr && orderID == "" {
+				t.Errorf("PurchaseItem() should return created order ID")
+			}
 
 			// キャッシュから削除されたか確認
 			if tt.wantEmbeddingDeleted && err == nil {
@@ -177,3 +198,88 @@ func TestItemPurchase_PurchaseItem(t *testing.T) {
 		})
 	}
 }
+
+func TestItemPurchase_PurchaseItem_Reservation(t *testing.T) {
+	item := &model.Item{ItemId: "item1", UserId: "seller1", Status: model.StatusOnSale, Price: 1000}
+	expiresAt := time.Now().Add(time.Hour)
+	reservation := &model.Offer{Id: "offer1", ItemId: "item1", BuyerId: "buyer1", SellerId: "seller1", Price: 800, Status: model.OfferStatusAccepted, ExpiresAt: &expiresAt}
+
+	tests := []struct {
+		name          string
+		buyerID       string
+		wantErr       error
+		wantPrice     int
+		wantPurchased bool
+	}{
+		{name: "成功: 合意した購入者は合意金額で購入", buyerID: "buyer1", wantPrice: 800, wantPurchased: true},
+		{name: "失敗: 他の購入者は取り置き中は購入できない", buyerID: "buyer2", wantErr: model.ErrItemReserved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paidPrice int
+			purchasedOffer := ""
+			mockItemDAO := &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return item, nil
+				},
+				PurchaseItemFunc: func(ctx context.Context, itemID string, buyerID string, orderID string, price int, reservationID string) error {
+					paidPrice = price
+					purchasedOffer = reservationID
+					return nil
+				},
+				GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+					return map[string][]float32{}, nil
+				},
+			}
+			mockOfferDAO := &MockOfferDAO{
+				GetActiveReservationFunc: func(ctx context.Context, itemID string, now time.Time) (*model.Offer, error) {
+					return reservation, nil
+				},
+			}
+
+			u := NewItemPurchase(mockItemDAO, mockOfferDAO, &MockNotificationDAO{}, &MockBlockDAO{}, cache.NewEmbeddingCache(mockItemDAO), cache.NewSellerStatsCache(time.Minute))
+			_, err := u.PurchaseItem(context.Background(), "item1", tt.buyerID)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PurchaseItem() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if paidPrice != 0 {
+					t.Errorf("PurchaseItem() should not purchase reserved item")
+				}
+				return
+			}
+			if paidPrice != tt.wantPrice {
+				t.Errorf("PurchaseItem() price = %v, want %v", paidPrice, tt.wantPrice)
+			}
+			if tt.wantPurchased && purchasedOffer != reservation.Id {
+				t.Errorf("PurchaseItem() reservationID = %v, want %v", purchasedOffer, reservation.Id)
+			}
+		})
+	}
+}
+
+func TestItemPurchase_BlockedSeller(t *testing.T) {
+	purchased := false
+	mockItemDAO := &MockItemDAO{
+		GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+			return &model.Item{ItemId: itemID, Name: "Test Item", UserId: "seller1", Status: model.StatusOnSale, Price: 1000}, nil
+		},
+		PurchaseItemFunc: func(ctx context.Context, itemID string, buyerID string, orderID string, price int, reservationID string) error {
+			purchased = true
+			return nil
+		},
+		GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+			return map[string][]float32{}, nil
+		},
+	}
+
+	u := NewItemPurchase(mockItemDAO, &MockOfferDAO{}, &MockNotificationDAO{}, newBlockingDAO(), cache.NewEmbeddingCache(mockItemDAO), cache.NewSellerStatsCache(time.Minute))
+	if _, err := u.PurchaseItem(context.Background(), "item1", "user1"); !errors.Is(err, model.ErrUserBlocked) {
+		t.Fatalf("PurchaseItem() error = %v, want %v", err, model.ErrUserBlocked)
+	}
+	if purchased {
+		t.Errorf("should not purchase blocked seller's item")
+	}
+}
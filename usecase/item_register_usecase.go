@@ -6,9 +6,11 @@ import (
 	"db/cache"
 	"db/dao"
 	"db/model"
+	"db/search"
 	"db/service"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/oklog/ulid"
@@ -21,13 +23,20 @@ type ItemRegister interface {
 }
 
 type itemRegister struct {
-	itemDAO       dao.ItemDAO
-	geminiService service.GeminiService
-	embeddingCache *cache.EmbeddingCache
+	itemDAO         dao.ItemDAO
+	categoryDAO     dao.CategoryDAO
+	geminiService   service.GeminiService
+	embeddingCache  *cache.EmbeddingCache
+	searchIndex     search.Index
+	savedSearchDAO  dao.SavedSearchDAO
+	notificationDAO dao.NotificationDAO
+	followDAO       dao.FollowDAO
+	userDAO         dao.UserDAO
 }
 
-func NewItemRegister(dao dao.ItemDAO, geminiService service.GeminiService, embeddingCache *cache.EmbeddingCache) ItemRegister {
-	return &itemRegister{itemDAO: dao, geminiService: geminiService, embeddingCache: embeddingCache}
+// NewItemRegister : searchIndexがnilなら出品時のインデックス登録を省く
+func NewItemRegister(dao dao.ItemDAO, categoryDAO dao.CategoryDAO, geminiService service.GeminiService, embeddingCache *cache.EmbeddingCache, searchIndex search.Index, savedSearchDAO dao.SavedSearchDAO, notificationDAO dao.NotificationDAO, followDAO dao.FollowDAO, userDAO dao.UserDAO) ItemRegister {
+	return &itemRegister{itemDAO: dao, categoryDAO: categoryDAO, geminiService: geminiService, embeddingCache: embeddingCache, searchIndex: searchIndex, savedSearchDAO: savedSearchDAO, notificationDAO: notificationDAO, followDAO: followDAO, userDAO: userDAO}
 }
 
 func (us *itemRegister) RegisterItem(ctx context.Context, uid string, req *model.ItemCreateRequest) (string, error) {
@@ -35,6 +44,16 @@ func (us *itemRegister) RegisterItem(ctx context.Context, uid string, req *model
 	if !req.IsValid() {
 		return "", ErrInvalidItemRequest
 	}
+	// カテゴリは任意だが、指定する場合は存在するものに限る
+	if req.CategoryId != 0 {
+		exists, err := us.categoryDAO.CategoryExists(ctx, req.CategoryId)
+		if err != nil {
+			return "", fmt.Errorf("fail:categoryDAO.CategoryExists: %w", err)
+		}
+		if !exists {
+			return "", fmt.Errorf("%w: %v", ErrInvalidItemRequest, model.ErrCategoryNotFound)
+		}
+	}
 	// 商品説明をベクトル化
 	textToEmbed := fmt.Sprintf("%s\n%s", req.Name, req.Description)
 	embedding, err := us.geminiService.GenerateEmbedding(ctx, textToEmbed)
@@ -47,23 +66,65 @@ func (us *itemRegister) RegisterItem(ctx context.Context, uid string, req *model
 	newItemID := ulid.MustNew(ulid.Timestamp(t), entropy).String()
 
 	newItem := model.Item{
-		ItemId:      newItemID,
-		UserId:      uid,
-		Name:        req.Name,
-		Price:       req.Price,
-		Description: req.Description,
-		Embedding:   embedding,
-		ImageURLs:   req.ImageURLs,
-		CreatedAt:   t,
-		UpdatedAt:   t,
+		ItemId:         newItemID,
+		UserId:         uid,
+		Name:           req.Name,
+		Price:          req.Price,
+		Description:    req.Description,
+		Embedding:      embedding,
+		ImageURLs:      req.ImageURLs,
+		CategoryId:     req.CategoryId,
+		Condition:      req.Condition,
+		Brand:          req.Brand,
+		ShippingPayer:  req.ShippingPayer,
+		ShippingMethod: req.ShippingMethod,
+		CreatedAt:      t,
+		UpdatedAt:      t,
 	}
 	err = us.itemDAO.ItemInsert(ctx, &newItem)
 	if err != nil {
 		return "", fmt.Errorf("fail:itemDAO.ItemInsert: %w", err)
 	}
 
-	// キャッシュも即時更新
+	// キャッシュと検索インデックスも即時更新
 	us.embeddingCache.Set(newItemID, embedding)
+	if us.searchIndex != nil {
+		us.searchIndex.Upsert(model.ItemSearchDocument{ItemId: newItemID, Name: req.Name, Description: req.Description})
+	}
+
+	// フォロワーへの通知・保存した検索条件との照合・メンションの通知は、出品のレスポンスを待たせないよう非同期で行う
+	notifyCtx := context.WithoutCancel(ctx)
+	go func() {
+		notifyFollowers(notifyCtx, us.followDAO, us.notificationDAO, &newItem)
+		notifySavedSearchMatches(notifyCtx, us.savedSearchDAO, us.notificationDAO, &newItem)
+		notifyMentions(notifyCtx, us.userDAO, us.notificationDAO, &newItem, model.ExtractMentions(newItem.Description))
+	}()
 
 	return newItemID, nil
 }
+
+// notifyMentions : 商品説明で@メンションされたユーザーに通知する (出品者本人・ブロック関係にあるユーザーは除く)
+func notifyMentions(ctx context.Context, userDAO dao.UserDAO, notificationDAO dao.NotificationDAO, item *model.Item, handles []string) {
+	if len(handles) == 0 {
+		return
+	}
+
+	userIDs, err := userDAO.GetMentionedUserIDs(ctx, item.UserId, handles)
+	if err != nil {
+		log.Printf("Warning: failed to get mentioned users: %v", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		notification := &model.Notification{
+			UserId:   userID,
+			Type:     model.NotificationTypeMention,
+			ItemId:   item.ItemId,
+			ItemName: item.Name,
+			Message:  fmt.Sprintf("%sの商品説明であなたがメンションされました", item.Name),
+		}
+		if err := notificationDAO.CreateNotification(ctx, notification); err != nil {
+			log.Printf("Warning: failed to create notification: %v", err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/model"
+	"db/search"
+	"testing"
+	"time"
+)
+
+func TestItemRegister_RegisterItem_SavedSearch(t *testing.T) {
+	mockItemDAO := &MockItemDAO{
+		ItemInsertFunc: func(ctx context.Context, item *model.Item) error {
+			return nil
+		},
+		GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+			return map[string][]float32{}, nil
+		},
+	}
+	mockSavedSearchDAO := &MockSavedSearchDAO{
+		GetSavedSearchesForPriceFunc: func(ctx context.Context, price int, excludeUserID string) ([]model.SavedSearch, error) {
+			if price != 3000 || excludeUserID != "seller1" {
+				t.Errorf("GetSavedSearchesForPrice() called with (%d, %q), want (3000, seller1)", price, excludeUserID)
+			}
+			return []model.SavedSearch{
+				{Id: "search1", UserId: "user2", Keyword: "ジャケット"},
+				{Id: "search2", UserId: "user3", Keyword: "スニーカー"},
+			}, nil
+		},
+	}
+	// 通知は出品のレスポンスの後に非同期で作られるのでチャネルで受け取る
+	notified := make(chan *model.Notification, 10)
+	mockNotificationDAO := &MockNotificationDAO{
+		CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+			notified <- notification
+			return nil
+		},
+	}
+
+	u := NewItemRegister(mockItemDAO, newTestCategoryDAO(), &MockGeminiService{}, cache.NewEmbeddingCache(mockItemDAO), search.NewInvertedIndex(mockItemDAO), mockSavedSearchDAO, mockNotificationDAO, &MockFollowDAO{}, &MockUserDAO{})
+	itemID, err := u.RegisterItem(context.Background(), "seller1", &model.ItemCreateRequest{
+		Name:        "デニムジャケット",
+		Price:       3000,
+		Description: "数回着用しました",
+		ImageURLs:   []string{"https://example.com/1.jpg"},
+	})
+	if err != nil {
+		t.Fatalf("RegisterItem() error = %v", err)
+	}
+
+	select {
+	case n := <-notified:
+		if n.UserId != "user2" || n.Type != model.NotificationTypeSavedSearch || n.ItemId != itemID {
+			t.Errorf("notification = %+v, want saved_search for user2 on %s", n, itemID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("saved search notification was not created")
+	}
+
+	// キーワードが一致しない条件には通知しない
+	select {
+	case n := <-notified:
+		t.Errorf("unexpected notification = %+v", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
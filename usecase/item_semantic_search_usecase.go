@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/dao"
+	"db/model"
+	"db/search"
+	"db/service"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+const (
+	// semanticMinScore : これ未満の類似度の商品は検索結果に含めない
+	semanticMinScore = 0.5
+	// semanticCandidateLimit : スコア上位何件までを絞り込み・ページングの対象にするか
+	semanticCandidateLimit = 200
+	// hybridSemanticWeight : ハイブリッド検索での類似度の重み (残りがキーワード一致度)
+	hybridSemanticWeight = 0.6
+)
+
+type ItemSemanticSearch interface {
+	SearchItems(ctx context.Context, query string, mode string, filter model.ItemFilter, limit int, offset int) (*model.ItemListResult, error)
+}
+
+type itemSemanticSearch struct {
+	itemDAO        dao.ItemDAO
+	categoryDAO    dao.CategoryDAO
+	blockDAO       dao.BlockDAO
+	geminiService  service.GeminiService
+	embeddingCache *cache.EmbeddingCache
+	searchIndex    search.Index
+}
+
+// NewItemSemanticSearch : searchIndexがnilの場合、ハイブリッド検索のキーワードの順位はDBのLIKE検索で決める
+func NewItemSemanticSearch(itemDAO dao.ItemDAO, categoryDAO dao.CategoryDAO, blockDAO dao.BlockDAO, geminiService service.GeminiService, embeddingCache *cache.EmbeddingCache, searchIndex search.Index) ItemSemanticSearch {
+	return &itemSemanticSearch{
+		itemDAO:        itemDAO,
+		categoryDAO:    categoryDAO,
+		blockDAO:       blockDAO,
+		geminiService:  geminiService,
+		embeddingCache: embeddingCache,
+		searchIndex:    searchIndex,
+	}
+}
+
+// SearchItems : 検索語のベクトルと商品のベクトルの類似度で検索する
+// hybridの場合はキーワード検索の順位も加味する。並び順は常にスコア順 (filter.Sortは使わない)
+func (u *itemSemanticSearch) SearchItems(ctx context.Context, query string, mode string, filter model.ItemFilter, limit int, offset int) (*model.ItemListResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("keyword is required")
+	}
+	if mode != model.SearchModeSemantic && mode != model.SearchModeHybrid {
+		return nil, fmt.Errorf("%w: unsupported search mode %q", model.ErrInvalidRequest, mode)
+	}
+
+	if err := resolveCategoryFilter(ctx, u.categoryDAO, &filter); err != nil {
+		return nil, err
+	}
+	if err := resolveBlockFilter(ctx, u.blockDAO, &filter); err != nil {
+		return nil, err
+	}
+
+	semanticWeight := 1.0
+	if mode == model.SearchModeHybrid {
+		semanticWeight = hybridSemanticWeight
+	}
+
+	scores := make(map[string]float64)
+
+	// 1. 類似度 (キャッシュにあるのは販売中の商品のみ)
+	queryVec, err := u.geminiService.GenerateEmbedding(ctx, query)
+	if err != nil {
+		if mode == model.SearchModeSemantic {
+			return nil, fmt.Errorf("failed to generate query embedding: %w", err)
+		}
+		// ハイブリッドならキーワードの一致度だけで続行する
+		log.Printf("Warning: failed to generate query embedding, falling back to keyword only: %v", err)
+	}
+	if len(queryVec) > 0 {
+		for id, vec := range u.embeddingCache.Get() {
+			if score := cosineSimilarity(queryVec, vec); score >= semanticMinScore {
+				scores[id] = semanticWeight * score
+			}
+		}
+	}
+
+	// 2. キーワードの一致度 (キーワード検索の順位が高いほど1に近い)
+	if mode == model.SearchModeHybrid {
+		keywordIDs, err := u.keywordRankedIDs(ctx, query, filter)
+		if err != nil {
+			return nil, err
+		}
+		for rank, id := range keywordIDs {
+			keywordScore := 1 - float64(rank)/float64(len(keywordIDs))
+			scores[id] += (1 - semanticWeight) * keywordScore
+		}
+	}
+
+	// 3. スコア順に並べて上位の候補に絞る
+	rankedIDs := make([]string, 0, len(scores))
+	for id := range scores {
+		rankedIDs = append(rankedIDs, id)
+	}
+	sort.Slice(rankedIDs, func(i, j int) bool {
+		if scores[rankedIDs[i]] != scores[rankedIDs[j]] {
+			return scores[rankedIDs[i]] > scores[rankedIDs[j]]
+		}
+		return rankedIDs[i] > rankedIDs[j] // 同点はIDの降順 (ULIDなので新しい順)
+	})
+	if len(rankedIDs) > semanticCandidateLimit {
+		rankedIDs = rankedIDs[:semanticCandidateLimit]
+	}
+
+	// 4. 一覧と同じ絞り込み条件を適用してページング
+	return pageRankedItems(ctx, u.itemDAO, rankedIDs, filter, limit, offset)
+}
+
+// keywordRankedIDs : 絞り込み条件に合う商品をキーワード検索と同じ順位で最大semanticCandidateLimit件返す
+func (u *itemSemanticSearch) keywordRankedIDs(ctx context.Context, query string, filter model.ItemFilter) ([]string, error) {
+	if u.searchIndex == nil {
+		keywordFilter := filter
+		keywordFilter.Sort = ""
+		items, err := u.itemDAO.SearchItems(ctx, query, keywordFilter, semanticCandidateLimit, 0)
+		if err != nil {
+			return nil, fmt.Errorf("fail:itemDAO.SearchItems: %w", err)
+		}
+		ids := make([]string, len(items))
+		for i, item := range items {
+			ids[i] = item.ItemId
+		}
+		return ids, nil
+	}
+
+	rankedIDs, err := searchIndexIDs(ctx, u.searchIndex, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(rankedIDs) == 0 {
+		return rankedIDs, nil
+	}
+	ids, err := filterRankedIDs(ctx, u.itemDAO, rankedIDs, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > semanticCandidateLimit {
+		ids = ids[:semanticCandidateLimit]
+	}
+	return ids, nil
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/model"
+	"db/search"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// MockGeminiService : service.GeminiService のモック
+type MockGeminiService struct {
+	GenerateEmbeddingFunc func(ctx context.Context, text string) ([]float32, error)
+}
+
+func (m *MockGeminiService) GenerateDescriptionFromImageURL(ctx context.Context, imageURL string) (string, error) {
+	return "", nil
+}
+
+func (m *MockGeminiService) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	if m.GenerateEmbeddingFunc != nil {
+		return m.GenerateEmbeddingFunc(ctx, text)
+	}
+	return nil, nil
+}
+
+func TestItemSemanticSearch_SearchItems(t *testing.T) {
+	// "coat" は検索語と同じ向き、"parka" はやや近く、"tv" は無関係
+	embeddings := map[string][]float32{
+		"coat":  {1, 0},
+		"parka": {0.8, 0.6},
+		"tv":    {0, 1},
+	}
+
+	tests := []struct {
+		name        string
+		mode        string
+		keywordHits []string
+		embedErr    error
+		excludedIDs []string // 絞り込み条件で除外される商品
+		limit       int
+		offset      int
+		wantIDs     []string
+		wantTotal   int
+		wantErr     bool
+	}{
+		{name: "成功: 類似度順", mode: model.SearchModeSemantic, limit: 10, wantIDs: []string{"coat", "parka"}, wantTotal: 2},
+		{name: "成功: 絞り込み条件を適用", mode: model.SearchModeSemantic, excludedIDs: []string{"coat"}, limit: 10, wantIDs: []string{"parka"}, wantTotal: 1},
+		{name: "成功: ページング", mode: model.SearchModeSemantic, limit: 1, offset: 1, wantIDs: []string{"parka"}, wantTotal: 2},
+		{name: "成功: ハイブリッドはキーワード一致を加点", mode: model.SearchModeHybrid, keywordHits: []string{"parka", "tv"}, limit: 10, wantIDs: []string{"parka", "coat", "tv"}, wantTotal: 3},
+		{name: "成功: ハイブリッドはベクトル化失敗時もキーワードで返す", mode: model.SearchModeHybrid, keywordHits: []string{"tv"}, embedErr: errors.New("api error"), limit: 10, wantIDs: []string{"tv"}, wantTotal: 1},
+		{name: "失敗: セマンティックでベクトル化失敗", mode: model.SearchModeSemantic, embedErr: errors.New("api error"), limit: 10, wantErr: true},
+		{name: "失敗: 未知の検索方式", mode: "fuzzy", limit: 10, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockItemDAO := &MockItemDAO{
+				GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+					return embeddings, nil
+				},
+				SearchItemsFunc: func(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
+					items := make([]model.ItemSimple, len(tt.keywordHits))
+					for i, id := range tt.keywordHits {
+						items[i] = model.ItemSimple{ItemId: id}
+					}
+					return items, nil
+				},
+				FilterItemIDsFunc: func(ctx context.Context, itemIDs []string, filter model.ItemFilter) ([]string, error) {
+					ids := make([]string, 0)
+					for _, id := range itemIDs {
+						if !slices.Contains(tt.excludedIDs, id) {
+							ids = append(ids, id)
+						}
+					}
+					return ids, nil
+				},
+				GetItemsByIDsFunc: func(ctx context.Context, itemIDs []string) ([]model.ItemSimple, error) {
+					items := make([]model.ItemSimple, len(itemIDs))
+					for i, id := range itemIDs {
+						items[i] = model.ItemSimple{ItemId: id}
+					}
+					return items, nil
+				},
+			}
+			mockGemini := &MockGeminiService{
+				GenerateEmbeddingFunc: func(ctx context.Context, text string) ([]float32, error) {
+					if tt.embedErr != nil {
+						return nil, tt.embedErr
+					}
+					return []float32{1, 0}, nil
+				},
+			}
+
+			u := NewItemSemanticSearch(mockItemDAO, &MockCategoryDAO{}, &MockBlockDAO{}, mockGemini, cache.NewEmbeddingCache(mockItemDAO), nil)
+			got, err := u.SearchItems(context.Background(), "warm winter jacket", tt.mode, model.ItemFilter{}, tt.limit, tt.offset)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SearchItems() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			gotIDs := make([]string, len(got.Items))
+			for i, item := range got.Items {
+				gotIDs[i] = item.ItemId
+			}
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("SearchItems() ids = %v, want %v", gotIDs, tt.wantIDs)
+			}
+			if got.Total != tt.wantTotal {
+				t.Errorf("SearchItems() total = %v, want %v", got.Total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestItemSemanticSearch_SearchItems_HybridUsesSearchIndex(t *testing.T) {
+	docs := []model.ItemSearchDocument{
+		{ItemId: "01", Name: "ダウンジャケット", Description: "冬用"},
+		{ItemId: "02", Name: "デニムジャケット", Description: "ジャケットの定番"},
+		{ItemId: "03", Name: "ジャケット", Description: "子供用"},
+		{ItemId: "04", Name: "スニーカー"},
+	}
+	mockItemDAO := &MockItemDAO{
+		GetSearchDocumentsFunc: func(ctx context.Context) ([]model.ItemSearchDocument, error) {
+			return docs, nil
+		},
+		GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+			return map[string][]float32{}, nil
+		},
+		SearchItemsFunc: func(ctx context.Context, keyword string, filter model.ItemFilter, limit int, offset int) ([]model.ItemSimple, error) {
+			t.Errorf("SearchItems() (LIKE search) should not be called when the index is enabled")
+			return nil, nil
+		},
+		// 03は絞り込み条件で除外される
+		FilterItemIDsFunc: func(ctx context.Context, itemIDs []string, filter model.ItemFilter) ([]string, error) {
+			ids := make([]string, 0)
+			for _, id := range itemIDs {
+				if id != "03" {
+					ids = append(ids, id)
+				}
+			}
+			return ids, nil
+		},
+		GetItemsByIDsFunc: func(ctx context.Context, itemIDs []string) ([]model.ItemSimple, error) {
+			items := make([]model.ItemSimple, len(itemIDs))
+			for i, id := range itemIDs {
+				items[i] = model.ItemSimple{ItemId: id}
+			}
+			return items, nil
+		},
+	}
+	// ベクトル化に失敗させ、キーワードの順位だけで並ぶようにする
+	mockGemini := &MockGeminiService{
+		GenerateEmbeddingFunc: func(ctx context.Context, text string) ([]float32, error) {
+			return nil, errors.New("api error")
+		},
+	}
+	searchIndex := search.NewInvertedIndex(mockItemDAO)
+
+	// キーワード検索と同じく、ひらがなの検索語でもカタカナの商品に一致し、関連度順に並ぶ
+	hits, err := searchIndex.Search(context.Background(), "じゃけっと", 10)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	wantIDs := make([]string, 0)
+	for _, hit := range hits {
+		if hit.ItemId != "03" {
+			wantIDs = append(wantIDs, hit.ItemId)
+		}
+	}
+	if len(wantIDs) != 2 {
+		t.Fatalf("index hits = %v, want 01 and 02 after filtering", hits)
+	}
+
+	u := NewItemSemanticSearch(mockItemDAO, &MockCategoryDAO{}, &MockBlockDAO{}, mockGemini, cache.NewEmbeddingCache(mockItemDAO), searchIndex)
+	got, err := u.SearchItems(context.Background(), "じゃけっと", model.SearchModeHybrid, model.ItemFilter{}, 10, 0)
+	if err != nil {
+		t.Fatalf("SearchItems() error = %v", err)
+	}
+
+	gotIDs := make([]string, len(got.Items))
+	for i, item := range got.Items {
+		gotIDs[i] = item.ItemId
+	}
+	if !slices.Equal(gotIDs, wantIDs) {
+		t.Errorf("SearchItems() ids = %v, want %v", gotIDs, wantIDs)
+	}
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"db/dao"
+	"db/model"
+	"db/search"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// popularQueryWindow : 人気の検索語として集計する期間
+	popularQueryWindow = 30 * 24 * time.Hour
+	// popularQueryMinCount : 補完候補にする検索語の最低検索回数 (1回きりの検索語は出さない)
+	popularQueryMinCount = 2
+	// popularQueryLimit : 補完候補にする検索語の上限
+	popularQueryLimit = 5000
+	// queryWeight : 検索1回あたりの重み (出品1件を1とする)
+	queryWeight = 2.0
+	// queryLogDedupeWindow : 同じ利用者が同じ検索語を繰り返しても1回と数える期間
+	queryLogDedupeWindow = 10 * time.Minute
+	// queryLogPruneSize : 記録済みの検索語がこの件数を超えたら期限切れのものを捨てる
+	queryLogPruneSize = 10000
+)
+
+type ItemSuggest interface {
+	Suggest(ctx context.Context, prefix string, limit int) ([]string, error)
+	LogQuery(ctx context.Context, clientKey, query string)
+	Refresh(ctx context.Context) error
+}
+
+type itemSuggest struct {
+	itemDAO        dao.ItemDAO
+	searchQueryDAO dao.SearchQueryDAO
+	suggester      search.Suggester
+
+	mu         sync.Mutex
+	lastLogged map[string]time.Time // 利用者と正規化した検索語 → 最後に記録した時刻
+}
+
+func NewItemSuggest(itemDAO dao.ItemDAO, searchQueryDAO dao.SearchQueryDAO, suggester search.Suggester) ItemSuggest {
+	return &itemSuggest{
+		itemDAO:        itemDAO,
+		searchQueryDAO: searchQueryDAO,
+		suggester:      suggester,
+		lastLogged:     make(map[string]time.Time),
+	}
+}
+
+// Suggest : 入力途中の検索語から、商品名と人気の検索語の補完候補を返す
+func (u *itemSuggest) Suggest(ctx context.Context, prefix string, limit int) ([]string, error) {
+	return u.suggester.Suggest(prefix, limit), nil
+}
+
+// LogQuery : 人気の検索語の集計用に検索語を記録する (失敗しても検索には影響させない)
+// clientKeyはログインユーザーのIDか接続元のIPで、同じ利用者の連続した検索は一定期間1回と数える
+func (u *itemSuggest) LogQuery(ctx context.Context, clientKey, query string) {
+	query = strings.TrimSpace(query)
+	if query == "" || utf8.RuneCountInString(query) > model.MaxSearchQueryLen {
+		return
+	}
+
+	normalized := search.Normalize(query)
+	if !u.markLogged(clientKey+"\x00"+normalized, time.Now()) {
+		return
+	}
+
+	if err := u.searchQueryDAO.LogQuery(ctx, query, normalized); err != nil {
+		log.Printf("Warning: failed to log search query: %v", err)
+	}
+}
+
+// markLogged : 期間内に同じキーを記録していなければ記録済みにしてtrueを返す
+func (u *itemSuggest) markLogged(key string, now time.Time) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if last, ok := u.lastLogged[key]; ok && now.Sub(last) < queryLogDedupeWindow {
+		return false
+	}
+	if len(u.lastLogged) >= queryLogPruneSize {
+		for k, last := range u.lastLogged {
+			if now.Sub(last) >= queryLogDedupeWindow {
+				delete(u.lastLogged, k)
+			}
+		}
+	}
+	u.lastLogged[key] = now
+	return true
+}
+
+// Refresh : 商品名と人気の検索語をDBから読み込み、補完候補を作り直す
+func (u *itemSuggest) Refresh(ctx context.Context) error {
+	docs, err := u.itemDAO.GetSearchDocuments(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get item names: %w", err)
+	}
+	queries, err := u.searchQueryDAO.GetPopularQueries(ctx, time.Now().Add(-popularQueryWindow), popularQueryMinCount, popularQueryLimit)
+	if err != nil {
+		return fmt.Errorf("failed to get popular queries: %w", err)
+	}
+
+	suggestions := make([]search.Suggestion, 0, len(docs)+len(queries))
+	for _, doc := range docs {
+		suggestions = append(suggestions, search.Suggestion{Text: doc.Name, Weight: 1})
+	}
+	for _, q := range queries {
+		suggestions = append(suggestions, search.Suggestion{Text: q.Query, Weight: queryWeight * float64(q.Count)})
+	}
+
+	u.suggester.Rebuild(suggestions)
+	return nil
+}
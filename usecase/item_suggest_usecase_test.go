@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"db/model"
+	"db/search"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockSearchQueryDAO : dao.SearchQueryDAO のモック
+type MockSearchQueryDAO struct {
+	LogQueryFunc          func(ctx context.Context, query string, normalizedQuery string) error
+	GetPopularQueriesFunc func(ctx context.Context, since time.Time, minCount int, limit int) ([]model.PopularQuery, error)
+}
+
+func (m *MockSearchQueryDAO) LogQuery(ctx context.Context, query string, normalizedQuery string) error {
+	if m.LogQueryFunc != nil {
+		return m.LogQueryFunc(ctx, query, normalizedQuery)
+	}
+	return nil
+}
+
+func (m *MockSearchQueryDAO) GetPopularQueries(ctx context.Context, since time.Time, minCount int, limit int) ([]model.PopularQuery, error) {
+	if m.GetPopularQueriesFunc != nil {
+		return m.GetPopularQueriesFunc(ctx, since, minCount, limit)
+	}
+	return []model.PopularQuery{}, nil
+}
+
+func TestItemSuggest_Refresh(t *testing.T) {
+	mockItemDAO := &MockItemDAO{
+		GetSearchDocumentsFunc: func(ctx context.Context) ([]model.ItemSearchDocument, error) {
+			return []model.ItemSearchDocument{
+				{ItemId: "01", Name: "ダウンジャケット"},
+				{ItemId: "02", Name: "ダウンベスト"},
+				{ItemId: "03", Name: "ダウンベスト"},
+			}, nil
+		},
+	}
+	mockQueryDAO := &MockSearchQueryDAO{
+		GetPopularQueriesFunc: func(ctx context.Context, since time.Time, minCount int, limit int) ([]model.PopularQuery, error) {
+			return []model.PopularQuery{{Query: "ダウン", Count: 3}}, nil
+		},
+	}
+
+	u := NewItemSuggest(mockItemDAO, mockQueryDAO, search.NewTrieSuggester())
+	if err := u.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	// 人気の検索語(3回×2) > 同名の出品2件 > 出品1件
+	got, err := u.Suggest(context.Background(), "だうん", 10)
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	want := []string{"ダウン", "ダウンベスト", "ダウンジャケット"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Suggest() = %v, want %v", got, want)
+	}
+}
+
+func TestItemSuggest_Refresh_Error(t *testing.T) {
+	mockQueryDAO := &MockSearchQueryDAO{
+		GetPopularQueriesFunc: func(ctx context.Context, since time.Time, minCount int, limit int) ([]model.PopularQuery, error) {
+			return nil, errors.New("db error")
+		},
+	}
+	u := NewItemSuggest(&MockItemDAO{}, mockQueryDAO, search.NewTrieSuggester())
+	if err := u.Refresh(context.Background()); err == nil {
+		t.Errorf("Refresh() error = nil, want error")
+	}
+}
+
+func TestItemSuggest_LogQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		wantLogged     bool
+		wantNormalized string
+	}{
+		{name: "成功: 正規化した検索語も記録", query: " ジャケット ", wantLogged: true, wantNormalized: "じゃけっと"},
+		{name: "成功: 空白のみは記録しない", query: "  ", wantLogged: false},
+		{name: "成功: 長すぎる検索語は記録しない", query: strings.Repeat("あ", model.MaxSearchQueryLen+1), wantLogged: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logged := false
+			var normalized string
+			mockQueryDAO := &MockSearchQueryDAO{
+				LogQueryFunc: func(ctx context.Context, query string, normalizedQuery string) error {
+					logged = true
+					normalized = normalizedQuery
+					return nil
+				},
+			}
+
+			u := NewItemSuggest(&MockItemDAO{}, mockQueryDAO, search.NewTrieSuggester())
+			u.LogQuery(context.Background(), "ip:192.0.2.1", tt.query)
+
+			if logged != tt.wantLogged {
+				t.Fatalf("LogQuery() logged = %v, want %v", logged, tt.wantLogged)
+			}
+			if tt.wantLogged && normalized != tt.wantNormalized {
+				t.Errorf("normalized query = %q, want %q", normalized, tt.wantNormalized)
+			}
+		})
+	}
+}
+
+func TestItemSuggest_LogQuery_Dedupe(t *testing.T) {
+	var logged []string
+	mockQueryDAO := &MockSearchQueryDAO{
+		LogQueryFunc: func(ctx context.Context, query string, normalizedQuery string) error {
+			logged = append(logged, query)
+			return nil
+		},
+	}
+	u := NewItemSuggest(&MockItemDAO{}, mockQueryDAO, search.NewTrieSuggester())
+	ctx := context.Background()
+
+	// 同じ利用者の同じ検索語 (表記違いを含む) は期間内なら1回と数える
+	u.LogQuery(ctx, "ip:192.0.2.1", "ジャケット")
+	u.LogQuery(ctx, "ip:192.0.2.1", "じゃけっと")
+	u.LogQuery(ctx, "ip:192.0.2.1", "ジャケット")
+	// 別の利用者・別の検索語は数える
+	u.LogQuery(ctx, "user:user1", "ジャケット")
+	u.LogQuery(ctx, "ip:192.0.2.1", "スニーカー")
+
+	want := []string{"ジャケット", "ジャケット", "スニーカー"}
+	if !slices.Equal(logged, want) {
+		t.Errorf("logged = %v, want %v", logged, want)
+	}
+}
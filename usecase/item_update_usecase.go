@@ -5,8 +5,11 @@ import (
 	"db/cache"
 	"db/dao"
 	"db/model"
+	"db/search"
 	"db/service"
+	"encoding/json"
 	"fmt"
+	"log"
 )
 
 type ItemUpdate interface {
@@ -14,18 +17,39 @@ type ItemUpdate interface {
 }
 
 type itemUpdate struct {
-	itemDAO        dao.ItemDAO
-	geminiService  service.GeminiService
-	embeddingCache *cache.EmbeddingCache
+	itemDAO         dao.ItemDAO
+	categoryDAO     dao.CategoryDAO
+	geminiService   service.GeminiService
+	embeddingCache  *cache.EmbeddingCache
+	searchIndex     search.Index
+	likeDAO         dao.LikeDAO
+	notificationDAO dao.NotificationDAO
+	userDAO         dao.UserDAO
 }
 
-func NewItemUpdate(itemDAO dao.ItemDAO, geminiService service.GeminiService, embeddingCache *cache.EmbeddingCache) ItemUpdate {
-	return &itemUpdate{itemDAO: itemDAO, geminiService: geminiService, embeddingCache: embeddingCache}
+// NewItemUpdate : searchIndexがnilなら更新内容のインデックスへの反映を省く
+func NewItemUpdate(itemDAO dao.ItemDAO, categoryDAO dao.CategoryDAO, geminiService service.GeminiService, embeddingCache *cache.EmbeddingCache, searchIndex search.Index, likeDAO dao.LikeDAO, notificationDAO dao.NotificationDAO, userDAO dao.UserDAO) ItemUpdate {
+	return &itemUpdate{itemDAO: itemDAO, categoryDAO: categoryDAO, geminiService: geminiService, embeddingCache: embeddingCache, searchIndex: searchIndex, likeDAO: likeDAO, notificationDAO: notificationDAO, userDAO: userDAO}
 }
 
 func (u *itemUpdate) UpdateItem(ctx context.Context, req *model.ItemUpdateRequest) error {
 	if !req.IsValid() {
-		return fmt.Errorf("invalid request")
+		return model.ErrInvalidUpdateRequest
+	}
+	// カテゴリは任意だが、指定する場合は存在するものに限る
+	if req.CategoryId != 0 {
+		exists, err := u.categoryDAO.CategoryExists(ctx, req.CategoryId)
+		if err != nil {
+			return fmt.Errorf("failed to check category: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("%w: %v", model.ErrInvalidUpdateRequest, model.ErrCategoryNotFound)
+		}
+	}
+	// 新しいメンションの判定用に更新前の商品を取得 (値下げの判定はDAOが行ロック下で読んだ価格を使う)
+	current, err := u.itemDAO.GetItem(ctx, req.ItemID)
+	if err != nil {
+		return fmt.Errorf("failed to get item: %w", err)
 	}
 	// 商品説明をベクトル化
 	textToEmbed := fmt.Sprintf("%s\n%s", req.Name, req.Description)
@@ -33,13 +57,87 @@ func (u *itemUpdate) UpdateItem(ctx context.Context, req *model.ItemUpdateReques
 	if err != nil {
 		fmt.Printf("Warning: failed to update embedding: %v\n", err)
 	}
-	err = u.itemDAO.UpdateItem(ctx, req.ItemID, req.UserID, req.Name, req.Price, req.Description, req.ImageURLs, embedding)
+	oldPrice, err := u.itemDAO.UpdateItem(ctx, &model.Item{
+		ItemId:         req.ItemID,
+		UserId:         req.UserID,
+		Name:           req.Name,
+		Price:          req.Price,
+		Description:    req.Description,
+		ImageURLs:      req.ImageURLs,
+		CategoryId:     req.CategoryId,
+		Condition:      req.Condition,
+		Brand:          req.Brand,
+		ShippingPayer:  req.ShippingPayer,
+		ShippingMethod: req.ShippingMethod,
+		Embedding:      embedding,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update item: %w", err)
 	}
 
-	// キャッシュも即時更新
+	// キャッシュと検索インデックスも即時更新
 	u.embeddingCache.Set(req.ItemID, embedding)
+	if u.searchIndex != nil {
+		u.searchIndex.Upsert(model.ItemSearchDocument{ItemId: req.ItemID, Name: req.Name, Description: req.Description})
+	}
+
+	if req.Price < oldPrice {
+		u.notifyPriceDrop(ctx, req.ItemID, req.UserID, req.Name, oldPrice, req.Price)
+	}
+
+	// 更新前の説明にもあったメンションには再通知しない
+	// 通知は出品時と同じく、更新のレスポンスを待たせないよう非同期で行う
+	if handles := newMentions(current.Description, req.Description); len(handles) > 0 {
+		item := &model.Item{ItemId: req.ItemID, UserId: req.UserID, Name: req.Name}
+		go notifyMentions(context.WithoutCancel(ctx), u.userDAO, u.notificationDAO, item, handles)
+	}
 
 	return nil
 }
+
+// newMentions : 更新後の説明にだけあるメンションを返す
+func newMentions(oldDescription, newDescription string) []string {
+	old := make(map[string]bool)
+	for _, handle := range model.ExtractMentions(oldDescription) {
+		old[handle] = true
+	}
+	handles := make([]string, 0)
+	for _, handle := range model.ExtractMentions(newDescription) {
+		if !old[handle] {
+			handles = append(handles, handle)
+		}
+	}
+	return handles
+}
+
+// notifyPriceDrop : 値下げをいいねしたユーザーに通知する (通知作成が失敗しても更新は成功とする)
+func (u *itemUpdate) notifyPriceDrop(ctx context.Context, itemID, sellerID, itemName string, oldPrice, newPrice int) {
+	likerIDs, err := u.likeDAO.GetLikerIDs(ctx, itemID)
+	if err != nil {
+		log.Printf("Warning: failed to get likers: %v\n", err)
+		return
+	}
+
+	payload, err := json.Marshal(model.PriceDropPayload{OldPrice: oldPrice, NewPrice: newPrice})
+	if err != nil {
+		log.Printf("Warning: failed to marshal price drop payload: %v\n", err)
+		return
+	}
+
+	for _, likerID := range likerIDs {
+		if likerID == sellerID {
+			continue
+		}
+		notification := &model.Notification{
+			UserId:   likerID,
+			Type:     model.NotificationTypePriceDrop,
+			ItemId:   itemID,
+			ItemName: itemName,
+			Message:  fmt.Sprintf("いいねした%sが¥%dから¥%dに値下げされました", itemName, oldPrice, newPrice),
+			Payload:  payload,
+		}
+		if err := u.notificationDAO.CreateNotification(ctx, notification); err != nil {
+			log.Printf("Warning: failed to create notification: %v\n", err)
+		}
+	}
+}
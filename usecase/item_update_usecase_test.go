@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/model"
+	"db/search"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestItemUpdate_UpdateItem_PriceDrop(t *testing.T) {
+	tests := []struct {
+		name         string
+		fetchedPrice int // GetItemで読んだ価格 (ロック前なので古い可能性がある)
+		lockedPrice  int // UpdateItemが行ロック下で読んだ更新前の価格
+		newPrice     int
+		updateErr    error
+		wantErr      error
+		wantOldPrice int // 0なら通知なし
+	}{
+		{name: "成功: 値下げをいいねしたユーザーに通知", fetchedPrice: 1000, lockedPrice: 1000, newPrice: 800, wantOldPrice: 1000},
+		{name: "成功: 取得後に値上げされていてもロック時の価格から下がれば通知", fetchedPrice: 700, lockedPrice: 1000, newPrice: 800, wantOldPrice: 1000},
+		{name: "成功: 取得後に同じ価格へ値下げ済みなら通知しない", fetchedPrice: 1000, lockedPrice: 800, newPrice: 800},
+		{name: "成功: 値上げは通知しない", fetchedPrice: 1000, lockedPrice: 1000, newPrice: 1200},
+		{name: "失敗: 更新できなければ通知しない", fetchedPrice: 1000, lockedPrice: 1000, newPrice: 800, updateErr: model.ErrNotAuthorized, wantErr: model.ErrNotAuthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockItemDAO := &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return &model.Item{ItemId: itemID, UserId: "seller1", Name: "Test Item", Price: tt.fetchedPrice, Status: model.StatusOnSale}, nil
+				},
+				UpdateItemFunc: func(ctx context.Context, item *model.Item) (int, error) {
+					if tt.updateErr != nil {
+						return 0, tt.updateErr
+					}
+					return tt.lockedPrice, nil
+				},
+				GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+					return map[string][]float32{}, nil
+				},
+			}
+			mockLikeDAO := &MockLikeDAO{
+				GetLikerIDsFunc: func(ctx context.Context, itemID string) ([]string, error) {
+					return []string{"user1", "seller1", "user2"}, nil
+				},
+			}
+			notified := make(map[string]model.PriceDropPayload)
+			mockNotificationDAO := &MockNotificationDAO{
+				CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+					var payload model.PriceDropPayload
+					if err := json.Unmarshal(notification.Payload, &payload); err != nil {
+						t.Fatalf("invalid payload %s: %v", notification.Payload, err)
+					}
+					notified[notification.UserId] = payload
+					return nil
+				},
+			}
+
+			u := NewItemUpdate(mockItemDAO, newTestCategoryDAO(), &MockGeminiService{}, cache.NewEmbeddingCache(mockItemDAO), search.NewInvertedIndex(mockItemDAO), mockLikeDAO, mockNotificationDAO, &MockUserDAO{})
+			err := u.UpdateItem(context.Background(), &model.ItemUpdateRequest{
+				ItemID:    "item1",
+				UserID:    "seller1",
+				Name:      "Test Item",
+				Price:     tt.newPrice,
+				ImageURLs: []string{"https://example.com/1.jpg"},
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateItem() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantOldPrice == 0 {
+				if len(notified) > 0 {
+					t.Errorf("price drop should not be notified, got %v", notified)
+				}
+				return
+			}
+			want := model.PriceDropPayload{OldPrice: tt.wantOldPrice, NewPrice: tt.newPrice}
+			if len(notified) != 2 || notified["user1"] != want || notified["user2"] != want {
+				t.Errorf("notified = %v, want user1 and user2 with %+v", notified, want)
+			}
+		})
+	}
+}
+
+func TestItemUpdate_UpdateItem_Mention(t *testing.T) {
+	tests := []struct {
+		name           string
+		oldDescription string
+		newDescription string
+		wantHandles    []string
+		wantRecipients []string
+	}{
+		{name: "成功: 追加されたメンションだけ通知", oldDescription: "@tanaka_shop さんから購入", newDescription: "@tanaka_shop さんから購入、@Sato さんに譲ります", wantHandles: []string{"sato"}, wantRecipients: []string{"user2"}},
+		{name: "成功: 既存のメンションは再通知しない", oldDescription: "@tanaka_shop さんから購入", newDescription: "@tanaka_shop さんから購入しました"},
+		{name: "成功: メンションなし", oldDescription: "美品", newDescription: "美品です"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockItemDAO := &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return &model.Item{ItemId: itemID, UserId: "seller1", Name: "Test Item", Price: 1000, Description: tt.oldDescription, Status: model.StatusOnSale}, nil
+				},
+				GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+					return map[string][]float32{}, nil
+				},
+			}
+			var gotHandles []string
+			mockUserDAO := &MockUserDAO{
+				GetMentionedUserIDsFunc: func(ctx context.Context, authorID string, handles []string) ([]string, error) {
+					if authorID != "seller1" {
+						t.Errorf("authorID = %q, want seller1", authorID)
+					}
+					gotHandles = handles
+					return []string{"user2"}, nil
+				},
+			}
+			// 通知は非同期で作られるのでチャネルで受け取る
+			notified := make(chan *model.Notification, 10)
+			mockNotificationDAO := &MockNotificationDAO{
+				CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+					notified <- notification
+					return nil
+				},
+			}
+
+			u := NewItemUpdate(mockItemDAO, newTestCategoryDAO(), &MockGeminiService{}, cache.NewEmbeddingCache(mockItemDAO), search.NewInvertedIndex(mockItemDAO), &MockLikeDAO{}, mockNotificationDAO, mockUserDAO)
+			err := u.UpdateItem(context.Background(), &model.ItemUpdateRequest{
+				ItemID:      "item1",
+				UserID:      "seller1",
+				Name:        "Test Item",
+				Price:       1000,
+				Description: tt.newDescription,
+				ImageURLs:   []string{"https://example.com/1.jpg"},
+			})
+			if err != nil {
+				t.Fatalf("UpdateItem() error = %v", err)
+			}
+
+			recipients := make([]string, 0)
+			for range tt.wantRecipients {
+				select {
+				case n := <-notified:
+					if n.Type != model.NotificationTypeMention {
+						t.Errorf("notification type = %q, want %q", n.Type, model.NotificationTypeMention)
+					}
+					recipients = append(recipients, n.UserId)
+				case <-time.After(time.Second):
+					t.Fatalf("mention notification was not sent")
+				}
+			}
+			if !slices.Equal(recipients, tt.wantRecipients) || !slices.Equal(gotHandles, tt.wantHandles) {
+				t.Errorf("handles = %v, recipients = %v, want %v, %v", gotHandles, recipients, tt.wantHandles, tt.wantRecipients)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/dao"
+	"db/model"
+	"db/search"
+	"fmt"
+	"log"
+	"time"
+)
+
+type ItemWithdraw interface {
+	WithdrawItem(ctx context.Context, itemID string, userID string) error
+}
+
+type itemWithdraw struct {
+	itemDAO         dao.ItemDAO
+	offerDAO        dao.OfferDAO
+	likeDAO         dao.LikeDAO
+	notificationDAO dao.NotificationDAO
+	embeddingCache  *cache.EmbeddingCache
+	searchIndex     search.Index
+	statsCache      *cache.SellerStatsCache
+}
+
+// NewItemWithdraw : searchIndexがnilならインデックスからの削除を省く
+func NewItemWithdraw(itemDAO dao.ItemDAO, offerDAO dao.OfferDAO, likeDAO dao.LikeDAO, notificationDAO dao.NotificationDAO, embeddingCache *cache.EmbeddingCache, searchIndex search.Index, statsCache *cache.SellerStatsCache) ItemWithdraw {
+	return &itemWithdraw{
+		itemDAO:         itemDAO,
+		offerDAO:        offerDAO,
+		likeDAO:         likeDAO,
+		notificationDAO: notificationDAO,
+		embeddingCache:  embeddingCache,
+		searchIndex:     searchIndex,
+		statsCache:      statsCache,
+	}
+}
+
+// WithdrawItem : 出品を取り下げ、おすすめから除外し、いいねしていたユーザーに通知する
+// 売却済み・価格交渉で取り置き中の商品は取引中のため取り下げられない
+func (u *itemWithdraw) WithdrawItem(ctx context.Context, itemID string, userID string) error {
+	item, err := u.itemDAO.GetItem(ctx, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to get item: %w", err)
+	}
+	if item.UserId != userID {
+		return model.ErrNotAuthorized
+	}
+
+	// 取り置き中なら早めに断る (承諾と入れ違った場合はDAOが商品の行をロックして確かめ直す)
+	reservation, err := u.offerDAO.GetActiveReservation(ctx, itemID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to check reservation: %w", err)
+	}
+	if reservation != nil {
+		return model.ErrItemInTransaction
+	}
+
+	// いいねは取り下げと同時に削除されるので先に通知先を取得しておく
+	likerIDs, err := u.likeDAO.GetLikerIDs(ctx, itemID)
+	if err != nil {
+		log.Printf("Warning: failed to get likers: %v\n", err)
+	}
+
+	if err := u.itemDAO.WithdrawItem(ctx, itemID, userID); err != nil {
+		return fmt.Errorf("failed to withdraw item: %w", err)
+	}
+
+	// キャッシュからベクトルを削除（おすすめから除外）し、検索インデックスからも外す
+	u.embeddingCache.Delete(itemID)
+	if u.searchIndex != nil {
+		u.searchIndex.Remove(itemID)
+	}
+	// 取り下げた商品は出品数に数えない
+	u.statsCache.Invalidate(userID)
+
+	// 通知作成が失敗しても取り下げは成功とする
+	for _, likerID := range likerIDs {
+		if likerID == userID {
+			continue
+		}
+		notification := &model.Notification{
+			UserId:   likerID,
+			Type:     model.NotificationTypeWithdraw,
+			ItemId:   itemID,
+			ItemName: item.Name,
+			Message:  fmt.Sprintf("いいねした%sの出品が取り下げられました", item.Name),
+		}
+		if err := u.notificationDAO.CreateNotification(ctx, notification); err != nil {
+			log.Printf("Warning: failed to create notification: %v\n", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/model"
+	"db/search"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockLikeDAO : dao.LikeDAO のモック
+type MockLikeDAO struct {
+	AddLikeFunc            func(ctx context.Context, userID, itemID string) error
+	RemoveLikeFunc         func(ctx context.Context, userID, itemID string) error
+	ToggleLikeFunc         func(ctx context.Context, userID, itemID string) (bool, error)
+	CountLikesFunc         func(ctx context.Context, itemID string) (int, error)
+	GetLikedItemsFunc      func(ctx context.Context, userID string) ([]model.ItemSimple, error)
+	GetLikedItemIDsFunc    func(ctx context.Context, userID string) ([]string, error)
+	GetLikerIDsFunc        func(ctx context.Context, itemID string) ([]string, error)
+	FilterLikedItemIDsFunc func(ctx context.Context, userID string, itemIDs []string) (map[string]bool, error)
+}
+
+func (m *MockLikeDAO) AddLike(ctx context.Context, userID, itemID string) error {
+	if m.AddLikeFunc != nil {
+		return m.AddLikeFunc(ctx, userID, itemID)
+	}
+	return nil
+}
+
+func (m *MockLikeDAO) RemoveLike(ctx context.Context, userID, itemID string) error {
+	if m.RemoveLikeFunc != nil {
+		return m.RemoveLikeFunc(ctx, userID, itemID)
+	}
+	return nil
+}
+
+func (m *MockLikeDAO) ToggleLike(ctx context.Context, userID, itemID string) (bool, error) {
+	if m.ToggleLikeFunc != nil {
+		return m.ToggleLikeFunc(ctx, userID, itemID)
+	}
+	return false, nil
+}
+
+func (m *MockLikeDAO) CountLikes(ctx context.Context, itemID string) (int, error) {
+	if m.CountLikesFunc != nil {
+		return m.CountLikesFunc(ctx, itemID)
+	}
+	return 0, nil
+}
+
+func (m *MockLikeDAO) GetLikedItems(ctx context.Context, userID string) ([]model.ItemSimple, error) {
+	if m.GetLikedItemsFunc != nil {
+		return m.GetLikedItemsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockLikeDAO) GetLikedItemIDs(ctx context.Context, userID string) ([]string, error) {
+	if m.GetLikedItemIDsFunc != nil {
+		return m.GetLikedItemIDsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockLikeDAO) GetLikerIDs(ctx context.Context, itemID string) ([]string, error) {
+	if m.GetLikerIDsFunc != nil {
+		return m.GetLikerIDsFunc(ctx, itemID)
+	}
+	return nil, nil
+}
+
+func (m *MockLikeDAO) FilterLikedItemIDs(ctx context.Context, userID string, itemIDs []string) (map[string]bool, error) {
+	if m.FilterLikedItemIDsFunc != nil {
+		return m.FilterLikedItemIDsFunc(ctx, userID, itemIDs)
+	}
+	return map[string]bool{}, nil
+}
+
+func TestItemWithdraw_WithdrawItem(t *testing.T) {
+	item := &model.Item{ItemId: "item1", Name: "Test Item", UserId: "seller1", Status: model.StatusOnSale}
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name             string
+		userID           string
+		reservation      *model.Offer
+		withdrawErr      error
+		wantErr          error
+		wantRecipients   []string
+		wantCacheEvicted bool
+	}{
+		{name: "成功: 取り下げていいねしたユーザーに通知", userID: "seller1", wantRecipients: []string{"user1", "user2"}, wantCacheEvicted: true},
+		{name: "失敗: 出品者以外", userID: "other", wantErr: model.ErrNotAuthorized},
+		{name: "失敗: 価格交渉で取り置き中", userID: "seller1", reservation: &model.Offer{BuyerId: "buyer1", Status: model.OfferStatusAccepted, ExpiresAt: &expiresAt}, wantErr: model.ErrItemInTransaction},
+		{name: "失敗: 売却済み", userID: "seller1", withdrawErr: model.ErrItemInTransaction, wantErr: model.ErrItemInTransaction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withdrawn := false
+			mockItemDAO := &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return item, nil
+				},
+				WithdrawItemFunc: func(ctx context.Context, itemID string, userID string) error {
+					if tt.withdrawErr != nil {
+						return tt.withdrawErr
+					}
+					withdrawn = true
+					return nil
+				},
+				GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+					return map[string][]float32{"item1": {0.1, 0.2}}, nil
+				},
+				GetSearchDocumentsFunc: func(ctx context.Context) ([]model.ItemSearchDocument, error) {
+					return []model.ItemSearchDocument{{ItemId: "item1", Name: "Test Item"}}, nil
+				},
+			}
+			mockOfferDAO := &MockOfferDAO{
+				GetActiveReservationFunc: func(ctx context.Context, itemID string, now time.Time) (*model.Offer, error) {
+					return tt.reservation, nil
+				},
+			}
+			mockLikeDAO := &MockLikeDAO{
+				GetLikerIDsFunc: func(ctx context.Context, itemID string) ([]string, error) {
+					return []string{"user1", "seller1", "user2"}, nil
+				},
+			}
+			var recipients []string
+			mockNotificationDAO := &MockNotificationDAO{
+				CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+					recipients = append(recipients, notification.UserId)
+					return nil
+				},
+			}
+			embeddingCache := cache.NewEmbeddingCache(mockItemDAO)
+			searchIndex := search.NewInvertedIndex(mockItemDAO)
+
+			u := NewItemWithdraw(mockItemDAO, mockOfferDAO, mockLikeDAO, mockNotificationDAO, embeddingCache, searchIndex, cache.NewSellerStatsCache(time.Minute))
+			err := u.WithdrawItem(context.Background(), "item1", tt.userID)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WithdrawItem() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if withdrawn || len(recipients) > 0 {
+					t.Errorf("WithdrawItem() should not withdraw or notify on error")
+				}
+				if _, ok := embeddingCache.Get()["item1"]; !ok {
+					t.Errorf("embedding should remain in cache on error")
+				}
+				return
+			}
+			if _, ok := embeddingCache.Get()["item1"]; ok == tt.wantCacheEvicted {
+				t.Errorf("embedding should be evicted from cache")
+			}
+			if hits, _ := searchIndex.Search(context.Background(), "Test Item", 10); len(hits) != 0 {
+				t.Errorf("withdrawn item should be removed from search index")
+			}
+			// 出品者自身のいいねには通知しない
+			if len(recipients) != len(tt.wantRecipients) {
+				t.Fatalf("notification recipients = %v, want %v", recipients, tt.wantRecipients)
+			}
+			for i, r := range tt.wantRecipients {
+				if recipients[i] != r {
+					t.Errorf("notification recipients = %v, want %v", recipients, tt.wantRecipients)
+				}
+			}
+		})
+	}
+}
+
+func TestItemWithdraw_WithoutSearchIndex(t *testing.T) {
+	mockItemDAO := &MockItemDAO{
+		GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+			return &model.Item{ItemId: itemID, Name: "Test Item", UserId: "seller1", Status: model.StatusOnSale}, nil
+		},
+		GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+			return map[string][]float32{}, nil
+		},
+	}
+
+	// キーワード検索をDBで行う構成 (NewItemListにnilを渡す場合) でも取り下げられる
+	u := NewItemWithdraw(mockItemDAO, &MockOfferDAO{}, &MockLikeDAO{}, &MockNotificationDAO{}, cache.NewEmbeddingCache(mockItemDAO), nil, cache.NewSellerStatsCache(time.Minute))
+	if err := u.WithdrawItem(context.Background(), "item1", "seller1"); err != nil {
+		t.Fatalf("WithdrawItem() error = %v", err)
+	}
+}
@@ -8,20 +8,69 @@ import (
 )
 
 type LikeUsecase interface {
-	ToggleLike(ctx context.Context, userID, itemID string) error
+	Like(ctx context.Context, userID, itemID string) (*model.LikeState, error)
+	Unlike(ctx context.Context, userID, itemID string) (*model.LikeState, error)
+	ToggleLike(ctx context.Context, userID, itemID string) (*model.LikeState, error)
 	GetLikedItems(ctx context.Context, userID string) ([]model.ItemSimple, error)
 	GetLikedItemIDs(ctx context.Context, userID string) ([]string, error)
+	MarkLikedByMe(ctx context.Context, userID string, items []model.ItemSimple) error
+	MarkItemLikedByMe(ctx context.Context, userID string, item *model.Item) error
 }
 
 type likeUsecase struct {
 	likeDAO dao.LikeDAO
+	itemDAO dao.ItemDAO
 }
 
-func NewLikeUsecase(likeDAO dao.LikeDAO) LikeUsecase {
-	return &likeUsecase{likeDAO: likeDAO}
+func NewLikeUsecase(likeDAO dao.LikeDAO, itemDAO dao.ItemDAO) LikeUsecase {
+	return &likeUsecase{likeDAO: likeDAO, itemDAO: itemDAO}
 }
 
-func (u *likeUsecase) ToggleLike(ctx context.Context, userID, itemID string) error {
+// Like : いいねを登録する (登録済みでも成功し、登録後の状態を返す)
+func (u *likeUsecase) Like(ctx context.Context, userID, itemID string) (*model.LikeState, error) {
+	if err := u.checkLikeable(ctx, userID, itemID); err != nil {
+		return nil, err
+	}
+
+	if err := u.likeDAO.AddLike(ctx, userID, itemID); err != nil {
+		return nil, fmt.Errorf("failed to add like: %w", err)
+	}
+
+	return u.likeState(ctx, itemID, true)
+}
+
+// Unlike : いいねを解除する (未登録でも成功し、解除後の状態を返す)
+func (u *likeUsecase) Unlike(ctx context.Context, userID, itemID string) (*model.LikeState, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+	if itemID == "" {
+		return nil, fmt.Errorf("item ID is required")
+	}
+
+	if err := u.likeDAO.RemoveLike(ctx, userID, itemID); err != nil {
+		return nil, fmt.Errorf("failed to remove like: %w", err)
+	}
+
+	return u.likeState(ctx, itemID, false)
+}
+
+// ToggleLike : いいねを切り替える (互換性のため残している。切り替え後の状態を返す)
+func (u *likeUsecase) ToggleLike(ctx context.Context, userID, itemID string) (*model.LikeState, error) {
+	if err := u.checkLikeable(ctx, userID, itemID); err != nil {
+		return nil, err
+	}
+
+	liked, err := u.likeDAO.ToggleLike(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to toggle like: %w", err)
+	}
+
+	return u.likeState(ctx, itemID, liked)
+}
+
+// checkLikeable : いいねできる商品か確認する (取り下げ済みの商品は存在しない扱い)
+func (u *likeUsecase) checkLikeable(ctx context.Context, userID, itemID string) error {
 	if userID == "" {
 		return fmt.Errorf("user ID is required")
 	}
@@ -29,14 +78,25 @@ func (u *likeUsecase) ToggleLike(ctx context.Context, userID, itemID string) err
 		return fmt.Errorf("item ID is required")
 	}
 
-	err := u.likeDAO.ToggleLike(ctx, userID, itemID)
+	item, err := u.itemDAO.GetItem(ctx, itemID)
 	if err != nil {
-		return fmt.Errorf("failed to toggle like: %w", err)
+		return fmt.Errorf("failed to get item: %w", err)
+	}
+	if item.Status == model.StatusWithdrawn {
+		return model.ErrItemNotFound
 	}
-
 	return nil
 }
 
+// likeState : 登録・解除後の状態といいね数を返す
+func (u *likeUsecase) likeState(ctx context.Context, itemID string, liked bool) (*model.LikeState, error) {
+	count, err := u.likeDAO.CountLikes(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count likes: %w", err)
+	}
+	return &model.LikeState{ItemID: itemID, Liked: liked, LikeCount: count}, nil
+}
+
 func (u *likeUsecase) GetLikedItems(ctx context.Context, userID string) ([]model.ItemSimple, error) {
 	if userID == "" {
 		return nil, fmt.Errorf("user ID is required")
@@ -62,3 +122,41 @@ func (u *likeUsecase) GetLikedItemIDs(ctx context.Context, userID string) ([]str
 
 	return itemIDs, nil
 }
+
+// MarkLikedByMe : 一覧の各商品にユーザーがいいね済みかどうかを付ける (まとめて1回で問い合わせる)
+func (u *likeUsecase) MarkLikedByMe(ctx context.Context, userID string, items []model.ItemSimple) error {
+	if userID == "" || len(items) == 0 {
+		return nil
+	}
+
+	itemIDs := make([]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ItemId
+	}
+	liked, err := u.likeDAO.FilterLikedItemIDs(ctx, userID, itemIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get liked item IDs: %w", err)
+	}
+
+	for i := range items {
+		likedByMe := liked[items[i].ItemId]
+		items[i].LikedByMe = &likedByMe
+	}
+	return nil
+}
+
+// MarkItemLikedByMe : 商品詳細にユーザーがいいね済みかどうかを付ける
+func (u *likeUsecase) MarkItemLikedByMe(ctx context.Context, userID string, item *model.Item) error {
+	if userID == "" || item == nil {
+		return nil
+	}
+
+	liked, err := u.likeDAO.FilterLikedItemIDs(ctx, userID, []string{item.ItemId})
+	if err != nil {
+		return fmt.Errorf("failed to get liked item IDs: %w", err)
+	}
+
+	likedByMe := liked[item.ItemId]
+	item.LikedByMe = &likedByMe
+	return nil
+}
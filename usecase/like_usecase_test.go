@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"testing"
+)
+
+func TestLikeUsecase_MarkLikedByMe(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    string
+		wantCalls int
+		want      map[string]*bool
+	}{
+		{name: "成功: ログイン中はいいね済みかどうかを付ける", userID: "user1", wantCalls: 1, want: map[string]*bool{"item1": ptrBool(true), "item2": ptrBool(false)}},
+		{name: "成功: 未ログインは付けない", userID: "", wantCalls: 0, want: map[string]*bool{"item1": nil, "item2": nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			mockLikeDAO := &MockLikeDAO{
+				FilterLikedItemIDsFunc: func(ctx context.Context, userID string, itemIDs []string) (map[string]bool, error) {
+					calls++
+					if len(itemIDs) != 2 {
+						t.Errorf("FilterLikedItemIDs() itemIDs = %v, want 2 items in one call", itemIDs)
+					}
+					return map[string]bool{"item1": true}, nil
+				},
+			}
+			items := []model.ItemSimple{{ItemId: "item1"}, {ItemId: "item2"}}
+
+			u := NewLikeUsecase(mockLikeDAO, &MockItemDAO{})
+			if err := u.MarkLikedByMe(context.Background(), tt.userID, items); err != nil {
+				t.Fatalf("MarkLikedByMe() error = %v", err)
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("FilterLikedItemIDs() calls = %d, want %d", calls, tt.wantCalls)
+			}
+			for _, item := range items {
+				want := tt.want[item.ItemId]
+				if (item.LikedByMe == nil) != (want == nil) || (want != nil && *item.LikedByMe != *want) {
+					t.Errorf("%s LikedByMe = %v, want %v", item.ItemId, item.LikedByMe, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLikeUsecase_Like(t *testing.T) {
+	tests := []struct {
+		name       string
+		itemStatus string
+		itemErr    error
+		wantErr    error
+		wantAdded  bool
+	}{
+		{name: "成功: いいねして状態といいね数を返す", itemStatus: model.StatusOnSale, wantAdded: true},
+		{name: "失敗: 存在しない商品", itemErr: model.ErrItemNotFound, wantErr: model.ErrItemNotFound},
+		{name: "失敗: 取り下げ済みの商品", itemStatus: model.StatusWithdrawn, wantErr: model.ErrItemNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added := false
+			mockItemDAO := &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					if tt.itemErr != nil {
+						return nil, tt.itemErr
+					}
+					return &model.Item{ItemId: itemID, Status: tt.itemStatus}, nil
+				},
+			}
+			mockLikeDAO := &MockLikeDAO{
+				AddLikeFunc: func(ctx context.Context, userID, itemID string) error {
+					added = true
+					return nil
+				},
+				CountLikesFunc: func(ctx context.Context, itemID string) (int, error) {
+					return 3, nil
+				},
+			}
+
+			u := NewLikeUsecase(mockLikeDAO, mockItemDAO)
+			state, err := u.Like(context.Background(), "user1", "item1")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Like() error = %v, want %v", err, tt.wantErr)
+			}
+			if added != tt.wantAdded {
+				t.Errorf("AddLike called = %v, want %v", added, tt.wantAdded)
+			}
+			if tt.wantErr == nil && (state == nil || !state.Liked || state.LikeCount != 3 || state.ItemID != "item1") {
+				t.Errorf("Like() = %+v, want liked with count 3", state)
+			}
+		})
+	}
+}
+
+func TestLikeUsecase_Unlike(t *testing.T) {
+	mockLikeDAO := &MockLikeDAO{
+		CountLikesFunc: func(ctx context.Context, itemID string) (int, error) {
+			return 2, nil
+		},
+	}
+
+	u := NewLikeUsecase(mockLikeDAO, &MockItemDAO{})
+	state, err := u.Unlike(context.Background(), "user1", "item1")
+	if err != nil {
+		t.Fatalf("Unlike() error = %v", err)
+	}
+	if state.Liked || state.LikeCount != 2 {
+		t.Errorf("Unlike() = %+v, want not liked with count 2", state)
+	}
+}
+
+func ptrBool(b bool) *bool {
+	return &b
+}
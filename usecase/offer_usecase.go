@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"db/cache"
+	"db/dao"
+	"db/hub"
+	"db/model"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+type OfferUsecase interface {
+	CreateOffer(ctx context.Context, itemID, buyerID string, price int) (*model.Offer, error)
+	GetOffer(ctx context.Context, offerID, userID string) (*model.Offer, error)
+	RespondOffer(ctx context.Context, offerID, userID, action string, price int) (*model.Offer, error)
+}
+
+type offerUsecase struct {
+	offerDAO        dao.OfferDAO
+	itemDAO         dao.ItemDAO
+	chatDAO         dao.ChatDAO
+	notificationDAO dao.NotificationDAO
+	blockDAO        dao.BlockDAO
+	chatHub         hub.ChatHub
+	statsCache      *cache.SellerStatsCache
+}
+
+func NewOfferUsecase(offerDAO dao.OfferDAO, itemDAO dao.ItemDAO, chatDAO dao.ChatDAO, notificationDAO dao.NotificationDAO, blockDAO dao.BlockDAO, chatHub hub.ChatHub, statsCache *cache.SellerStatsCache) OfferUsecase {
+	return &offerUsecase{
+		offerDAO:        offerDAO,
+		itemDAO:         itemDAO,
+		chatDAO:         chatDAO,
+		notificationDAO: notificationDAO,
+		blockDAO:        blockDAO,
+		chatHub:         chatHub,
+		statsCache:      statsCache,
+	}
+}
+
+// CreateOffer : 購入希望者が販売中の商品に価格を提案する
+// 提案は商品のチャットルームにシステムメッセージとして投稿し、出品者に通知する
+func (u *offerUsecase) CreateOffer(ctx context.Context, itemID, buyerID string, price int) (*model.Offer, error) {
+	if price <= 0 {
+		return nil, model.ErrInvalidRequest
+	}
+
+	item, err := u.itemDAO.GetItem(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item: %w", err)
+	}
+	if err := checkOnSale(item); err != nil {
+		return nil, err
+	}
+	if buyerID == item.UserId {
+		return nil, model.ErrCannotOfferOwnItem
+	}
+	// ブロック関係にある出品者には提案できない (提案はチャットルームを作るため)
+	if err := checkNotBlocked(ctx, u.blockDAO, buyerID, item.UserId); err != nil {
+		return nil, err
+	}
+
+	t := time.Now()
+
+	// 他の購入希望者のために取り置き中なら提案できない
+	if err := u.checkReservation(ctx, itemID, buyerID, t); err != nil {
+		return nil, err
+	}
+
+	// 回答待ちの提案は1商品につき1件まで
+	pending, err := u.offerDAO.GetPendingOffer(ctx, itemID, buyerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check pending offer: %w", err)
+	}
+	if pending != nil {
+		return nil, model.ErrOfferAlreadyPending
+	}
+
+	room, err := getOrCreateChatRoom(ctx, u.chatDAO, item, buyerID)
+	if err != nil {
+		return nil, err
+	}
+
+	entropy := ulid.Monotonic(rand.Reader, 0)
+	offer := &model.Offer{
+		Id:         ulid.MustNew(ulid.Timestamp(t), entropy).String(),
+		ItemId:     itemID,
+		ChatRoomId: room.Id,
+		BuyerId:    buyerID,
+		SellerId:   item.UserId,
+		Price:      price,
+		ProposedBy: buyerID,
+		Status:     model.OfferStatusPending,
+		CreatedAt:  t,
+		UpdatedAt:  t,
+	}
+	if err := u.offerDAO.CreateOffer(ctx, offer); err != nil {
+		return nil, fmt.Errorf("failed to create offer: %w", err)
+	}
+
+	u.announce(ctx, offer, item, buyerID,
+		fmt.Sprintf("%d円で価格を提案しました", price),
+		fmt.Sprintf("%sに価格の提案が届きました", item.Name),
+	)
+
+	return offer, nil
+}
+
+// GetOffer : 価格交渉を取得 (当事者のみ)
+func (u *offerUsecase) GetOffer(ctx context.Context, offerID, userID string) (*model.Offer, error) {
+	offer, err := u.offerDAO.GetOffer(ctx, offerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offer: %w", err)
+	}
+	if !offer.IsParticipant(userID) {
+		return nil, model.ErrNotOfferParticipant
+	}
+	return offer, nil
+}
+
+// RespondOffer : 最後に金額を提示した相手側が、提案を承諾・拒否・再提示する
+// 承諾すると取り置き期間中は合意した購入者だけが合意した金額で購入できる
+func (u *offerUsecase) RespondOffer(ctx context.Context, offerID, userID, action string, price int) (*model.Offer, error) {
+	offer, err := u.GetOffer(ctx, offerID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.Status != model.OfferStatusPending {
+		return nil, fmt.Errorf("%w: offer is %s", model.ErrInvalidOfferTransition, offer.Status)
+	}
+	if userID == offer.ProposedBy {
+		return nil, fmt.Errorf("%w: waiting for the other party", model.ErrInvalidOfferTransition)
+	}
+	// 提案後にブロックした(された)相手とは交渉を進められない
+	if err := checkNotBlocked(ctx, u.blockDAO, offer.BuyerId, offer.SellerId); err != nil {
+		return nil, err
+	}
+
+	item, err := u.itemDAO.GetItem(ctx, offer.ItemId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item: %w", err)
+	}
+
+	t := time.Now()
+	prevProposedBy := offer.ProposedBy
+	var content, notice string
+
+	switch action {
+	case model.OfferActionAccept:
+		// 他の購入希望者の取り置きがないかはDAOが商品の行をロックして確かめる (ErrItemReserved)
+		if err := checkOnSale(item); err != nil {
+			return nil, err
+		}
+		expiresAt := t.Add(model.OfferReservationWindow)
+		offer.Status = model.OfferStatusAccepted
+		offer.ExpiresAt = &expiresAt
+		content = fmt.Sprintf("%d円で合意しました。%d時間以内に購入してください", offer.Price, int(model.OfferReservationWindow.Hours()))
+		notice = "%sの価格交渉が成立しました"
+	case model.OfferActionReject:
+		offer.Status = model.OfferStatusRejected
+		content = fmt.Sprintf("%d円の提案を見送りました", offer.Price)
+		notice = "%sの価格の提案が見送られました"
+	case model.OfferActionCounter:
+		if price <= 0 {
+			return nil, model.ErrInvalidRequest
+		}
+		if err := checkOnSale(item); err != nil {
+			return nil, err
+		}
+		offer.Price = price
+		offer.ProposedBy = userID
+		content = fmt.Sprintf("%d円で価格を再提案しました", price)
+		notice = "%sに価格の再提案が届きました"
+	default:
+		return nil, fmt.Errorf("%w: unknown action %q", model.ErrInvalidRequest, action)
+	}
+	offer.UpdatedAt = t
+
+	if err := u.offerDAO.RespondOffer(ctx, offer, prevProposedBy); err != nil {
+		return nil, fmt.Errorf("failed to update offer: %w", err)
+	}
+
+	u.announce(ctx, offer, item, userID, content, fmt.Sprintf(notice, item.Name))
+
+	return offer, nil
+}
+
+// checkOnSale : 販売中の商品でなければ価格交渉できない
+func checkOnSale(item *model.Item) error {
+	if item.Status != model.StatusOnSale {
+		return fmt.Errorf("%w: item is not on sale", model.ErrInvalidOfferTransition)
+	}
+	return nil
+}
+
+// checkReservation : 他の購入希望者のために取り置き中ならErrItemReservedを返す
+func (u *offerUsecase) checkReservation(ctx context.Context, itemID, buyerID string, now time.Time) error {
+	reservation, err := u.offerDAO.GetActiveReservation(ctx, itemID, now)
+	if err != nil {
+		return fmt.Errorf("failed to check reservation: %w", err)
+	}
+	if reservation != nil && reservation.BuyerId != buyerID {
+		return model.ErrItemReserved
+	}
+	return nil
+}
+
+// announce : 価格交渉の内容をチャットにシステムメッセージとして投稿し、相手に通知する
+// どちらも失敗しても価格交渉自体は成功とする
+func (u *offerUsecase) announce(ctx context.Context, offer *model.Offer, item *model.Item, actorID, content, notice string) {
+	t := time.Now()
+	entropy := ulid.Monotonic(rand.Reader, 0)
+
+	msg := &model.Message{
+		Id:         ulid.MustNew(ulid.Timestamp(t), entropy).String(),
+		ChatRoomId: offer.ChatRoomId,
+		SenderId:   actorID,
+		Type:       model.MessageTypeOffer,
+		OfferId:    offer.Id,
+		Content:    content,
+		CreatedAt:  t,
+	}
+	if err := u.chatDAO.SaveMessage(ctx, msg); err != nil {
+		log.Printf("Warning: failed to save offer message: %v\n", err)
+	} else {
+		// 価格交渉のメッセージも出品者の返信率・返信時間に数える
+		u.statsCache.Invalidate(offer.SellerId)
+		if err := u.chatHub.Publish(ctx, offer.ChatRoomId, *msg); err != nil {
+			log.Printf("Warning: failed to publish message: %v\n", err)
+		}
+	}
+
+	recipientID := offer.SellerId
+	if actorID == offer.SellerId {
+		recipientID = offer.BuyerId
+	}
+	notification := &model.Notification{
+		UserId:   recipientID,
+		Type:     model.NotificationTypeOffer,
+		ItemId:   offer.ItemId,
+		ItemName: item.Name,
+		Message:  notice,
+	}
+	if err := u.notificationDAO.CreateNotification(ctx, notification); err != nil {
+		log.Printf("Warning: failed to create notification: %v\n", err)
+	}
+}
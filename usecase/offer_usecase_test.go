@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/hub"
+	"db/model"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockOfferDAO : dao.OfferDAO のモック
+type MockOfferDAO struct {
+	CreateOfferFunc          func(ctx context.Context, offer *model.Offer) error
+	GetOfferFunc             func(ctx context.Context, offerID string) (*model.Offer, error)
+	GetPendingOfferFunc      func(ctx context.Context, itemID, buyerID string) (*model.Offer, error)
+	GetActiveReservationFunc func(ctx context.Context, itemID string, now time.Time) (*model.Offer, error)
+	RespondOfferFunc         func(ctx context.Context, offer *model.Offer, prevProposedBy string) error
+}
+
+func (m *MockOfferDAO) CreateOffer(ctx context.Context, offer *model.Offer) error {
+	if m.CreateOfferFunc != nil {
+		return m.CreateOfferFunc(ctx, offer)
+	}
+	return nil
+}
+
+func (m *MockOfferDAO) GetOffer(ctx context.Context, offerID string) (*model.Offer, error) {
+	if m.GetOfferFunc != nil {
+		return m.GetOfferFunc(ctx, offerID)
+	}
+	return nil, model.ErrOfferNotFound
+}
+
+func (m *MockOfferDAO) GetPendingOffer(ctx context.Context, itemID, buyerID string) (*model.Offer, error) {
+	if m.GetPendingOfferFunc != nil {
+		return m.GetPendingOfferFunc(ctx, itemID, buyerID)
+	}
+	return nil, nil
+}
+
+func (m *MockOfferDAO) GetActiveReservation(ctx context.Context, itemID string, now time.Time) (*model.Offer, error) {
+	if m.GetActiveReservationFunc != nil {
+		return m.GetActiveReservationFunc(ctx, itemID, now)
+	}
+	return nil, nil
+}
+
+func (m *MockOfferDAO) RespondOffer(ctx context.Context, offer *model.Offer, prevProposedBy string) error {
+	if m.RespondOfferFunc != nil {
+		return m.RespondOfferFunc(ctx, offer, prevProposedBy)
+	}
+	return nil
+}
+
+func TestOfferUsecase_CreateOffer(t *testing.T) {
+	onSale := &model.Item{ItemId: "item1", Name: "Test Item", UserId: "seller1", Price: 1000, Status: model.StatusOnSale}
+	sold := &model.Item{ItemId: "item1", Name: "Test Item", UserId: "seller1", Price: 1000, Status: model.StatusSold}
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		item        *model.Item
+		buyerID     string
+		price       int
+		pending     *model.Offer
+		reservation *model.Offer
+		wantErr     error
+	}{
+		{name: "成功: 購入希望者が価格を提案", item: onSale, buyerID: "buyer1", price: 800},
+		{name: "失敗: 金額が0", item: onSale, buyerID: "buyer1", price: 0, wantErr: model.ErrInvalidRequest},
+		{name: "失敗: 自分の商品", item: onSale, buyerID: "seller1", price: 800, wantErr: model.ErrCannotOfferOwnItem},
+		{name: "失敗: 売り切れ", item: sold, buyerID: "buyer1", price: 800, wantErr: model.ErrInvalidOfferTransition},
+		{name: "失敗: 回答待ちの提案がある", item: onSale, buyerID: "buyer1", price: 800, pending: &model.Offer{Id: "offer0"}, wantErr: model.ErrOfferAlreadyPending},
+		{name: "失敗: 他の購入希望者のために取り置き中", item: onSale, buyerID: "buyer1", price: 800, reservation: &model.Offer{BuyerId: "buyer2", Status: model.OfferStatusAccepted, ExpiresAt: &expiresAt}, wantErr: model.ErrItemReserved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *model.Offer
+			mockOfferDAO := &MockOfferDAO{
+				CreateOfferFunc: func(ctx context.Context, offer *model.Offer) error {
+					created = offer
+					return nil
+				},
+				GetPendingOfferFunc: func(ctx context.Context, itemID, buyerID string) (*model.Offer, error) {
+					return tt.pending, nil
+				},
+				GetActiveReservationFunc: func(ctx context.Context, itemID string, now time.Time) (*model.Offer, error) {
+					return tt.reservation, nil
+				},
+			}
+			mockItemDAO := &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return tt.item, nil
+				},
+			}
+			var saved *model.Message
+			mockChatDAO := &MockChatDAO{
+				SaveMessageFunc: func(ctx context.Context, msg *model.Message) error {
+					saved = msg
+					return nil
+				},
+			}
+			var recipient string
+			mockNotificationDAO := &MockNotificationDAO{
+				CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+					recipient = notification.UserId
+					return nil
+				},
+			}
+
+			u := NewOfferUsecase(mockOfferDAO, mockItemDAO, mockChatDAO, mockNotificationDAO, &MockBlockDAO{}, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+			offer, err := u.CreateOffer(context.Background(), "item1", tt.buyerID, tt.price)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateOffer() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if created != nil {
+					t.Errorf("CreateOffer() should not save offer on error")
+				}
+				return
+			}
+			if offer.Status != model.OfferStatusPending || offer.ProposedBy != tt.buyerID || offer.SellerId != "seller1" || offer.ChatRoomId == "" {
+				t.Errorf("CreateOffer() = %+v", offer)
+			}
+			// チャットにシステムメッセージとして投稿される
+			if saved == nil || saved.Type != model.MessageTypeOffer || saved.OfferId != offer.Id || saved.ChatRoomId != offer.ChatRoomId {
+				t.Errorf("offer system message = %+v", saved)
+			}
+			if recipient != "seller1" {
+				t.Errorf("notification recipient = %v, want seller1", recipient)
+			}
+		})
+	}
+}
+
+func TestOfferUsecase_RespondOffer(t *testing.T) {
+	onSale := &model.Item{ItemId: "item1", Name: "Test Item", UserId: "seller1", Price: 1000, Status: model.StatusOnSale}
+	sold := &model.Item{ItemId: "item1", Name: "Test Item", UserId: "seller1", Price: 1000, Status: model.StatusSold}
+
+	tests := []struct {
+		name          string
+		item          *model.Item
+		status        string
+		proposedBy    string
+		userID        string
+		action        string
+		price         int
+		blocked       bool
+		respondErr    error
+		wantErr       error
+		wantStatus    string
+		wantPrice     int
+		wantRecipient string
+	}{
+		{name: "成功: 出品者が承諾", item: onSale, status: model.OfferStatusPending, proposedBy: "buyer1", userID: "seller1", action: model.OfferActionAccept, wantStatus: model.OfferStatusAccepted, wantPrice: 800, wantRecipient: "buyer1"},
+		{name: "成功: 出品者が拒否", item: onSale, status: model.OfferStatusPending, proposedBy: "buyer1", userID: "seller1", action: model.OfferActionReject, wantStatus: model.OfferStatusRejected, wantPrice: 800, wantRecipient: "buyer1"},
+		{name: "成功: 出品者が再提示", item: onSale, status: model.OfferStatusPending, proposedBy: "buyer1", userID: "seller1", action: model.OfferActionCounter, price: 900, wantStatus: model.OfferStatusPending, wantPrice: 900, wantRecipient: "buyer1"},
+		{name: "成功: 再提示を購入希望者が承諾", item: onSale, status: model.OfferStatusPending, proposedBy: "seller1", userID: "buyer1", action: model.OfferActionAccept, wantStatus: model.OfferStatusAccepted, wantPrice: 800, wantRecipient: "seller1"},
+		{name: "失敗: 提案した本人は回答できない", item: onSale, status: model.OfferStatusPending, proposedBy: "buyer1", userID: "buyer1", action: model.OfferActionAccept, wantErr: model.ErrInvalidOfferTransition},
+		{name: "失敗: 第三者", item: onSale, status: model.OfferStatusPending, proposedBy: "buyer1", userID: "other", action: model.OfferActionAccept, wantErr: model.ErrNotOfferParticipant},
+		{name: "失敗: 回答済み", item: onSale, status: model.OfferStatusRejected, proposedBy: "buyer1", userID: "seller1", action: model.OfferActionAccept, wantErr: model.ErrInvalidOfferTransition},
+		{name: "失敗: 売り切れ後の承諾", item: sold, status: model.OfferStatusPending, proposedBy: "buyer1", userID: "seller1", action: model.OfferActionAccept, wantErr: model.ErrInvalidOfferTransition},
+		{name: "失敗: 他の購入希望者と合意済み", item: onSale, status: model.OfferStatusPending, proposedBy: "buyer1", userID: "seller1", action: model.OfferActionAccept, respondErr: model.ErrItemReserved, wantErr: model.ErrItemReserved},
+		{name: "失敗: 提案後にブロック関係になった", item: onSale, status: model.OfferStatusPending, proposedBy: "buyer1", userID: "seller1", action: model.OfferActionAccept, blocked: true, wantErr: model.ErrUserBlocked},
+		{name: "失敗: 再提示の金額が0", item: onSale, status: model.OfferStatusPending, proposedBy: "buyer1", userID: "seller1", action: model.OfferActionCounter, wantErr: model.ErrInvalidRequest},
+		{name: "失敗: 未知のアクション", item: onSale, status: model.OfferStatusPending, proposedBy: "buyer1", userID: "seller1", action: "withdraw", wantErr: model.ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := false
+			mockOfferDAO := &MockOfferDAO{
+				GetOfferFunc: func(ctx context.Context, offerID string) (*model.Offer, error) {
+					return &model.Offer{Id: offerID, ItemId: "item1", ChatRoomId: "room1", BuyerId: "buyer1", SellerId: "seller1", Price: 800, ProposedBy: tt.proposedBy, Status: tt.status}, nil
+				},
+				RespondOfferFunc: func(ctx context.Context, offer *model.Offer, prevProposedBy string) error {
+					if prevProposedBy != tt.proposedBy {
+						t.Errorf("RespondOffer() prevProposedBy = %v, want %v", prevProposedBy, tt.proposedBy)
+					}
+					// 他の購入希望者の取り置きはDAOが商品の行をロックして確かめる
+					if tt.respondErr != nil {
+						return tt.respondErr
+					}
+					updated = true
+					return nil
+				},
+			}
+			mockItemDAO := &MockItemDAO{
+				GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+					return tt.item, nil
+				},
+			}
+			mockBlockDAO := &MockBlockDAO{
+				IsBlockedFunc: func(ctx context.Context, userID, otherID string) (bool, error) {
+					return tt.blocked, nil
+				},
+			}
+			var recipient string
+			mockNotificationDAO := &MockNotificationDAO{
+				CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+					recipient = notification.UserId
+					return nil
+				},
+			}
+
+			u := NewOfferUsecase(mockOfferDAO, mockItemDAO, &MockChatDAO{}, mockNotificationDAO, mockBlockDAO, hub.NewLocalChatHub(), cache.NewSellerStatsCache(time.Minute))
+			offer, err := u.RespondOffer(context.Background(), "offer1", tt.userID, tt.action, tt.price)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RespondOffer() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if updated {
+					t.Errorf("RespondOffer() should not update offer on error")
+				}
+				if recipient != "" {
+					t.Errorf("RespondOffer() should not notify on error")
+				}
+				return
+			}
+			if offer.Status != tt.wantStatus || offer.Price != tt.wantPrice {
+				t.Errorf("RespondOffer() status = %v price = %v, want %v %v", offer.Status, offer.Price, tt.wantStatus, tt.wantPrice)
+			}
+			if tt.wantStatus == model.OfferStatusAccepted && !offer.IsReserving(time.Now()) {
+				t.Errorf("accepted offer should reserve item, expires_at = %v", offer.ExpiresAt)
+			}
+			if tt.action == model.OfferActionCounter && offer.ProposedBy != tt.userID {
+				t.Errorf("counter should switch proposer, got %v", offer.ProposedBy)
+			}
+			if recipient != tt.wantRecipient {
+				t.Errorf("notification recipient = %v, want %v", recipient, tt.wantRecipient)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/dao"
+	"db/model"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+)
+
+type OrderUsecase interface {
+	GetOrder(ctx context.Context, orderID, userID string) (*model.Order, error)
+	TransitionOrder(ctx context.Context, orderID, userID, action string) (*model.Order, error)
+}
+
+// 状態遷移を実行できる当事者
+const (
+	orderActorBuyer  = "buyer"
+	orderActorSeller = "seller"
+	orderActorEither = "either"
+)
+
+// orderTransition : アクションごとの状態遷移の定義
+type orderTransition struct {
+	from   []string
+	to     string
+	actor  string
+	notice string // 相手への通知文 (%sに商品名)
+}
+
+// orderTransitions : 取引の状態遷移表
+// 支払い待ち → 支払い済み → 発送済み → 受取済み → 完了 (発送前ならキャンセル可能)
+var orderTransitions = map[string]orderTransition{
+	model.OrderActionPay: {
+		from:   []string{model.OrderStatusAwaitingPayment},
+		to:     model.OrderStatusPaid,
+		actor:  orderActorBuyer,
+		notice: "%sの支払いが完了しました。発送してください",
+	},
+	model.OrderActionShip: {
+		from:   []string{model.OrderStatusPaid},
+		to:     model.OrderStatusShipped,
+		actor:  orderActorSeller,
+		notice: "%sが発送されました",
+	},
+	model.OrderActionReceive: {
+		from:   []string{model.OrderStatusShipped},
+		to:     model.OrderStatusReceived,
+		actor:  orderActorBuyer,
+		notice: "%sが受け取られました",
+	},
+	model.OrderActionComplete: {
+		from:   []string{model.OrderStatusReceived},
+		to:     model.OrderStatusCompleted,
+		actor:  orderActorSeller,
+		notice: "%sの取引が完了しました",
+	},
+	model.OrderActionCancel: {
+		from:   []string{model.OrderStatusAwaitingPayment, model.OrderStatusPaid},
+		to:     model.OrderStatusCancelled,
+		actor:  orderActorEither,
+		notice: "%sの取引がキャンセルされました",
+	},
+}
+
+type orderUsecase struct {
+	orderDAO        dao.OrderDAO
+	itemDAO         dao.ItemDAO
+	notificationDAO dao.NotificationDAO
+	embeddingCache  *cache.EmbeddingCache
+	statsCache      *cache.SellerStatsCache
+}
+
+func NewOrderUsecase(orderDAO dao.OrderDAO, itemDAO dao.ItemDAO, notificationDAO dao.NotificationDAO, embeddingCache *cache.EmbeddingCache, statsCache *cache.SellerStatsCache) OrderUsecase {
+	return &orderUsecase{
+		orderDAO:        orderDAO,
+		itemDAO:         itemDAO,
+		notificationDAO: notificationDAO,
+		embeddingCache:  embeddingCache,
+		statsCache:      statsCache,
+	}
+}
+
+// GetOrder : 取引を取得 (当事者のみ)
+func (u *orderUsecase) GetOrder(ctx context.Context, orderID, userID string) (*model.Order, error) {
+	order, err := u.orderDAO.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if !order.IsParticipant(userID) {
+		return nil, model.ErrNotOrderParticipant
+	}
+	return order, nil
+}
+
+// TransitionOrder : アクションに応じて取引の状態を進め、相手に通知する
+func (u *orderUsecase) TransitionOrder(ctx context.Context, orderID, userID, action string) (*model.Order, error) {
+	transition, ok := orderTransitions[action]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown action %q", model.ErrInvalidRequest, action)
+	}
+
+	order, err := u.GetOrder(ctx, orderID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 実行できる当事者か
+	switch transition.actor {
+	case orderActorBuyer:
+		if userID != order.BuyerId {
+			return nil, model.ErrNotOrderParticipant
+		}
+	case orderActorSeller:
+		if userID != order.SellerId {
+			return nil, model.ErrNotOrderParticipant
+		}
+	}
+
+	// 現在の状態から遷移できるか
+	if !slices.Contains(transition.from, order.Status) {
+		return nil, fmt.Errorf("%w: cannot %s order in %s", model.ErrInvalidOrderTransition, action, order.Status)
+	}
+
+	if transition.to == model.OrderStatusCancelled {
+		if err := u.orderDAO.CancelOrder(ctx, orderID, order.Status); err != nil {
+			return nil, fmt.Errorf("failed to cancel order: %w", err)
+		}
+		u.restoreEmbedding(ctx, order.ItemId)
+		// 販売中に戻るので出品者の売却数・出品数・売れるまでの時間が変わる
+		u.statsCache.Invalidate(order.SellerId)
+	} else {
+		if err := u.orderDAO.UpdateOrderStatus(ctx, orderID, order.Status, transition.to); err != nil {
+			return nil, fmt.Errorf("failed to update order status: %w", err)
+		}
+	}
+
+	order.Status = transition.to
+	order.UpdatedAt = time.Now()
+
+	// 相手に通知 (失敗しても遷移は成功とする)
+	recipientID := order.SellerId
+	if userID == order.SellerId {
+		recipientID = order.BuyerId
+	}
+	notification := &model.Notification{
+		UserId:   recipientID,
+		Type:     model.NotificationTypeOrder,
+		ItemId:   order.ItemId,
+		ItemName: order.ItemName,
+		Message:  fmt.Sprintf(transition.notice, order.ItemName),
+	}
+	if err := u.notificationDAO.CreateNotification(ctx, notification); err != nil {
+		log.Printf("Warning: failed to create notification: %v\n", err)
+	}
+
+	return order, nil
+}
+
+// restoreEmbedding : キャンセルで販売中に戻った商品をおすすめ対象に戻す
+func (u *orderUsecase) restoreEmbedding(ctx context.Context, itemID string) {
+	embedding, err := u.itemDAO.GetItemEmbedding(ctx, itemID)
+	if err != nil {
+		log.Printf("Warning: failed to get embedding: %v\n", err)
+		return
+	}
+	u.embeddingCache.Set(itemID, embedding)
+}
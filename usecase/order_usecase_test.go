@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/model"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockOrderDAO : dao.OrderDAO のモック
+type MockOrderDAO struct {
+	GetOrderFunc                func(ctx context.Context, orderID string) (*model.Order, error)
+	GetCompletedOrderByItemFunc func(ctx context.Context, itemID string) (*model.Order, error)
+	UpdateOrderStatusFunc       func(ctx context.Context, orderID string, fromStatus string, toStatus string) error
+	CancelOrderFunc             func(ctx context.Context, orderID string, fromStatus string) error
+}
+
+func (m *MockOrderDAO) GetOrder(ctx context.Context, orderID string) (*model.Order, error) {
+	if m.GetOrderFunc != nil {
+		return m.GetOrderFunc(ctx, orderID)
+	}
+	return nil, model.ErrOrderNotFound
+}
+
+func (m *MockOrderDAO) GetCompletedOrderByItem(ctx context.Context, itemID string) (*model.Order, error) {
+	if m.GetCompletedOrderByItemFunc != nil {
+		return m.GetCompletedOrderByItemFunc(ctx, itemID)
+	}
+	return nil, model.ErrOrderNotFound
+}
+
+func (m *MockOrderDAO) UpdateOrderStatus(ctx context.Context, orderID string, fromStatus string, toStatus string) error {
+	if m.UpdateOrderStatusFunc != nil {
+		return m.UpdateOrderStatusFunc(ctx, orderID, fromStatus, toStatus)
+	}
+	return nil
+}
+
+func (m *MockOrderDAO) CancelOrder(ctx context.Context, orderID string, fromStatus string) error {
+	if m.CancelOrderFunc != nil {
+		return m.CancelOrderFunc(ctx, orderID, fromStatus)
+	}
+	return nil
+}
+
+func TestOrderUsecase_TransitionOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        string
+		userID        string
+		action        string
+		wantStatus    string
+		wantErr       error
+		wantRecipient string
+	}{
+		{name: "成功: 購入者が支払い", status: model.OrderStatusAwaitingPayment, userID: "buyer1", action: model.OrderActionPay, wantStatus: model.OrderStatusPaid, wantRecipient: "seller1"},
+		{name: "成功: 出品者が発送", status: model.OrderStatusPaid, userID: "seller1", action: model.OrderActionShip, wantStatus: model.OrderStatusShipped, wantRecipient: "buyer1"},
+		{name: "成功: 購入者が受取", status: model.OrderStatusShipped, userID: "buyer1", action: model.OrderActionReceive, wantStatus: model.OrderStatusReceived, wantRecipient: "seller1"},
+		{name: "成功: 出品者が完了", status: model.OrderStatusReceived, userID: "seller1", action: model.OrderActionComplete, wantStatus: model.OrderStatusCompleted, wantRecipient: "buyer1"},
+		{name: "成功: 出品者がキャンセル", status: model.OrderStatusPaid, userID: "seller1", action: model.OrderActionCancel, wantStatus: model.OrderStatusCancelled, wantRecipient: "buyer1"},
+		{name: "成功: 購入者がキャンセル", status: model.OrderStatusAwaitingPayment, userID: "buyer1", action: model.OrderActionCancel, wantStatus: model.OrderStatusCancelled, wantRecipient: "seller1"},
+		{name: "失敗: 出品者は支払いできない", status: model.OrderStatusAwaitingPayment, userID: "seller1", action: model.OrderActionPay, wantErr: model.ErrNotOrderParticipant},
+		{name: "失敗: 購入者は発送できない", status: model.OrderStatusPaid, userID: "buyer1", action: model.OrderActionShip, wantErr: model.ErrNotOrderParticipant},
+		{name: "失敗: 第三者", status: model.OrderStatusAwaitingPayment, userID: "other", action: model.OrderActionCancel, wantErr: model.ErrNotOrderParticipant},
+		{name: "失敗: 支払い前に発送", status: model.OrderStatusAwaitingPayment, userID: "seller1", action: model.OrderActionShip, wantErr: model.ErrInvalidOrderTransition},
+		{name: "失敗: 発送後はキャンセル不可", status: model.OrderStatusShipped, userID: "buyer1", action: model.OrderActionCancel, wantErr: model.ErrInvalidOrderTransition},
+		{name: "失敗: 完了後は操作不可", status: model.OrderStatusCompleted, userID: "seller1", action: model.OrderActionComplete, wantErr: model.ErrInvalidOrderTransition},
+		{name: "失敗: 未知のアクション", status: model.OrderStatusPaid, userID: "seller1", action: "refund", wantErr: model.ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updatedTo string
+			cancelled := false
+			mockOrderDAO := &MockOrderDAO{
+				GetOrderFunc: func(ctx context.Context, orderID string) (*model.Order, error) {
+					return &model.Order{Id: orderID, ItemId: "item1", ItemName: "Test Item", BuyerId: "buyer1", SellerId: "seller1", Status: tt.status}, nil
+				},
+				UpdateOrderStatusFunc: func(ctx context.Context, orderID string, fromStatus string, toStatus string) error {
+					if fromStatus != tt.status {
+						t.Errorf("UpdateOrderStatus() from = %v, want %v", fromStatus, tt.status)
+					}
+					updatedTo = toStatus
+					return nil
+				},
+				CancelOrderFunc: func(ctx context.Context, orderID string, fromStatus string) error {
+					cancelled = true
+					return nil
+				},
+			}
+			mockItemDAO := &MockItemDAO{
+				GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+					return map[string][]float32{}, nil
+				},
+				GetItemEmbeddingFunc: func(ctx context.Context, itemID string) ([]float32, error) {
+					return []float32{0.1, 0.2}, nil
+				},
+			}
+			var recipient string
+			mockNotificationDAO := &MockNotificationDAO{
+				CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+					recipient = notification.UserId
+					return nil
+				},
+			}
+			embeddingCache := cache.NewEmbeddingCache(mockItemDAO)
+
+			u := NewOrderUsecase(mockOrderDAO, mockItemDAO, mockNotificationDAO, embeddingCache, cache.NewSellerStatsCache(time.Minute))
+			order, err := u.TransitionOrder(context.Background(), "order1", tt.userID, tt.action)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TransitionOrder() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if updatedTo != "" || cancelled {
+					t.Errorf("TransitionOrder() should not change state on error")
+				}
+				return
+			}
+			if order.Status != tt.wantStatus {
+				t.Errorf("TransitionOrder() status = %v, want %v", order.Status, tt.wantStatus)
+			}
+			if recipient != tt.wantRecipient {
+				t.Errorf("notification recipient = %v, want %v", recipient, tt.wantRecipient)
+			}
+			if tt.wantStatus == model.OrderStatusCancelled {
+				if !cancelled {
+					t.Errorf("CancelOrder() should be called")
+				}
+				// キャンセルされた商品はおすすめ対象に戻る
+				if _, ok := embeddingCache.Get()["item1"]; !ok {
+					t.Errorf("embedding should be restored to cache after cancel")
+				}
+			} else if updatedTo != tt.wantStatus {
+				t.Errorf("UpdateOrderStatus() to = %v, want %v", updatedTo, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestOrderUsecase_GetOrder(t *testing.T) {
+	mockOrderDAO := &MockOrderDAO{
+		GetOrderFunc: func(ctx context.Context, orderID string) (*model.Order, error) {
+			if orderID != "order1" {
+				return nil, model.ErrOrderNotFound
+			}
+			return &model.Order{Id: "order1", BuyerId: "buyer1", SellerId: "seller1", Status: model.OrderStatusPaid}, nil
+		},
+	}
+	u := NewOrderUsecase(mockOrderDAO, &MockItemDAO{}, &MockNotificationDAO{}, nil, nil)
+
+	tests := []struct {
+		name    string
+		orderID string
+		userID  string
+		wantErr error
+	}{
+		{name: "成功: 購入者", orderID: "order1", userID: "buyer1"},
+		{name: "成功: 出品者", orderID: "order1", userID: "seller1"},
+		{name: "失敗: 第三者", orderID: "order1", userID: "other", wantErr: model.ErrNotOrderParticipant},
+		{name: "失敗: 存在しない取引", orderID: "missing", userID: "buyer1", wantErr: model.ErrOrderNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := u.GetOrder(context.Background(), tt.orderID, tt.userID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetOrder() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
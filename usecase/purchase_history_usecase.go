@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"db/dao"
+	"db/model"
+	"fmt"
+)
+
+type PurchaseHistory interface {
+	GetPurchasedItems(ctx context.Context, userID string, limit int, offset int) ([]model.PurchasedItem, error)
+	GetSoldItems(ctx context.Context, userID string, limit int, offset int) ([]model.SoldItem, error)
+}
+
+type purchaseHistory struct {
+	itemDAO dao.ItemDAO
+}
+
+func NewPurchaseHistory(itemDAO dao.ItemDAO) PurchaseHistory {
+	return &purchaseHistory{itemDAO: itemDAO}
+}
+
+// GetPurchasedItems : ログインユーザーが購入した商品一覧を取得
+func (u *purchaseHistory) GetPurchasedItems(ctx context.Context, userID string, limit int, offset int) ([]model.PurchasedItem, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	items, err := u.itemDAO.GetPurchasedItems(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchased items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetSoldItems : ログインユーザーの出品で売れた商品一覧を取得
+func (u *purchaseHistory) GetSoldItems(ctx context.Context, userID string, limit int, offset int) ([]model.SoldItem, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	items, err := u.itemDAO.GetSoldItems(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sold items: %w", err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"testing"
+)
+
+func TestPurchaseHistory_GetPurchasedItems(t *testing.T) {
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		userID    string
+		daoErr    error
+		wantErr   bool
+		wantCount int
+	}{
+		{name: "成功: 購入した商品を取得", userID: "buyer1", wantCount: 2},
+		{name: "失敗: ユーザーIDが空", userID: "", wantErr: true},
+		{name: "失敗: DBエラー", userID: "buyer1", daoErr: dbErr, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			mockItemDAO := &MockItemDAO{
+				GetPurchasedItemsFunc: func(ctx context.Context, buyerID string, limit int, offset int) ([]model.PurchasedItem, error) {
+					called = true
+					if buyerID != tt.userID || limit != 20 || offset != 40 {
+						t.Errorf("GetPurchasedItems() called with (%q, %d, %d), want (%q, 20, 40)", buyerID, limit, offset, tt.userID)
+					}
+					if tt.daoErr != nil {
+						return nil, tt.daoErr
+					}
+					return []model.PurchasedItem{{ItemId: "item2", SellerId: "seller1"}, {ItemId: "item1", SellerId: "seller2"}}, nil
+				},
+			}
+
+			u := NewPurchaseHistory(mockItemDAO)
+			items, err := u.GetPurchasedItems(context.Background(), tt.userID, 20, 40)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPurchasedItems() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.daoErr != nil && !errors.Is(err, tt.daoErr) {
+				t.Errorf("GetPurchasedItems() error = %v, want %v", err, tt.daoErr)
+			}
+			if tt.userID == "" && called {
+				t.Errorf("GetPurchasedItems() should not call DAO without user ID")
+			}
+			if len(items) != tt.wantCount {
+				t.Errorf("GetPurchasedItems() got length = %v, want %v", len(items), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestPurchaseHistory_GetSoldItems(t *testing.T) {
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		userID    string
+		daoErr    error
+		wantErr   bool
+		wantCount int
+	}{
+		{name: "成功: 売れた商品を取得", userID: "seller1", wantCount: 1},
+		{name: "失敗: ユーザーIDが空", userID: "", wantErr: true},
+		{name: "失敗: DBエラー", userID: "seller1", daoErr: dbErr, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			mockItemDAO := &MockItemDAO{
+				GetSoldItemsFunc: func(ctx context.Context, sellerID string, limit int, offset int) ([]model.SoldItem, error) {
+					called = true
+					if sellerID != tt.userID || limit != 20 || offset != 40 {
+						t.Errorf("GetSoldItems() called with (%q, %d, %d), want (%q, 20, 40)", sellerID, limit, offset, tt.userID)
+					}
+					if tt.daoErr != nil {
+						return nil, tt.daoErr
+					}
+					return []model.SoldItem{{ItemId: "item1", BuyerId: "buyer1"}}, nil
+				},
+			}
+
+			u := NewPurchaseHistory(mockItemDAO)
+			items, err := u.GetSoldItems(context.Background(), tt.userID, 20, 40)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetSoldItems() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.daoErr != nil && !errors.Is(err, tt.daoErr) {
+				t.Errorf("GetSoldItems() error = %v, want %v", err, tt.daoErr)
+			}
+			if tt.userID == "" && called {
+				t.Errorf("GetSoldItems() should not call DAO without user ID")
+			}
+			if len(items) != tt.wantCount {
+				t.Errorf("GetSoldItems() got length = %v, want %v", len(items), tt.wantCount)
+			}
+		})
+	}
+}
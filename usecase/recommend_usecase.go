@@ -10,27 +10,33 @@ import (
 	"sort"
 )
 
+// recommendCandidateLimit : ブロック関係の出品者を除外する場合に、スコア上位何件から選ぶか
+const recommendCandidateLimit = 200
+
 type RecommendUsecase interface {
-	GetSimilarItems(ctx context.Context, targetItemID string, limit int) ([]model.ItemSimple, error)
+	GetSimilarItems(ctx context.Context, targetItemID string, viewerID string, limit int) ([]model.ItemSimple, error)
 	GetPersonalizedRecommendations(ctx context.Context, userID string, limit int) ([]model.ItemSimple, error)
 }
 
 type recommendUsecase struct {
 	itemDAO        dao.ItemDAO
 	likeDAO        dao.LikeDAO
+	blockDAO       dao.BlockDAO
 	embeddingCache *cache.EmbeddingCache
 }
 
-func NewRecommendUsecase(itemDAO dao.ItemDAO, likeDAO dao.LikeDAO, embeddingCache *cache.EmbeddingCache) RecommendUsecase {
+func NewRecommendUsecase(itemDAO dao.ItemDAO, likeDAO dao.LikeDAO, blockDAO dao.BlockDAO, embeddingCache *cache.EmbeddingCache) RecommendUsecase {
 	return &recommendUsecase{
 		itemDAO:        itemDAO,
 		likeDAO:        likeDAO,
+		blockDAO:       blockDAO,
 		embeddingCache: embeddingCache,
 	}
 }
 
 // GetSimilarItems : 指定した商品に似ている商品を返す (Item-to-Item)
-func (us *recommendUsecase) GetSimilarItems(ctx context.Context, targetItemID string, limit int) ([]model.ItemSimple, error) {
+// viewerIDが空でなければ、そのユーザーとブロック関係にある出品者の商品を除外する
+func (us *recommendUsecase) GetSimilarItems(ctx context.Context, targetItemID string, viewerID string, limit int) ([]model.ItemSimple, error) {
 	// キャッシュから取得（超高速）
 	allEmbeddings := us.embeddingCache.Get()
 
@@ -48,8 +54,13 @@ func (us *recommendUsecase) GetSimilarItems(ctx context.Context, targetItemID st
 		targetVector = vec
 	}
 
+	blockedIDs, err := us.blockedUserIDs(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
 	// 類似度計算
-	recommendations, err := us.calculateRanking(ctx, targetVector, allEmbeddings, limit, []string{targetItemID})
+	recommendations, err := us.calculateRanking(ctx, targetVector, allEmbeddings, limit, []string{targetItemID}, blockedIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -96,8 +107,13 @@ func (us *recommendUsecase) GetPersonalizedRecommendations(ctx context.Context,
 		userVector[i] /= float32(count)
 	}
 
-	// 4. ランキング計算 (いいね済みの商品は除外)
-	recommendations, err := us.calculateRanking(ctx, userVector, allEmbeddings, limit, likedItemIDs)
+	blockedIDs, err := us.blockedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. ランキング計算 (いいね済みの商品とブロック関係の出品者の商品は除外)
+	recommendations, err := us.calculateRanking(ctx, userVector, allEmbeddings, limit, likedItemIDs, blockedIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -105,8 +121,20 @@ func (us *recommendUsecase) GetPersonalizedRecommendations(ctx context.Context,
 	return recommendations, nil
 }
 
+// blockedUserIDs : ユーザーとブロック関係にある相手のID一覧 (ゲストなら空)
+func (us *recommendUsecase) blockedUserIDs(ctx context.Context, userID string) ([]string, error) {
+	if userID == "" {
+		return nil, nil
+	}
+	ids, err := us.blockDAO.GetBlockedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+	return ids, nil
+}
+
 // 共通ロジック: ランキング計算と商品情報取得
-func (us *recommendUsecase) calculateRanking(ctx context.Context, targetVec []float32, allEmbeddings map[string][]float32, limit int, excludeIDs []string) ([]model.ItemSimple, error) {
+func (us *recommendUsecase) calculateRanking(ctx context.Context, targetVec []float32, allEmbeddings map[string][]float32, limit int, excludeIDs []string, excludeSellerIDs []string) ([]model.ItemSimple, error) {
 	type itemScore struct {
 		ID    string
 		Score float64
@@ -131,8 +159,11 @@ func (us *recommendUsecase) calculateRanking(ctx context.Context, targetVec []fl
 		return scores[i].Score > scores[j].Score
 	})
 
-	// Top N 抽出
+	// Top N 抽出 (出品者を除外する場合は多めに取ってから絞り込む)
 	topN := limit
+	if len(excludeSellerIDs) > 0 && topN < recommendCandidateLimit {
+		topN = recommendCandidateLimit
+	}
 	if len(scores) < topN {
 		topN = len(scores)
 	}
@@ -143,6 +174,14 @@ func (us *recommendUsecase) calculateRanking(ctx context.Context, targetVec []fl
 		topIDs[i] = scores[i].ID
 	}
 
+	if len(excludeSellerIDs) > 0 {
+		var err error
+		topIDs, err = us.excludeSellers(ctx, topIDs, excludeSellerIDs, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// バルク取得（1回のクエリで全取得）
 	results, err := us.itemDAO.GetItemsByIDs(ctx, topIDs)
 	if err != nil {
@@ -152,6 +191,29 @@ func (us *recommendUsecase) calculateRanking(ctx context.Context, targetVec []fl
 	return results, nil
 }
 
+// excludeSellers : 順位を保ったまま指定した出品者の商品を除き、上位limit件を返す
+func (us *recommendUsecase) excludeSellers(ctx context.Context, rankedIDs []string, sellerIDs []string, limit int) ([]string, error) {
+	matchedIDs, err := us.itemDAO.FilterItemIDs(ctx, rankedIDs, model.ItemFilter{ExcludeSellerIds: sellerIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter items: %w", err)
+	}
+	matched := make(map[string]bool, len(matchedIDs))
+	for _, id := range matchedIDs {
+		matched[id] = true
+	}
+
+	ids := make([]string, 0, limit)
+	for _, id := range rankedIDs {
+		if len(ids) == limit {
+			break
+		}
+		if matched[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
 func cosineSimilarity(a, b []float32) float64 {
 	if len(a) != len(b) || len(a) == 0 {
 		return 0.0
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/model"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestRecommendUsecase_ExcludesBlockedSellers(t *testing.T) {
+	// item2はブロック関係にあるseller1の出品
+	sellers := map[string]string{"item2": "seller1", "item3": "seller2", "item4": "seller3"}
+	mockItemDAO := &MockItemDAO{
+		GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+			return map[string][]float32{
+				"item1": {1, 0},
+				"item2": {1, 0.1},
+				"item3": {1, 0.2},
+				"item4": {0, 1},
+			}, nil
+		},
+		FilterItemIDsFunc: func(ctx context.Context, itemIDs []string, filter model.ItemFilter) ([]string, error) {
+			ids := make([]string, 0)
+			for _, id := range itemIDs {
+				if !slices.Contains(filter.ExcludeSellerIds, sellers[id]) {
+					ids = append(ids, id)
+				}
+			}
+			return ids, nil
+		},
+		GetItemsByIDsFunc: func(ctx context.Context, itemIDs []string) ([]model.ItemSimple, error) {
+			items := make([]model.ItemSimple, len(itemIDs))
+			for i, id := range itemIDs {
+				items[i] = model.ItemSimple{ItemId: id}
+			}
+			return items, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		viewerID string
+		wantIDs  []string
+	}{
+		{name: "成功: ブロック関係の出品者の商品を除外", viewerID: "user1", wantIDs: []string{"item3", "item4"}},
+		{name: "成功: ゲストは除外しない", viewerID: "", wantIDs: []string{"item2", "item3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewRecommendUsecase(mockItemDAO, &MockLikeDAO{}, newBlockingDAO(), cache.NewEmbeddingCache(mockItemDAO))
+			items, err := u.GetSimilarItems(context.Background(), "item1", tt.viewerID, 2)
+			if err != nil {
+				t.Fatalf("GetSimilarItems() error = %v", err)
+			}
+			gotIDs := make([]string, len(items))
+			for i, item := range items {
+				gotIDs[i] = item.ItemId
+			}
+			if !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("GetSimilarItems() = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"db/dao"
+	"db/model"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+type ReviewUsecase interface {
+	CreateReview(ctx context.Context, itemID, reviewerID string, req *model.ReviewCreateRequest) (*model.Review, error)
+	GetUserReviews(ctx context.Context, userID string, limit int, offset int) ([]model.Review, error)
+}
+
+type reviewUsecase struct {
+	reviewDAO       dao.ReviewDAO
+	orderDAO        dao.OrderDAO
+	notificationDAO dao.NotificationDAO
+}
+
+func NewReviewUsecase(reviewDAO dao.ReviewDAO, orderDAO dao.OrderDAO, notificationDAO dao.NotificationDAO) ReviewUsecase {
+	return &reviewUsecase{
+		reviewDAO:       reviewDAO,
+		orderDAO:        orderDAO,
+		notificationDAO: notificationDAO,
+	}
+}
+
+// CreateReview : 商品の取引が完了した後、購入者・出品者が相手を評価する (取引ごとに1人1回まで)
+func (u *reviewUsecase) CreateReview(ctx context.Context, itemID, reviewerID string, req *model.ReviewCreateRequest) (*model.Review, error) {
+	if !req.IsValid() {
+		return nil, model.ErrInvalidRequest
+	}
+
+	// 完了した取引の当事者のみ評価できる (支払い待ち・キャンセルされた取引は対象外)
+	order, err := u.orderDAO.GetCompletedOrderByItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, model.ErrOrderNotFound) {
+			return nil, model.ErrNotEligibleForReview
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	var role, revieweeID string
+	switch reviewerID {
+	case order.BuyerId:
+		role, revieweeID = model.ReviewerRoleBuyer, order.SellerId
+	case order.SellerId:
+		role, revieweeID = model.ReviewerRoleSeller, order.BuyerId
+	default:
+		return nil, model.ErrNotEligibleForReview
+	}
+
+	t := time.Now()
+	entropy := ulid.Monotonic(rand.Reader, 0)
+	review := &model.Review{
+		Id:           ulid.MustNew(ulid.Timestamp(t), entropy).String(),
+		OrderId:      order.Id,
+		ItemId:       itemID,
+		ReviewerId:   reviewerID,
+		RevieweeId:   revieweeID,
+		ReviewerRole: role,
+		Rating:       req.Rating,
+		Comment:      req.Comment,
+		CreatedAt:    t,
+	}
+	if err := u.reviewDAO.CreateReview(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to create review: %w", err)
+	}
+
+	// 評価された相手に通知 (失敗しても評価は成功とする)
+	notification := &model.Notification{
+		UserId:   revieweeID,
+		Type:     model.NotificationTypeReview,
+		ItemId:   itemID,
+		ItemName: order.ItemName,
+		Message:  fmt.Sprintf("%sの取引相手から評価が届きました", order.ItemName),
+	}
+	if err := u.notificationDAO.CreateNotification(ctx, notification); err != nil {
+		log.Printf("Warning: failed to create notification: %v\n", err)
+	}
+
+	return review, nil
+}
+
+// GetUserReviews : 指定ユーザーが受けた評価一覧を取得
+func (u *reviewUsecase) GetUserReviews(ctx context.Context, userID string, limit int, offset int) ([]model.Review, error) {
+	reviews, err := u.reviewDAO.GetUserReviews(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+	return reviews, nil
+}
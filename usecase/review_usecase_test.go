@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// MockReviewDAO : dao.ReviewDAO のモック
+type MockReviewDAO struct {
+	CreateReviewFunc     func(ctx context.Context, review *model.Review) error
+	GetUserReviewsFunc   func(ctx context.Context, userID string, limit int, offset int) ([]model.Review, error)
+	GetRatingSummaryFunc func(ctx context.Context, userID string) (*model.RatingSummary, error)
+}
+
+func (m *MockReviewDAO) CreateReview(ctx context.Context, review *model.Review) error {
+	if m.CreateReviewFunc != nil {
+		return m.CreateReviewFunc(ctx, review)
+	}
+	return nil
+}
+
+func (m *MockReviewDAO) GetUserReviews(ctx context.Context, userID string, limit int, offset int) ([]model.Review, error) {
+	if m.GetUserReviewsFunc != nil {
+		return m.GetUserReviewsFunc(ctx, userID, limit, offset)
+	}
+	return []model.Review{}, nil
+}
+
+func (m *MockReviewDAO) GetRatingSummary(ctx context.Context, userID string) (*model.RatingSummary, error) {
+	if m.GetRatingSummaryFunc != nil {
+		return m.GetRatingSummaryFunc(ctx, userID)
+	}
+	return &model.RatingSummary{}, nil
+}
+
+func TestReviewUsecase_CreateReview(t *testing.T) {
+	completed := &model.Order{Id: "order2", ItemId: "item1", ItemName: "Test Item", BuyerId: "buyer1", SellerId: "seller1", Status: model.OrderStatusCompleted}
+
+	tests := []struct {
+		name         string
+		order        *model.Order
+		reviewerID   string
+		req          model.ReviewCreateRequest
+		createErr    error
+		wantErr      error
+		wantReviewee string
+		wantRole     string
+	}{
+		{name: "成功: 購入者が出品者を評価", order: completed, reviewerID: "buyer1", req: model.ReviewCreateRequest{Rating: 5, Comment: "迅速な発送でした"}, wantReviewee: "seller1", wantRole: model.ReviewerRoleBuyer},
+		{name: "成功: 出品者が購入者を評価", order: completed, reviewerID: "seller1", req: model.ReviewCreateRequest{Rating: 4}, wantReviewee: "buyer1", wantRole: model.ReviewerRoleSeller},
+		{name: "失敗: 第三者は評価できない", order: completed, reviewerID: "other", req: model.ReviewCreateRequest{Rating: 3}, wantErr: model.ErrNotEligibleForReview},
+		{name: "失敗: 取引が完了していない", order: nil, reviewerID: "buyer1", req: model.ReviewCreateRequest{Rating: 3}, wantErr: model.ErrNotEligibleForReview},
+		{name: "失敗: 評価済み", order: completed, reviewerID: "buyer1", req: model.ReviewCreateRequest{Rating: 3}, createErr: model.ErrAlreadyReviewed, wantErr: model.ErrAlreadyReviewed},
+		{name: "失敗: 評価が0", order: completed, reviewerID: "buyer1", req: model.ReviewCreateRequest{Rating: 0}, wantErr: model.ErrInvalidRequest},
+		{name: "失敗: 評価が6", order: completed, reviewerID: "buyer1", req: model.ReviewCreateRequest{Rating: 6}, wantErr: model.ErrInvalidRequest},
+		{name: "失敗: コメントが長すぎる", order: completed, reviewerID: "buyer1", req: model.ReviewCreateRequest{Rating: 5, Comment: strings.Repeat("あ", model.MaxReviewCommentLen+1)}, wantErr: model.ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved *model.Review
+			mockReviewDAO := &MockReviewDAO{
+				CreateReviewFunc: func(ctx context.Context, review *model.Review) error {
+					if tt.createErr != nil {
+						return tt.createErr
+					}
+					saved = review
+					return nil
+				},
+			}
+			mockOrderDAO := &MockOrderDAO{
+				GetCompletedOrderByItemFunc: func(ctx context.Context, itemID string) (*model.Order, error) {
+					if tt.order == nil {
+						return nil, model.ErrOrderNotFound
+					}
+					return tt.order, nil
+				},
+			}
+			var recipient string
+			mockNotificationDAO := &MockNotificationDAO{
+				CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+					recipient = notification.UserId
+					return nil
+				},
+			}
+
+			u := NewReviewUsecase(mockReviewDAO, mockOrderDAO, mockNotificationDAO)
+			review, err := u.CreateReview(context.Background(), "item1", tt.reviewerID, &tt.req)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateReview() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if recipient != "" {
+					t.Errorf("notification should not be sent on error")
+				}
+				return
+			}
+			if saved == nil || saved.Id == "" {
+				t.Fatalf("CreateReview() should save review with generated id")
+			}
+			if review.OrderId != tt.order.Id {
+				t.Errorf("CreateReview() order_id = %v, want %v", review.OrderId, tt.order.Id)
+			}
+			if review.RevieweeId != tt.wantReviewee || review.ReviewerRole != tt.wantRole {
+				t.Errorf("CreateReview() reviewee = %v role = %v, want %v %v", review.RevieweeId, review.ReviewerRole, tt.wantReviewee, tt.wantRole)
+			}
+			if recipient != tt.wantReviewee {
+				t.Errorf("notification recipient = %v, want %v", recipient, tt.wantReviewee)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"db/dao"
+	"db/model"
+	"db/search"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid"
+)
+
+type SavedSearchUsecase interface {
+	CreateSavedSearch(ctx context.Context, userID string, req *model.SavedSearchRequest) (*model.SavedSearch, error)
+	GetSavedSearches(ctx context.Context, userID string) ([]model.SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, savedSearchID, userID string, req *model.SavedSearchRequest) (*model.SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, savedSearchID, userID string) error
+}
+
+type savedSearchUsecase struct {
+	savedSearchDAO dao.SavedSearchDAO
+}
+
+func NewSavedSearchUsecase(savedSearchDAO dao.SavedSearchDAO) SavedSearchUsecase {
+	return &savedSearchUsecase{savedSearchDAO: savedSearchDAO}
+}
+
+// CreateSavedSearch : 検索条件を保存する (1ユーザーあたりMaxSavedSearchesPerUser件まで)
+func (u *savedSearchUsecase) CreateSavedSearch(ctx context.Context, userID string, req *model.SavedSearchRequest) (*model.SavedSearch, error) {
+	if !req.IsValid() {
+		return nil, model.ErrInvalidRequest
+	}
+
+	t := time.Now()
+	savedSearch := &model.SavedSearch{
+		Id:        ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)).String(),
+		UserId:    userID,
+		Keyword:   strings.TrimSpace(req.Keyword),
+		MinPrice:  req.MinPrice,
+		MaxPrice:  req.MaxPrice,
+		CreatedAt: t,
+		UpdatedAt: t,
+	}
+	// 上限の確認は同時に保存されても超えないようDAOのトランザクション内で行う
+	if err := u.savedSearchDAO.CreateSavedSearch(ctx, savedSearch, model.MaxSavedSearchesPerUser); err != nil {
+		return nil, fmt.Errorf("failed to create saved search: %w", err)
+	}
+
+	return savedSearch, nil
+}
+
+// GetSavedSearches : ユーザーの保存した検索条件の一覧
+func (u *savedSearchUsecase) GetSavedSearches(ctx context.Context, userID string) ([]model.SavedSearch, error) {
+	savedSearches, err := u.savedSearchDAO.GetUserSavedSearches(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved searches: %w", err)
+	}
+	return savedSearches, nil
+}
+
+// UpdateSavedSearch : 自分の保存した検索条件を更新する
+func (u *savedSearchUsecase) UpdateSavedSearch(ctx context.Context, savedSearchID, userID string, req *model.SavedSearchRequest) (*model.SavedSearch, error) {
+	if !req.IsValid() {
+		return nil, model.ErrInvalidRequest
+	}
+
+	savedSearch, err := u.getOwnSavedSearch(ctx, savedSearchID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	savedSearch.Keyword = strings.TrimSpace(req.Keyword)
+	savedSearch.MinPrice = req.MinPrice
+	savedSearch.MaxPrice = req.MaxPrice
+	savedSearch.UpdatedAt = time.Now()
+	if err := u.savedSearchDAO.UpdateSavedSearch(ctx, savedSearch); err != nil {
+		return nil, fmt.Errorf("failed to update saved search: %w", err)
+	}
+
+	return savedSearch, nil
+}
+
+// DeleteSavedSearch : 自分の保存した検索条件を削除する
+func (u *savedSearchUsecase) DeleteSavedSearch(ctx context.Context, savedSearchID, userID string) error {
+	if _, err := u.getOwnSavedSearch(ctx, savedSearchID, userID); err != nil {
+		return err
+	}
+	if err := u.savedSearchDAO.DeleteSavedSearch(ctx, savedSearchID); err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	return nil
+}
+
+// getOwnSavedSearch : 検索条件を取得し、本人のものか確認する (他人の検索条件は存在しない扱い)
+func (u *savedSearchUsecase) getOwnSavedSearch(ctx context.Context, savedSearchID, userID string) (*model.SavedSearch, error) {
+	savedSearch, err := u.savedSearchDAO.GetSavedSearch(ctx, savedSearchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+	if savedSearch.UserId != userID {
+		return nil, model.ErrSavedSearchNotFound
+	}
+	return savedSearch, nil
+}
+
+// notifySavedSearchMatches : 出品された商品に一致する検索条件を保存しているユーザーに通知する
+// 出品処理とは非同期に呼ばれるため、失敗はログに残すだけにする
+func notifySavedSearchMatches(ctx context.Context, savedSearchDAO dao.SavedSearchDAO, notificationDAO dao.NotificationDAO, item *model.Item) {
+	savedSearches, err := savedSearchDAO.GetSavedSearchesForPrice(ctx, item.Price, item.UserId)
+	if err != nil {
+		log.Printf("Warning: failed to get saved searches: %v", err)
+		return
+	}
+
+	text := search.Normalize(item.Name + "\n" + item.Description)
+	notified := make(map[string]bool)
+	for _, s := range savedSearches {
+		// 同じユーザーが複数の条件に一致しても通知は1件にする
+		if notified[s.UserId] || !matchesKeyword(text, s.Keyword) {
+			continue
+		}
+		notified[s.UserId] = true
+
+		notification := &model.Notification{
+			UserId:   s.UserId,
+			Type:     model.NotificationTypeSavedSearch,
+			ItemId:   item.ItemId,
+			ItemName: item.Name,
+			Message:  fmt.Sprintf("保存した検索条件「%s」に一致する商品が出品されました", s.Keyword),
+		}
+		if err := notificationDAO.CreateNotification(ctx, notification); err != nil {
+			log.Printf("Warning: failed to create notification: %v", err)
+		}
+	}
+}
+
+// matchesKeyword : 空白で区切った検索語がすべて正規化済みのテキストに含まれるか
+func matchesKeyword(normalizedText, keyword string) bool {
+	terms := strings.Fields(search.Normalize(keyword))
+	if len(terms) == 0 {
+		return false
+	}
+	for _, term := range terms {
+		if !strings.Contains(normalizedText, term) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// MockSavedSearchDAO : dao.SavedSearchDAO のモック
+type MockSavedSearchDAO struct {
+	CreateSavedSearchFunc        func(ctx context.Context, savedSearch *model.SavedSearch, limit int) error
+	GetSavedSearchFunc           func(ctx context.Context, savedSearchID string) (*model.SavedSearch, error)
+	GetUserSavedSearchesFunc     func(ctx context.Context, userID string) ([]model.SavedSearch, error)
+	UpdateSavedSearchFunc        func(ctx context.Context, savedSearch *model.SavedSearch) error
+	DeleteSavedSearchFunc        func(ctx context.Context, savedSearchID string) error
+	GetSavedSearchesForPriceFunc func(ctx context.Context, price int, excludeUserID string) ([]model.SavedSearch, error)
+}
+
+func (m *MockSavedSearchDAO) CreateSavedSearch(ctx context.Context, savedSearch *model.SavedSearch, limit int) error {
+	if m.CreateSavedSearchFunc != nil {
+		return m.CreateSavedSearchFunc(ctx, savedSearch, limit)
+	}
+	return nil
+}
+
+func (m *MockSavedSearchDAO) GetSavedSearch(ctx context.Context, savedSearchID string) (*model.SavedSearch, error) {
+	if m.GetSavedSearchFunc != nil {
+		return m.GetSavedSearchFunc(ctx, savedSearchID)
+	}
+	return nil, model.ErrSavedSearchNotFound
+}
+
+func (m *MockSavedSearchDAO) GetUserSavedSearches(ctx context.Context, userID string) ([]model.SavedSearch, error) {
+	if m.GetUserSavedSearchesFunc != nil {
+		return m.GetUserSavedSearchesFunc(ctx, userID)
+	}
+	return []model.SavedSearch{}, nil
+}
+
+func (m *MockSavedSearchDAO) UpdateSavedSearch(ctx context.Context, savedSearch *model.SavedSearch) error {
+	if m.UpdateSavedSearchFunc != nil {
+		return m.UpdateSavedSearchFunc(ctx, savedSearch)
+	}
+	return nil
+}
+
+func (m *MockSavedSearchDAO) DeleteSavedSearch(ctx context.Context, savedSearchID string) error {
+	if m.DeleteSavedSearchFunc != nil {
+		return m.DeleteSavedSearchFunc(ctx, savedSearchID)
+	}
+	return nil
+}
+
+func (m *MockSavedSearchDAO) GetSavedSearchesForPrice(ctx context.Context, price int, excludeUserID string) ([]model.SavedSearch, error) {
+	if m.GetSavedSearchesForPriceFunc != nil {
+		return m.GetSavedSearchesForPriceFunc(ctx, price, excludeUserID)
+	}
+	return []model.SavedSearch{}, nil
+}
+
+func TestSavedSearchUsecase_CreateSavedSearch(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     model.SavedSearchRequest
+		count   int
+		wantErr error
+	}{
+		{name: "成功: 保存", req: model.SavedSearchRequest{Keyword: " ジャケット ", MaxPrice: 5000}, count: 0},
+		{name: "失敗: キーワードなし", req: model.SavedSearchRequest{Keyword: "  "}, wantErr: model.ErrInvalidRequest},
+		{name: "失敗: 価格の下限が上限より大きい", req: model.SavedSearchRequest{Keyword: "ジャケット", MinPrice: 5000, MaxPrice: 1000}, wantErr: model.ErrInvalidRequest},
+		{name: "失敗: 保存件数の上限", req: model.SavedSearchRequest{Keyword: "ジャケット"}, count: model.MaxSavedSearchesPerUser, wantErr: model.ErrSavedSearchLimitExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *model.SavedSearch
+			mockDAO := &MockSavedSearchDAO{
+				CreateSavedSearchFunc: func(ctx context.Context, savedSearch *model.SavedSearch, limit int) error {
+					// DAOはトランザクション内で数えた件数を上限と比べる
+					if tt.count >= limit {
+						return model.ErrSavedSearchLimitExceeded
+					}
+					created = savedSearch
+					return nil
+				},
+			}
+
+			u := NewSavedSearchUsecase(mockDAO)
+			got, err := u.CreateSavedSearch(context.Background(), "user1", &tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("CreateSavedSearch() error = %v, want %v", err, tt.wantErr)
+				}
+				if created != nil {
+					t.Errorf("CreateSavedSearch() saved despite error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateSavedSearch() unexpected error = %v", err)
+			}
+			if got.Id == "" || got.UserId != "user1" || got.Keyword != "ジャケット" {
+				t.Errorf("CreateSavedSearch() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestSavedSearchUsecase_DeleteSavedSearch(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		wantErr    error
+		wantDelete bool
+	}{
+		{name: "成功: 本人の検索条件を削除", userID: "user1", wantDelete: true},
+		{name: "失敗: 他人の検索条件", userID: "user2", wantErr: model.ErrSavedSearchNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			mockDAO := &MockSavedSearchDAO{
+				GetSavedSearchFunc: func(ctx context.Context, savedSearchID string) (*model.SavedSearch, error) {
+					return &model.SavedSearch{Id: savedSearchID, UserId: "user1", Keyword: "ジャケット"}, nil
+				},
+				DeleteSavedSearchFunc: func(ctx context.Context, savedSearchID string) error {
+					deleted = true
+					return nil
+				},
+			}
+
+			u := NewSavedSearchUsecase(mockDAO)
+			err := u.DeleteSavedSearch(context.Background(), "ss1", tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeleteSavedSearch() error = %v, want %v", err, tt.wantErr)
+			}
+			if deleted != tt.wantDelete {
+				t.Errorf("DeleteSavedSearch() deleted = %v, want %v", deleted, tt.wantDelete)
+			}
+		})
+	}
+}
+
+func TestNotifySavedSearchMatches(t *testing.T) {
+	item := &model.Item{
+		ItemId:      "item1",
+		UserId:      "seller1",
+		Name:        "ノースフェイス ダウンジャケット",
+		Description: "サイズM 冬用",
+		Price:       8000,
+	}
+	mockSavedSearchDAO := &MockSavedSearchDAO{
+		// 価格帯での絞り込みはDAOのクエリで行う
+		GetSavedSearchesForPriceFunc: func(ctx context.Context, price int, excludeUserID string) ([]model.SavedSearch, error) {
+			if price != item.Price || excludeUserID != item.UserId {
+				t.Errorf("GetSavedSearchesForPrice(%d, %q)", price, excludeUserID)
+			}
+			return []model.SavedSearch{
+				{UserId: "buyer1", Keyword: "ダウンジャケット"},
+				{UserId: "buyer1", Keyword: "ノースフェイス"}, // 同じユーザーには1件だけ
+				{UserId: "buyer2", Keyword: "だうん　ｍ"},   // 表記ゆれと全角空白
+				{UserId: "buyer3", Keyword: "ダウン L"},   // 一部の語が一致しない
+				{UserId: "buyer4", Keyword: "スニーカー"},
+			}, nil
+		},
+	}
+	notified := make([]string, 0)
+	mockNotificationDAO := &MockNotificationDAO{
+		CreateNotificationFunc: func(ctx context.Context, notification *model.Notification) error {
+			if notification.Type != model.NotificationTypeSavedSearch || notification.ItemId != item.ItemId {
+				t.Errorf("unexpected notification %+v", notification)
+			}
+			notified = append(notified, notification.UserId)
+			return nil
+		},
+	}
+
+	notifySavedSearchMatches(context.Background(), mockSavedSearchDAO, mockNotificationDAO, item)
+
+	if want := []string{"buyer1", "buyer2"}; !slices.Equal(notified, want) {
+		t.Errorf("notified = %v, want %v", notified, want)
+	}
+}
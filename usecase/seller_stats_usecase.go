@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/dao"
+	"db/model"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+type SellerStatsUsecase interface {
+	GetSellerStats(ctx context.Context, sellerID string) (*model.SellerStats, error)
+}
+
+type sellerStatsUsecase struct {
+	userDAO    dao.UserDAO
+	itemDAO    dao.ItemDAO
+	chatDAO    dao.ChatDAO
+	statsCache *cache.SellerStatsCache
+}
+
+func NewSellerStatsUsecase(userDAO dao.UserDAO, itemDAO dao.ItemDAO, chatDAO dao.ChatDAO, statsCache *cache.SellerStatsCache) SellerStatsUsecase {
+	return &sellerStatsUsecase{
+		userDAO:    userDAO,
+		itemDAO:    itemDAO,
+		chatDAO:    chatDAO,
+		statsCache: statsCache,
+	}
+}
+
+// GetSellerStats : 出品者の実績 (出品数・売れた数・売れるまでの平均時間・返信率・返信時間の中央値・登録日)
+// キャッシュがあればそれを返す
+func (u *sellerStatsUsecase) GetSellerStats(ctx context.Context, sellerID string) (*model.SellerStats, error) {
+	if stats, ok := u.statsCache.Get(sellerID); ok {
+		return stats, nil
+	}
+	// 計算中に購入・メッセージ送信で無効化されたら、古い実績をキャッシュに残さない
+	generation := u.statsCache.Generation(sellerID)
+
+	user, err := u.userDAO.GetUser(ctx, sellerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	itemStats, err := u.itemDAO.GetSellerItemStats(ctx, sellerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item stats: %w", err)
+	}
+
+	inquiries, err := u.chatDAO.GetSellerInquiries(ctx, sellerID, model.SellerStatsInquiryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inquiries: %w", err)
+	}
+
+	stats := &model.SellerStats{
+		ListingCount: itemStats.ListingCount,
+		SoldCount:    itemStats.SoldCount,
+		MemberSince:  user.CreatedAt,
+	}
+	if itemStats.AvgTimeToSellSeconds != nil {
+		avg := int64(math.Round(*itemStats.AvgTimeToSellSeconds))
+		stats.AvgTimeToSellSeconds = &avg
+	}
+	stats.ResponseRate, stats.MedianResponseSeconds = summarizeInquiries(inquiries)
+
+	u.statsCache.Set(sellerID, generation, stats)
+	return stats, nil
+}
+
+// summarizeInquiries : 問い合わせのうち返信した割合と、返信までの時間の中央値(秒)
+// 問い合わせがなければ両方nil、返信が1件もなければ中央値はnil
+func summarizeInquiries(inquiries []model.SellerInquiry) (*float64, *int64) {
+	if len(inquiries) == 0 {
+		return nil, nil
+	}
+
+	responseTimes := make([]time.Duration, 0, len(inquiries))
+	for _, in := range inquiries {
+		if in.FirstReplyAt != nil {
+			responseTimes = append(responseTimes, in.FirstReplyAt.Sub(in.InquiredAt))
+		}
+	}
+
+	rate := math.Round(float64(len(responseTimes))/float64(len(inquiries))*100) / 100
+	if len(responseTimes) == 0 {
+		return &rate, nil
+	}
+
+	sort.Slice(responseTimes, func(i, j int) bool { return responseTimes[i] < responseTimes[j] })
+	mid := len(responseTimes) / 2
+	median := responseTimes[mid]
+	if len(responseTimes)%2 == 0 {
+		median = (responseTimes[mid-1] + responseTimes[mid]) / 2
+	}
+	seconds := int64(median.Seconds())
+	return &rate, &seconds
+}
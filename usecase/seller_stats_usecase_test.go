@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/hub"
+	"db/model"
+	"testing"
+	"time"
+)
+
+func ptrInt64(v int64) *int64 { return &v }
+
+func ptrFloat64(v float64) *float64 { return &v }
+
+func TestSummarizeInquiries(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	reply := func(d time.Duration) *time.Time {
+		r := base.Add(d)
+		return &r
+	}
+
+	tests := []struct {
+		name       string
+		inquiries  []model.SellerInquiry
+		wantRate   *float64
+		wantMedian *int64
+	}{
+		{name: "成功: 問い合わせなし", inquiries: nil},
+		{
+			name:      "成功: 返信なし",
+			inquiries: []model.SellerInquiry{{InquiredAt: base}},
+			wantRate:  ptrFloat64(0),
+		},
+		{
+			name: "成功: 返信した問い合わせだけで中央値を取る",
+			inquiries: []model.SellerInquiry{
+				{InquiredAt: base, FirstReplyAt: reply(10 * time.Minute)},
+				{InquiredAt: base, FirstReplyAt: reply(time.Minute)},
+				{InquiredAt: base, FirstReplyAt: reply(time.Hour)},
+				{InquiredAt: base},
+			},
+			wantRate:   ptrFloat64(0.75),
+			wantMedian: ptrInt64(600),
+		},
+		{
+			name: "成功: 偶数件は中央の2件の平均",
+			inquiries: []model.SellerInquiry{
+				{InquiredAt: base, FirstReplyAt: reply(time.Minute)},
+				{InquiredAt: base, FirstReplyAt: reply(3 * time.Minute)},
+			},
+			wantRate:   ptrFloat64(1),
+			wantMedian: ptrInt64(120),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, median := summarizeInquiries(tt.inquiries)
+			if (rate == nil) != (tt.wantRate == nil) || (rate != nil && *rate != *tt.wantRate) {
+				t.Errorf("rate = %v, want %v", rate, tt.wantRate)
+			}
+			if (median == nil) != (tt.wantMedian == nil) || (median != nil && *median != *tt.wantMedian) {
+				t.Errorf("median = %v, want %v", median, tt.wantMedian)
+			}
+		})
+	}
+}
+
+func TestSellerStatsUsecase_GetSellerStats(t *testing.T) {
+	memberSince := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	calls := 0
+	mockUserDAO := &MockUserDAO{
+		GetUserFunc: func(ctx context.Context, id string) (*model.User, error) {
+			return &model.User{Id: id, CreatedAt: memberSince}, nil
+		},
+	}
+	mockItemDAO := &MockItemDAO{
+		GetSellerItemStatsFunc: func(ctx context.Context, sellerID string) (*model.SellerItemStats, error) {
+			calls++
+			return &model.SellerItemStats{ListingCount: 5, SoldCount: 2, AvgTimeToSellSeconds: ptrFloat64(3600.4)}, nil
+		},
+	}
+	statsCache := cache.NewSellerStatsCache(time.Minute)
+
+	u := NewSellerStatsUsecase(mockUserDAO, mockItemDAO, &MockChatDAO{}, statsCache)
+	stats, err := u.GetSellerStats(context.Background(), "seller1")
+	if err != nil {
+		t.Fatalf("GetSellerStats() error = %v", err)
+	}
+	if stats.ListingCount != 5 || stats.SoldCount != 2 || *stats.AvgTimeToSellSeconds != 3600 || !stats.MemberSince.Equal(memberSince) {
+		t.Errorf("GetSellerStats() = %+v", stats)
+	}
+	if stats.ResponseRate != nil || stats.MedianResponseSeconds != nil {
+		t.Errorf("response stats should be nil without inquiries: %+v", stats)
+	}
+
+	// 2回目はキャッシュから返す
+	if _, err := u.GetSellerStats(context.Background(), "seller1"); err != nil {
+		t.Fatalf("GetSellerStats() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("item stats fetched %d times, want 1", calls)
+	}
+
+	// 無効化されたら計算し直す
+	statsCache.Invalidate("seller1")
+	if _, err := u.GetSellerStats(context.Background(), "seller1"); err != nil {
+		t.Fatalf("GetSellerStats() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("item stats fetched %d times, want 2", calls)
+	}
+}
+
+func TestSellerStatsUsecase_GetSellerStats_InvalidatedWhileComputing(t *testing.T) {
+	statsCache := cache.NewSellerStatsCache(time.Minute)
+	calls := 0
+	mockItemDAO := &MockItemDAO{
+		GetSellerItemStatsFunc: func(ctx context.Context, sellerID string) (*model.SellerItemStats, error) {
+			calls++
+			if calls == 1 {
+				// 集計を読んだ直後に購入があった
+				statsCache.Invalidate(sellerID)
+				return &model.SellerItemStats{ListingCount: 5, SoldCount: 1}, nil
+			}
+			return &model.SellerItemStats{ListingCount: 5, SoldCount: 2}, nil
+		},
+	}
+
+	u := NewSellerStatsUsecase(&MockUserDAO{}, mockItemDAO, &MockChatDAO{}, statsCache)
+	if _, err := u.GetSellerStats(context.Background(), "seller1"); err != nil {
+		t.Fatalf("GetSellerStats() error = %v", err)
+	}
+	if _, ok := statsCache.Get("seller1"); ok {
+		t.Fatalf("stats computed before invalidation should not be cached")
+	}
+
+	stats, err := u.GetSellerStats(context.Background(), "seller1")
+	if err != nil {
+		t.Fatalf("GetSellerStats() error = %v", err)
+	}
+	if stats.SoldCount != 2 || calls != 2 {
+		t.Errorf("GetSellerStats() sold = %d after %d calls, want 2 after 2", stats.SoldCount, calls)
+	}
+	if cached, ok := statsCache.Get("seller1"); !ok || cached.SoldCount != 2 {
+		t.Errorf("fresh stats should be cached, got %+v", cached)
+	}
+}
+
+func TestSellerStatsCache_InvalidatedBySellerActivity(t *testing.T) {
+	item := &model.Item{ItemId: "item1", Name: "Test Item", UserId: "seller1", Status: model.StatusOnSale, Price: 1000}
+	room := &model.ChatRoom{Id: "room1", ItemId: "item1", BuyerId: "buyer1", SellerId: "seller1"}
+	mockItemDAO := &MockItemDAO{
+		GetItemFunc: func(ctx context.Context, itemID string) (*model.Item, error) {
+			return item, nil
+		},
+		GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+			return map[string][]float32{}, nil
+		},
+	}
+	mockChatDAO := &MockChatDAO{
+		GetChatRoomByIDFunc: func(ctx context.Context, roomID string) (*model.ChatRoom, error) {
+			return room, nil
+		},
+		GetChatRoomFunc: func(ctx context.Context, itemID, buyerID string) (*model.ChatRoom, error) {
+			return room, nil
+		},
+	}
+
+	tests := []struct {
+		name   string
+		action func(statsCache *cache.SellerStatsCache) error
+	}{
+		{
+			name: "成功: 購入で出品者の実績を破棄",
+			action: func(statsCache *cache.SellerStatsCache) error {
+				u := NewItemPurchase(mockItemDAO, &MockOfferDAO{}, &MockNotificationDAO{}, &MockBlockDAO{}, cache.NewEmbeddingCache(mockItemDAO), statsCache)
+				_, err := u.PurchaseItem(context.Background(), "item1", "buyer1")
+				return err
+			},
+		},
+		{
+			name: "成功: メッセージ送信で出品者の実績を破棄",
+			action: func(statsCache *cache.SellerStatsCache) error {
+				u := NewChatUsecase(mockChatDAO, mockItemDAO, &MockNotificationDAO{}, &MockBlockDAO{}, hub.NewLocalChatHub(), statsCache)
+				return u.SendMessage(context.Background(), "room1", "buyer1", "hello")
+			},
+		},
+		{
+			name: "成功: 価格交渉のメッセージで出品者の実績を破棄",
+			action: func(statsCache *cache.SellerStatsCache) error {
+				u := NewOfferUsecase(&MockOfferDAO{}, mockItemDAO, mockChatDAO, &MockNotificationDAO{}, &MockBlockDAO{}, hub.NewLocalChatHub(), statsCache)
+				_, err := u.CreateOffer(context.Background(), "item1", "buyer1", 800)
+				return err
+			},
+		},
+		{
+			name: "成功: 取引のキャンセルで出品者の実績を破棄",
+			action: func(statsCache *cache.SellerStatsCache) error {
+				mockOrderDAO := &MockOrderDAO{
+					GetOrderFunc: func(ctx context.Context, orderID string) (*model.Order, error) {
+						return &model.Order{Id: orderID, ItemId: "item1", BuyerId: "buyer1", SellerId: "seller1", Status: model.OrderStatusAwaitingPayment}, nil
+					},
+				}
+				u := NewOrderUsecase(mockOrderDAO, mockItemDAO, &MockNotificationDAO{}, cache.NewEmbeddingCache(mockItemDAO), statsCache)
+				_, err := u.TransitionOrder(context.Background(), "order1", "buyer1", model.OrderActionCancel)
+				return err
+			},
+		},
+		{
+			name: "成功: 取り下げで出品者の実績を破棄",
+			action: func(statsCache *cache.SellerStatsCache) error {
+				u := NewItemWithdraw(mockItemDAO, &MockOfferDAO{}, &MockLikeDAO{}, &MockNotificationDAO{}, cache.NewEmbeddingCache(mockItemDAO), nil, statsCache)
+				return u.WithdrawItem(context.Background(), "item1", "seller1")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statsCache := cache.NewSellerStatsCache(time.Minute)
+			statsCache.Set("seller1", statsCache.Generation("seller1"), &model.SellerStats{ListingCount: 1})
+			statsCache.Set("other", statsCache.Generation("other"), &model.SellerStats{ListingCount: 1})
+
+			if err := tt.action(statsCache); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if _, ok := statsCache.Get("seller1"); ok {
+				t.Errorf("seller stats should be invalidated")
+			}
+			if _, ok := statsCache.Get("other"); !ok {
+				t.Errorf("other sellers' stats should remain")
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/dao"
+	"db/search"
+	"fmt"
+	"log"
+)
+
+// AuthAccountDeleter : 認証基盤(Firebase)のアカウントを削除する (*auth.Client が満たす)
+type AuthAccountDeleter interface {
+	DeleteUser(ctx context.Context, uid string) error
+}
+
+type UserDelete interface {
+	DeleteUser(ctx context.Context, id string) error
+}
+
+type userDelete struct {
+	userDAO        dao.UserDAO
+	embeddingCache *cache.EmbeddingCache
+	searchIndex    search.Index
+	authDeleter    AuthAccountDeleter
+}
+
+// NewUserDelete : UserDeleteの生成
+// searchIndexがnilなら取り下げた商品のインデックスからの削除を省く
+func NewUserDelete(userDAO dao.UserDAO, embeddingCache *cache.EmbeddingCache, searchIndex search.Index, authDeleter AuthAccountDeleter) UserDelete {
+	return &userDelete{userDAO: userDAO, embeddingCache: embeddingCache, searchIndex: searchIndex, authDeleter: authDeleter}
+}
+
+// DeleteUser : 退会する。ユーザー情報は匿名化し、販売中の商品は取り下げ、Firebaseのアカウントも削除する
+// 完了していない取引があれば退会できない (ErrUserHasOpenOrder)
+func (ud *userDelete) DeleteUser(ctx context.Context, id string) error {
+	withdrawnIDs, err := ud.userDAO.DeleteUser(ctx, id)
+	if err != nil {
+		return fmt.Errorf("fail:userDAO.DeleteUser:%w", err)
+	}
+
+	// 取り下げた商品をおすすめ・検索から外す
+	for _, itemID := range withdrawnIDs {
+		ud.embeddingCache.Delete(itemID)
+		if ud.searchIndex != nil {
+			ud.searchIndex.Remove(itemID)
+		}
+	}
+
+	// 同じアカウントで再ログイン・トークン更新できないようにする
+	// (失敗しても退会済みの行は更新・取得できないので退会自体は成功とする)
+	if err := ud.authDeleter.DeleteUser(ctx, id); err != nil {
+		log.Printf("Warning: failed to delete firebase user %s: %v", id, err)
+	}
+
+	return nil
+}
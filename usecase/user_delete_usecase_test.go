@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"db/cache"
+	"db/model"
+	"db/search"
+	"errors"
+	"testing"
+)
+
+// MockAuthAccountDeleter : AuthAccountDeleter のモック
+type MockAuthAccountDeleter struct {
+	DeleteUserFunc func(ctx context.Context, uid string) error
+}
+
+func (m *MockAuthAccountDeleter) DeleteUser(ctx context.Context, uid string) error {
+	if m.DeleteUserFunc != nil {
+		return m.DeleteUserFunc(ctx, uid)
+	}
+	return nil
+}
+
+func TestUserDelete_DeleteUser(t *testing.T) {
+	tests := []struct {
+		name        string
+		deleteErr   error
+		wantErr     error
+		wantEvicted bool
+	}{
+		{name: "成功: 取り下げた商品をおすすめ・検索から外す", wantEvicted: true},
+		{name: "失敗: 完了していない取引がある", deleteErr: model.ErrUserHasOpenOrder, wantErr: model.ErrUserHasOpenOrder},
+		{name: "失敗: 退会済み", deleteErr: model.ErrUserNotFound, wantErr: model.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockItemDAO := &MockItemDAO{
+				GetAllItemEmbeddingsFunc: func(ctx context.Context) (map[string][]float32, error) {
+					return map[string][]float32{"item1": {0.1, 0.2}, "item2": {0.3, 0.4}}, nil
+				},
+				GetSearchDocumentsFunc: func(ctx context.Context) ([]model.ItemSearchDocument, error) {
+					return []model.ItemSearchDocument{{ItemId: "item1", Name: "Test Item"}}, nil
+				},
+			}
+			mockUserDAO := &MockUserDAO{
+				DeleteUserFunc: func(ctx context.Context, id string) ([]string, error) {
+					if tt.deleteErr != nil {
+						return nil, tt.deleteErr
+					}
+					return []string{"item1"}, nil
+				},
+			}
+			var authDeleted string
+			mockAuthDeleter := &MockAuthAccountDeleter{
+				DeleteUserFunc: func(ctx context.Context, uid string) error {
+					authDeleted = uid
+					return nil
+				},
+			}
+			embeddingCache := cache.NewEmbeddingCache(mockItemDAO)
+			searchIndex := search.NewInvertedIndex(mockItemDAO)
+
+			u := NewUserDelete(mockUserDAO, embeddingCache, searchIndex, mockAuthDeleter)
+			err := u.DeleteUser(context.Background(), "user1")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteUser() error = %v, want %v", err, tt.wantErr)
+			}
+			// 退会できたときだけFirebaseのアカウントも削除する
+			if (authDeleted == "user1") != (tt.wantErr == nil) {
+				t.Errorf("firebase user deleted = %q, want deleted %v", authDeleted, tt.wantErr == nil)
+			}
+			if _, ok := embeddingCache.Get()["item1"]; ok == tt.wantEvicted {
+				t.Errorf("embedding evicted = %v, want %v", !ok, tt.wantEvicted)
+			}
+			if _, ok := embeddingCache.Get()["item2"]; !ok {
+				t.Errorf("other items should remain in cache")
+			}
+			hits, _ := searchIndex.Search(context.Background(), "Test Item", 10)
+			if (len(hits) == 0) != tt.wantEvicted {
+				t.Errorf("search hits = %v, want evicted %v", hits, tt.wantEvicted)
+			}
+		})
+	}
+}
+
+func TestUserExport_ExportUser(t *testing.T) {
+	mockUserDAO := &MockUserDAO{
+		GetUserFunc: func(ctx context.Context, id string) (*model.User, error) {
+			return &model.User{Id: id, Name: "Test User"}, nil
+		},
+	}
+	mockItemDAO := &MockItemDAO{
+		GetMyItemsFunc: func(ctx context.Context, sellerID string) ([]model.ItemSimple, error) {
+			return []model.ItemSimple{{ItemId: "item1"}, {ItemId: "item2", Status: model.StatusWithdrawn}}, nil
+		},
+	}
+	mockLikeDAO := &MockLikeDAO{
+		GetLikedItemsFunc: func(ctx context.Context, userID string) ([]model.ItemSimple, error) {
+			return []model.ItemSimple{{ItemId: "item3"}}, nil
+		},
+	}
+	mockChatDAO := &MockChatDAO{
+		GetUserMessagesFunc: func(ctx context.Context, userID string) ([]model.Message, error) {
+			return []model.Message{{Id: "msg1", SenderId: userID}}, nil
+		},
+	}
+	var notificationLimit int
+	mockNotificationDAO := &MockNotificationDAO{
+		GetUserNotificationsFunc: func(ctx context.Context, userId string, limit int) ([]model.Notification, error) {
+			notificationLimit = limit
+			return []model.Notification{{Id: "n1", UserId: userId}}, nil
+		},
+	}
+
+	u := NewUserExport(mockUserDAO, mockItemDAO, mockLikeDAO, mockChatDAO, mockNotificationDAO)
+	export, err := u.ExportUser(context.Background(), "user1")
+	if err != nil {
+		t.Fatalf("ExportUser() error = %v", err)
+	}
+
+	if export.Profile.Id != "user1" || len(export.Items) != 2 || len(export.Likes) != 1 || len(export.Messages) != 1 || len(export.Notifications) != 1 {
+		t.Errorf("ExportUser() = %+v", export)
+	}
+	if notificationLimit != exportNotificationLimit {
+		t.Errorf("notification limit = %d, want %d", notificationLimit, exportNotificationLimit)
+	}
+	if export.ExportedAt.IsZero() {
+		t.Errorf("ExportedAt should be set")
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"db/dao"
+	"db/model"
+	"fmt"
+	"time"
+)
+
+// exportNotificationLimit : エクスポートに含める通知の上限 (新しい順)
+const exportNotificationLimit = 10000
+
+type UserExport interface {
+	ExportUser(ctx context.Context, id string) (*model.UserExport, error)
+}
+
+type userExport struct {
+	userDAO         dao.UserDAO
+	itemDAO         dao.ItemDAO
+	likeDAO         dao.LikeDAO
+	chatDAO         dao.ChatDAO
+	notificationDAO dao.NotificationDAO
+}
+
+// NewUserExport : UserExportの生成
+func NewUserExport(userDAO dao.UserDAO, itemDAO dao.ItemDAO, likeDAO dao.LikeDAO, chatDAO dao.ChatDAO, notificationDAO dao.NotificationDAO) UserExport {
+	return &userExport{
+		userDAO:         userDAO,
+		itemDAO:         itemDAO,
+		likeDAO:         likeDAO,
+		chatDAO:         chatDAO,
+		notificationDAO: notificationDAO,
+	}
+}
+
+// ExportUser : プロフィール・出品した商品・いいねした商品・送信したメッセージ・通知をまとめて返す
+func (ue *userExport) ExportUser(ctx context.Context, id string) (*model.UserExport, error) {
+	user, err := ue.userDAO.GetUser(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("fail:userDAO.GetUser:%w", err)
+	}
+
+	// 取り下げ済み・売却済みを含むすべての出品
+	items, err := ue.itemDAO.GetMyItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("fail:itemDAO.GetMyItems:%w", err)
+	}
+
+	likes, err := ue.likeDAO.GetLikedItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("fail:likeDAO.GetLikedItems:%w", err)
+	}
+
+	messages, err := ue.chatDAO.GetUserMessages(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("fail:chatDAO.GetUserMessages:%w", err)
+	}
+
+	notifications, err := ue.notificationDAO.GetUserNotifications(ctx, id, exportNotificationLimit)
+	if err != nil {
+		return nil, fmt.Errorf("fail:notificationDAO.GetUserNotifications:%w", err)
+	}
+
+	return &model.UserExport{
+		ExportedAt:    time.Now(),
+		Profile:       user,
+		Items:         items,
+		Likes:         likes,
+		Messages:      messages,
+		Notifications: notifications,
+	}, nil
+}
@@ -5,26 +5,68 @@ import (
 	"db/dao"
 	"db/model"
 	"fmt"
+	"log"
+	"math"
 )
 
 type UserGet interface {
 	GetUser(ctx context.Context, id string) (*model.User, error)
+	GetUserByHandle(ctx context.Context, handle string) (*model.User, error)
 }
 
 type userGet struct {
-	userDAO dao.UserDAO
+	userDAO   dao.UserDAO
+	reviewDAO dao.ReviewDAO
+	followDAO dao.FollowDAO
 }
 
-func NewUserGet(userDAO dao.UserDAO) UserGet {
-	return &userGet{userDAO: userDAO}
+func NewUserGet(userDAO dao.UserDAO, reviewDAO dao.ReviewDAO, followDAO dao.FollowDAO) UserGet {
+	return &userGet{userDAO: userDAO, reviewDAO: reviewDAO, followDAO: followDAO}
 }
 
-// GetUser : 指定されたIDのユーザーを取得
+// GetUser : 指定されたIDのユーザーを取得 (受けた評価の平均と件数、フォロワー数・フォロー数を含む)
 func (ug *userGet) GetUser(ctx context.Context, id string) (*model.User, error) {
 	user, err := ug.userDAO.GetUser(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("fail:userDAO.GetUser:%w", err)
 	}
 
+	ug.attachSummary(ctx, user)
 	return user, nil
 }
+
+// GetUserByHandle : 指定されたハンドル (@は省略可、大文字小文字を区別しない) のユーザーを取得
+func (ug *userGet) GetUserByHandle(ctx context.Context, handle string) (*model.User, error) {
+	handle = model.NormalizeHandle(handle)
+	if !model.IsValidHandle(handle) {
+		return nil, model.ErrUserNotFound
+	}
+
+	user, err := ug.userDAO.GetUserByHandle(ctx, handle)
+	if err != nil {
+		return nil, fmt.Errorf("fail:userDAO.GetUserByHandle:%w", err)
+	}
+
+	ug.attachSummary(ctx, user)
+	return user, nil
+}
+
+// attachSummary : 受けた評価の平均と件数、フォロワー数・フォロー数を付ける
+func (ug *userGet) attachSummary(ctx context.Context, user *model.User) {
+	// 集計に失敗してもユーザー情報は返す
+	summary, err := ug.reviewDAO.GetRatingSummary(ctx, user.Id)
+	if err != nil {
+		log.Printf("Warning: failed to get rating summary: %v\n", err)
+	} else {
+		user.RatingAverage = math.Round(summary.Average*10) / 10
+		user.ReviewCount = summary.Count
+	}
+
+	counts, err := ug.followDAO.GetFollowCounts(ctx, user.Id)
+	if err != nil {
+		log.Printf("Warning: failed to get follow counts: %v\n", err)
+	} else {
+		user.FollowerCount = counts.Followers
+		user.FollowingCount = counts.Following
+	}
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"testing"
+)
+
+func TestUserGet_GetUserByHandle(t *testing.T) {
+	tests := []struct {
+		name       string
+		handle     string
+		wantLookup string
+		wantErr    error
+	}{
+		{name: "成功: そのまま", handle: "tanaka_shop", wantLookup: "tanaka_shop"},
+		{name: "成功: @付き・大文字は正規化して検索", handle: "@Tanaka_Shop", wantLookup: "tanaka_shop"},
+		{name: "失敗: 存在しないハンドル", handle: "unknown_user", wantLookup: "unknown_user", wantErr: model.ErrUserNotFound},
+		{name: "失敗: 使えないハンドルは検索しない", handle: "tanaka-shop", wantErr: model.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookup string
+			mockUserDAO := &MockUserDAO{
+				GetUserByHandleFunc: func(ctx context.Context, handle string) (*model.User, error) {
+					lookup = handle
+					if handle != "tanaka_shop" {
+						return nil, model.ErrUserNotFound
+					}
+					return &model.User{Id: "user1", Name: "Taro", Handle: handle}, nil
+				},
+			}
+			mockReviewDAO := &MockReviewDAO{
+				GetRatingSummaryFunc: func(ctx context.Context, userID string) (*model.RatingSummary, error) {
+					return &model.RatingSummary{Average: 4.25, Count: 4}, nil
+				},
+			}
+			mockFollowDAO := &MockFollowDAO{
+				GetFollowCountsFunc: func(ctx context.Context, userID string) (*model.FollowCounts, error) {
+					return &model.FollowCounts{Followers: 3, Following: 1}, nil
+				},
+			}
+
+			u := NewUserGet(mockUserDAO, mockReviewDAO, mockFollowDAO)
+			user, err := u.GetUserByHandle(context.Background(), tt.handle)
+
+			if lookup != tt.wantLookup {
+				t.Errorf("looked up handle = %q, want %q", lookup, tt.wantLookup)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetUserByHandle() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			// IDで取得した場合と同じ集計を付ける
+			if user.Id != "user1" || user.RatingAverage != 4.3 || user.ReviewCount != 4 || user.FollowerCount != 3 {
+				t.Errorf("GetUserByHandle() = %+v", user)
+			}
+		})
+	}
+}
@@ -19,8 +19,10 @@ func NewUserRegister(us dao.UserDAO) UserRegister {
 	return &userRegister{userDAO: us}
 }
 
+// Register : ユーザーを登録 (ハンドルが使用済みならErrHandleTaken)
 func (us *userRegister) Register(ctx context.Context, uid string, req *model.UserCreateRequest) error {
 
+	req.Handle = model.NormalizeHandle(req.Handle)
 	if !req.IsValid() {
 		return model.ErrInvalidRequest
 	}
@@ -28,6 +30,7 @@ func (us *userRegister) Register(ctx context.Context, uid string, req *model.Use
 	newUser := model.User{
 		Id:      uid,
 		Name:    req.Name,
+		Handle:  req.Handle,
 		Age:     req.Age,
 		Email:   req.Email,
 		IconURL: req.IconURL,
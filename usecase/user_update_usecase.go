@@ -20,8 +20,9 @@ func NewUserUpdate(userDAO dao.UserDAO) UserUpdate {
 	return &userUpdate{userDAO: userDAO}
 }
 
-// UpdateUser : ユーザー情報を更新
+// UpdateUser : ユーザー情報を更新 (ハンドルは空なら変更せず、ClearHandleなら削除する。使用済みならErrHandleTaken)
 func (uu *userUpdate) UpdateUser(ctx context.Context, id string, req *model.UserUpdateRequest) error {
+	req.Handle = model.NormalizeHandle(req.Handle)
 	if !req.IsValid() {
 		return model.ErrInvalidRequest
 	}
@@ -30,12 +31,13 @@ func (uu *userUpdate) UpdateUser(ctx context.Context, id string, req *model.User
 	user := &model.User{
 		Id:      id,
 		Name:    req.Name,
+		Handle:  req.Handle,
 		Age:     req.Age,
 		Bio:     req.Bio,
 		IconURL: req.IconURL,
 	}
 
-	err := uu.userDAO.UpdateUser(ctx, user)
+	err := uu.userDAO.UpdateUser(ctx, user, req.ClearHandle)
 	if err != nil {
 		return fmt.Errorf("fail:userDAO.UpdateUser:%w", err)
 	}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"db/model"
+	"errors"
+	"testing"
+)
+
+func TestUserUpdate_UpdateUser_Handle(t *testing.T) {
+	tests := []struct {
+		name       string
+		handle     string
+		clear      bool
+		updateErr  error
+		wantHandle string
+		wantClear  bool
+		wantErr    error
+	}{
+		{name: "成功: ハンドルを正規化して保存", handle: " @Tanaka_Shop ", wantHandle: "tanaka_shop"},
+		{name: "成功: 空ならハンドルは変更しない", handle: "", wantHandle: ""},
+		{name: "成功: ハンドルを削除", clear: true, wantClear: true},
+		{name: "失敗: 削除と変更を同時に指定", handle: "tanaka_shop", clear: true, wantErr: model.ErrInvalidRequest},
+		{name: "失敗: 予約語", handle: "admin", wantErr: model.ErrInvalidRequest},
+		{name: "失敗: 使えない文字", handle: "田中", wantErr: model.ErrInvalidRequest},
+		{name: "失敗: 使用済み", handle: "sato", updateErr: model.ErrHandleTaken, wantHandle: "sato", wantErr: model.ErrHandleTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated *model.User
+			var cleared bool
+			mockUserDAO := &MockUserDAO{
+				UpdateUserFunc: func(ctx context.Context, user *model.User, clearHandle bool) error {
+					updated = user
+					cleared = clearHandle
+					return tt.updateErr
+				},
+			}
+
+			u := NewUserUpdate(mockUserDAO)
+			err := u.UpdateUser(context.Background(), "user1", &model.UserUpdateRequest{Name: "Taro", Age: -1, Handle: tt.handle, ClearHandle: tt.clear})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateUser() error = %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(tt.wantErr, model.ErrInvalidRequest) {
+				if updated != nil {
+					t.Errorf("UpdateUser() should not update on invalid handle")
+				}
+				return
+			}
+			if updated.Handle != tt.wantHandle || cleared != tt.wantClear {
+				t.Errorf("saved handle = %q clear = %v, want %q %v", updated.Handle, cleared, tt.wantHandle, tt.wantClear)
+			}
+		})
+	}
+}